@@ -1,10 +1,12 @@
 package telego
 
 import (
+	"context"
 	"errors"
-	"os"
+	"io"
 
 	objs "github.com/SakoDroid/telego/objects"
+	upp "github.com/SakoDroid/telego/parser"
 )
 
 /*AdvancedBot is an advanced type of bot which will give you alot more customization for the bot.
@@ -20,11 +22,16 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *AdvancedBot) ASendMessage(chatId int, text, parseMode string, replyTo int, silent, protectContent bool, entites []objs.MessageEntity, disabelWebPagePreview, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendMessageContext(context.Background(), chatId, text, parseMode, replyTo, silent, protectContent, entites, disabelWebPagePreview, allowSendingWithoutReply, keyboard)
+}
+
+/*ASendMessageContext works like "ASendMessage" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendMessageContext(ctx context.Context, chatId int, text, parseMode string, replyTo int, silent, protectContent bool, entites []objs.MessageEntity, disabelWebPagePreview, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendMessage(chatId, "", text, parseMode, entites, disabelWebPagePreview,
+	return bot.bot.apiInterface.SendMessageContext(ctx, chatId, "", text, parseMode, entites, disabelWebPagePreview,
 		silent, allowSendingWithoutReply, protectContent, replyTo, replyMarkup)
 }
 
@@ -35,11 +42,16 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *AdvancedBot) ASendMesssageUN(chatId, text, parseMode string, replyTo int, silent, protectContent bool, entites []objs.MessageEntity, disabelWebPagePreview, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendMesssageUNContext(context.Background(), chatId, text, parseMode, replyTo, silent, protectContent, entites, disabelWebPagePreview, allowSendingWithoutReply, keyboard)
+}
+
+/*ASendMesssageUNContext works like "ASendMesssageUN" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendMesssageUNContext(ctx context.Context, chatId, text, parseMode string, replyTo int, silent, protectContent bool, entites []objs.MessageEntity, disabelWebPagePreview, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendMessage(0, chatId, text, parseMode, entites, disabelWebPagePreview,
+	return bot.bot.apiInterface.SendMessageContext(ctx, 0, chatId, text, parseMode, entites, disabelWebPagePreview,
 		silent, allowSendingWithoutReply, protectContent, replyTo, replyMarkup)
 }
 
@@ -276,7 +288,7 @@ func (bot *AdvancedBot) ACreateAlbum(replyTo int, allowSendingWihtoutReply bool,
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaGroup{replyTo: replyTo, bot: bot.bot, media: make([]objs.InputMedia, 0), files: make([]*os.File, 0), allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
+	return &MediaGroup{replyTo: replyTo, bot: bot.bot, media: make([]objs.InputMedia, 0), files: make([]io.Reader, 0), allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
 }
 
 /*ASendVenue sends a venue to all types of chat but channels. To send it to channels use "SendVenueUN" method.
@@ -291,11 +303,16 @@ Official telegram doc :
 
 Use this method to send information about a venue. On success, the sent Message is returned.*/
 func (bot *AdvancedBot) ASendVenue(chatId, replyTo int, latitude, longitude float32, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType string, silent bool, allowSendingWihtoutReply, protectContent bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendVenueContext(context.Background(), chatId, replyTo, latitude, longitude, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType, silent, allowSendingWihtoutReply, protectContent, keyboard)
+}
+
+/*ASendVenueContext works like "ASendVenue" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendVenueContext(ctx context.Context, chatId, replyTo int, latitude, longitude float32, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType string, silent bool, allowSendingWihtoutReply, protectContent bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendVenue(
+	return bot.bot.apiInterface.SendVenueContext(ctx,
 		chatId, "", latitude, longitude, title, address, foursquareId, foursquareType,
 		googlePlaceId, googlePlaceType, replyTo, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
@@ -313,11 +330,16 @@ Official telegram doc :
 
 Use this method to send information about a venue. On success, the sent Message is returned.*/
 func (bot *AdvancedBot) ASendVenueUN(chatId string, replyTo int, latitude, longitude float32, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendVenueUNContext(context.Background(), chatId, replyTo, latitude, longitude, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType, silent, protectContent, allowSendingWihtoutReply, keyboard)
+}
+
+/*ASendVenueUNContext works like "ASendVenueUN" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendVenueUNContext(ctx context.Context, chatId string, replyTo int, latitude, longitude float32, title, address, foursquareId, foursquareType, googlePlaceId, googlePlaceType string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendVenue(
+	return bot.bot.apiInterface.SendVenueContext(ctx,
 		0, chatId, latitude, longitude, title, address, foursquareId, foursquareType,
 		googlePlaceId, googlePlaceType, replyTo, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
@@ -335,11 +357,16 @@ Official telegram doc :
 
 Use this method to send phone contacts. On success, the sent Message is returned.*/
 func (bot *AdvancedBot) ASendContact(chatId, replyTo int, phoneNumber, firstName, lastName, vCard string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendContactContext(context.Background(), chatId, replyTo, phoneNumber, firstName, lastName, vCard, silent, protectContent, allowSendingWihtoutReply, keyboard)
+}
+
+/*ASendContactContext works like "ASendContact" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendContactContext(ctx context.Context, chatId, replyTo int, phoneNumber, firstName, lastName, vCard string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendContact(
+	return bot.bot.apiInterface.SendContactContext(ctx,
 		chatId, "", phoneNumber, firstName, lastName, vCard, replyTo, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
@@ -356,11 +383,16 @@ Official telegram doc :
 
 Use this method to send phone contacts. On success, the sent Message is returned.*/
 func (bot *AdvancedBot) ASendContactUN(chatId string, replyTo int, phoneNumber, firstName, lastName, vCard string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendContactUNContext(context.Background(), chatId, replyTo, phoneNumber, firstName, lastName, vCard, silent, protectContent, allowSendingWihtoutReply, keyboard)
+}
+
+/*ASendContactUNContext works like "ASendContactUN" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendContactUNContext(ctx context.Context, chatId string, replyTo int, phoneNumber, firstName, lastName, vCard string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendContact(
+	return bot.bot.apiInterface.SendContactContext(ctx,
 		0, chatId, phoneNumber, firstName, lastName, vCard, replyTo, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
@@ -379,11 +411,16 @@ Official telegram doc :
 
 Use this method to send an animated emoji that will display a random value. On success, the sent Message is returned*/
 func (bot *AdvancedBot) ASendDice(chatId, replyTo int, emoji string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendDiceContext(context.Background(), chatId, replyTo, emoji, silent, protectContent, allowSendingWihtoutReply, keyboard)
+}
+
+/*ASendDiceContext works like "ASendDice" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendDiceContext(ctx context.Context, chatId, replyTo int, emoji string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendDice(
+	return bot.bot.apiInterface.SendDiceContext(ctx,
 		chatId, "", emoji, replyTo, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
@@ -402,11 +439,16 @@ Official telegram doc :
 
 Use this method to send an animated emoji that will display a random value. On success, the sent Message is returned*/
 func (bot *AdvancedBot) ASendDiceUN(chatId string, replyTo int, emoji string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendDiceUNContext(context.Background(), chatId, replyTo, emoji, silent, protectContent, allowSendingWihtoutReply, keyboard)
+}
+
+/*ASendDiceUNContext works like "ASendDiceUN" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendDiceUNContext(ctx context.Context, chatId string, replyTo int, emoji string, silent, protectContent bool, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendDice(
+	return bot.bot.apiInterface.SendDiceContext(ctx,
 		0, chatId, emoji, replyTo, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
@@ -434,11 +476,16 @@ Official telegram doc :
 
 Use this method to send point on the map. On success, the sent Message is returned.*/
 func (bot *AdvancedBot) ASendLocation(chatId int, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendLocationContext(context.Background(), chatId, silent, protectContent, latitude, longitude, accuracy, replyTo, allowSendingWihtoutReply, keyboard)
+}
+
+/*ASendLocationContext works like "ASendLocation" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendLocationContext(ctx context.Context, chatId int, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendLocation(
+	return bot.bot.apiInterface.SendLocationContext(ctx,
 		chatId, "", latitude, longitude, accuracy, 0, 0, 0, replyTo, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
@@ -457,11 +504,16 @@ Official telegram doc :
 
 Use this method to send point on the map. On success, the sent Message is returned.*/
 func (bot *AdvancedBot) ASendLocationUN(chatId string, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendLocationUNContext(context.Background(), chatId, silent, protectContent, latitude, longitude, accuracy, replyTo, allowSendingWihtoutReply, keyboard)
+}
+
+/*ASendLocationUNContext works like "ASendLocationUN" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendLocationUNContext(ctx context.Context, chatId string, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int, allowSendingWihtoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendLocation(
+	return bot.bot.apiInterface.SendLocationContext(ctx,
 		0, chatId, latitude, longitude, accuracy, 0, 0, 0, replyTo, silent, allowSendingWihtoutReply, protectContent, replyMarkup,
 	)
 }
@@ -470,7 +522,13 @@ func (bot *AdvancedBot) ASendLocationUN(chatId string, silent, protectContent bo
 
 Alternatively, the user can be redirected to the specified Game URL. For this option to work, you must first create a game for your bot via @Botfather and accept the terms. Otherwise, you may use links like t.me/your_bot?start=XXXX that open your bot with a parameter.*/
 func (bot *AdvancedBot) AAnswerCallbackQuery(callbackQueryId, text string, showAlert bool, url string, cacheTime int) (*objs.LogicalResult, error) {
-	return bot.bot.apiInterface.AnswerCallbackQuery(callbackQueryId, text, url, showAlert, cacheTime)
+	return bot.AAnswerCallbackQueryContext(context.Background(), callbackQueryId, text, showAlert, url, cacheTime)
+}
+
+/*AAnswerCallbackQueryContext works like "AAnswerCallbackQuery" but sends the request with the given context.*/
+func (bot *AdvancedBot) AAnswerCallbackQueryContext(ctx context.Context, callbackQueryId, text string, showAlert bool, url string, cacheTime int) (*objs.LogicalResult, error) {
+	bot.bot.pendingCallbacks.Delete(callbackQueryId)
+	return bot.bot.apiInterface.AnswerCallbackQueryContext(ctx, callbackQueryId, text, url, showAlert, cacheTime)
 }
 
 /*AAnswerInlineQuery returns an InlineQueryResponder which has several methods for answering an inline query.
@@ -523,11 +581,16 @@ Official telegram doc :
 
 Use this method to send a game. On success, the sent Message is returned.*/
 func (bot *AdvancedBot) ASendGame(chatId int, gameShortName string, silent bool, replyTo int, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
+	return bot.ASendGameContext(context.Background(), chatId, gameShortName, silent, replyTo, allowSendingWithoutReply, keyboard)
+}
+
+/*ASendGameContext works like "ASendGame" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASendGameContext(ctx context.Context, chatId int, gameShortName string, silent bool, replyTo int, allowSendingWithoutReply bool, keyboard MarkUps) (*objs.SendMethodsResult, error) {
 	var replyMarkup objs.ReplyMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return bot.bot.apiInterface.SendGame(
+	return bot.bot.apiInterface.SendGameContext(ctx,
 		chatId, gameShortName, silent, replyTo, allowSendingWithoutReply, replyMarkup,
 	)
 }
@@ -549,7 +612,12 @@ Use this method to set the score of the specified user in a game message. On suc
 "inlineMessageId" : Required if chat_id and message_id are not specified. Identifier of the inline message.
 */
 func (bot *AdvancedBot) ASetGameScore(userId, score, chatId, messageId int, force, disableEditMessage bool, inlineMessageId string) (*objs.DefaultResult, error) {
-	return bot.bot.apiInterface.SetGameScore(
+	return bot.ASetGameScoreContext(context.Background(), userId, score, chatId, messageId, force, disableEditMessage, inlineMessageId)
+}
+
+/*ASetGameScoreContext works like "ASetGameScore" but sends the request with the given context.*/
+func (bot *AdvancedBot) ASetGameScoreContext(ctx context.Context, userId, score, chatId, messageId int, force, disableEditMessage bool, inlineMessageId string) (*objs.DefaultResult, error) {
+	return bot.bot.apiInterface.SetGameScoreContext(ctx,
 		userId, score, force, disableEditMessage, chatId, messageId, inlineMessageId,
 	)
 }
@@ -558,19 +626,34 @@ func (bot *AdvancedBot) ASetGameScore(userId, score, chatId, messageId int, forc
 
 Use this if the data submitted by the user doesn't satisfy the standards your service requires for any reason. For example, if a birthday date seems invalid, a submitted document is blurry, a scan shows evidence of tampering, etc. Supply some details in the error message to make sure the user knows how to correct the issues.*/
 func (bot *AdvancedBot) SetPassportDataErrors(userId int, errors []objs.PassportElementError) (*objs.LogicalResult, error) {
-	return bot.bot.apiInterface.SetPassportDataErrors(
+	return bot.SetPassportDataErrorsContext(context.Background(), userId, errors)
+}
+
+/*SetPassportDataErrorsContext works like "SetPassportDataErrors" but sends the request with the given context.*/
+func (bot *AdvancedBot) SetPassportDataErrorsContext(ctx context.Context, userId int, errors []objs.PassportElementError) (*objs.LogicalResult, error) {
+	return bot.bot.apiInterface.SetPassportDataErrorsContext(ctx,
 		userId, errors,
 	)
 }
 
 /*SetMyDefaultAdministratorRights as describe by telegram official doc : Use this method to change the default administrator rights requested by the bot when it's added as an administrator to groups or channels. These rights will be suggested to users, but they are are free to modify the list before adding the bot. Returns True on success.*/
 func (bot *AdvancedBot) SetMyDefaultAdministratorRights(forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
-	return bot.bot.apiInterface.SetMyDefaultAdministratorRights(forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages)
+	return bot.SetMyDefaultAdministratorRightsContext(context.Background(), forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages)
+}
+
+/*SetMyDefaultAdministratorRightsContext works like "SetMyDefaultAdministratorRights" but sends the request with the given context.*/
+func (bot *AdvancedBot) SetMyDefaultAdministratorRightsContext(ctx context.Context, forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
+	return bot.bot.apiInterface.SetMyDefaultAdministratorRightsContext(ctx, forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages)
 }
 
 /*GetMyDefaultAdministratorRights as describe by telegram official doc : Use this method to get the current default administrator rights of the bot. Returns ChatAdministratorRights on success.*/
 func (bot *AdvancedBot) GetMyDefaultAdministratorRights(forChannels bool) (*objs.ChatAdministratorRightsResult, error) {
-	return bot.bot.apiInterface.GetMyDefaultAdministratorRights(forChannels)
+	return bot.GetMyDefaultAdministratorRightsContext(context.Background(), forChannels)
+}
+
+/*GetMyDefaultAdministratorRightsContext works like "GetMyDefaultAdministratorRights" but sends the request with the given context.*/
+func (bot *AdvancedBot) GetMyDefaultAdministratorRightsContext(ctx context.Context, forChannels bool) (*objs.ChatAdministratorRightsResult, error) {
+	return bot.bot.apiInterface.GetMyDefaultAdministratorRightsContext(ctx, forChannels)
 }
 
 /*RegisterChannel can be used to register special channels. Sepcial channels can be used to get only certain types of updates through them. For example you can register a channel to only receive messages or register a channel to only receive edited messages in a certain chat.
@@ -634,22 +717,48 @@ func (bot *AdvancedBot) RegisterChannel(chatId, mediaType string) (*chan *objs.U
 
 /*UnRegisterChannel can be used to unregister a channel for the given arguments*/
 func (bot *AdvancedBot) UnRegisterChannel(chatId, mediaType string) {
+	bot.bot.channelsLock.Lock()
+	defer bot.bot.channelsLock.Unlock()
 	if bot.bot.channelsMap[chatId] != nil {
-		bot.bot.channelsMap[chatId][mediaType] = nil
+		delete(bot.bot.channelsMap[chatId], mediaType)
 		if len(bot.bot.channelsMap[chatId]) == 0 {
 			delete(bot.bot.channelsMap, chatId)
 		}
 	}
 }
 
+/*RegisterFilteredChannel returns a channel that is updated with the updates that the given filter accepts. Filters can be created and combined using the functions of the parser package (like parser.And(parser.ChatTypes("group"), parser.HasPhoto())) and "FromAdmin" method of the bot.
+
+Filtered channels are checked before the channels registered with "RegisterChannel", in the order they have been registered. An update is passed only into the first channel whose filter accepts it. Updates that are processed by the handlers don't reach the channels.*/
+func (bot *AdvancedBot) RegisterFilteredChannel(filter upp.Filter) *chan *objs.Update {
+	ch := bot.bot.newChannel()
+	bot.bot.channelsLock.Lock()
+	defer bot.bot.channelsLock.Unlock()
+	bot.bot.filteredChannels = append(bot.bot.filteredChannels, &filteredChannel{filter: filter, channel: ch})
+	return ch
+}
+
+/*UnRegisterFilteredChannel unregisters the given channel which has been registered with "RegisterFilteredChannel".*/
+func (bot *AdvancedBot) UnRegisterFilteredChannel(ch *chan *objs.Update) {
+	bot.bot.channelsLock.Lock()
+	defer bot.bot.channelsLock.Unlock()
+	for i, fc := range bot.bot.filteredChannels {
+		if fc.channel == ch {
+			bot.bot.filteredChannels = append(bot.bot.filteredChannels[:i:i], bot.bot.filteredChannels[i+1:]...)
+			return
+		}
+	}
+}
+
 func (bot *AdvancedBot) getChannel(chatId, media string) *chan *objs.Update {
+	bot.bot.channelsLock.Lock()
+	defer bot.bot.channelsLock.Unlock()
 	if bot.bot.channelsMap[chatId] == nil {
 		bot.bot.channelsMap[chatId] = make(map[string]*chan *objs.Update)
 	}
 	out := bot.bot.channelsMap[chatId][media]
 	if out == nil {
-		tmp := make(chan *objs.Update)
-		out = &tmp
+		out = bot.bot.newChannel()
 		bot.bot.channelsMap[chatId][media] = out
 	}
 	return out
@@ -1,13 +1,21 @@
 package telego
 
 import (
+	"context"
 	"errors"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	cfg "github.com/SakoDroid/telego/configs"
+	errs "github.com/SakoDroid/telego/errors"
 	logger "github.com/SakoDroid/telego/logger"
 	objs "github.com/SakoDroid/telego/objects"
 	upp "github.com/SakoDroid/telego/parser"
+	ss "github.com/SakoDroid/telego/session"
 	tba "github.com/SakoDroid/telego/tba"
 )
 
@@ -15,37 +23,180 @@ type Bot struct {
 	botCfg                 *cfg.BotConfigs
 	apiInterface           *tba.BotAPIInterface
 	channelsMap            map[string]map[string]*chan *objs.Update
+	channelsLock           sync.RWMutex //Guards channelsMap and filteredChannels.
 	interfaceUpdateChannel *chan *objs.Update
 	chatUpdateChannel      *chan *objs.ChatUpdate
-	prcRoutineChannel      *chan bool
+	runLock                sync.Mutex
+	cancelRun              context.CancelFunc
+	handlerCtx             context.Context //Context of the context handlers. It is cancelled after the running handlers are waited for, so they can finish their requests during the shutdown.
+	sessions               *ss.Manager
+	sessionsLock           sync.RWMutex //Guards sessions.
+	pendingCallbacks       sync.Map
+	filteredChannels       []*filteredChannel
+	admins                 adminCache
+	parser                 *upp.Parser
+	errorHandler           func(*objs.Update, error)
+	failureReply           string
+	errorLock              sync.RWMutex //Guards errorHandler and failureReply.
 	ab                     *AdvancedBot
 }
 
-/*Run starts the bot. If the bot has already been started it returns an error.*/
+/*Run starts the bot and blocks until "Stop" method is called. If the bot has already been started it returns an error.*/
 func (bot *Bot) Run(pause bool) error {
+	return bot.RunContext(context.Background())
+}
+
+/*RunContext starts the bot and blocks until the given context is cancelled or "Stop" method is called. If the bot has already been started it returns an error.
+
+When the bot is stopped, receiving updates (long polling or the webhook server) is stopped first, then the update processing routines are stopped and at last the handlers that are still running are waited for. "ShutdownTimeout" field of the bot configs limits the time spent on waiting for the webhook server and the running handlers.
+The returned error is nil if the bot has been shut down gracefully.*/
+func (bot *Bot) RunContext(ctx context.Context) error {
+	bot.runLock.Lock()
+	if bot.cancelRun != nil {
+		bot.runLock.Unlock()
+		return &errs.BotAlreadyRunning{}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	handlerCtx, cancelHandlers := context.WithCancel(context.Background())
+	bot.cancelRun = cancel
+	bot.handlerCtx = handlerCtx
+	bot.runLock.Unlock()
+	defer func() {
+		cancel()
+		cancelHandlers()
+		bot.runLock.Lock()
+		bot.cancelRun = nil
+		bot.handlerCtx = nil
+		bot.runLock.Unlock()
+	}()
 	logger.InitTheLogger(bot.botCfg)
-	if !bot.checkWebHook() {
-		logger.Logger.Fatalln("Webhook check failed. See the logs for more info.")
+	certChanged := false
+	if bot.botCfg.Webhook {
+		var err error
+		if certChanged, err = bot.apiInterface.PrepareCertificate(); err != nil {
+			return err
+		}
+	}
+	if !bot.checkWebHook(certChanged) {
+		return errors.New("webhook check failed. See the logs for more info")
+	}
+	bot.parser.StartWorkers(bot.botCfg.DispatcherConfigs)
+	defer bot.parser.StopWorkers()
+	//Updates waiting for an unread channel are dropped once the shutdown begins, so receiving the updates can be stopped.
+	bot.parser.SetContext(ctx)
+	defer bot.parser.SetContext(nil)
+	prcCtx, stopProcessing := context.WithCancel(context.Background())
+	defer stopProcessing()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		bot.startChatUpdateRoutine(prcCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		bot.startUpdateProcessing(prcCtx)
+	}()
+	if path, ok := bot.claimConfigFile(); ok {
+		if err := cfg.DumpFile(bot.botCfg, bot.botCfg.GetConfigFile()); err != nil {
+			logger.Logger.Println("Unable to save the configs.", err)
+		}
+		cfgCtx, stopReloading := context.WithCancel(ctx)
+		reloaded := make(chan bool)
+		go func() {
+			defer close(reloaded)
+			bot.botCfg.StartCfgUpdateRoutineContext(cfgCtx)
+		}()
+		defer func() {
+			stopReloading()
+			<-reloaded
+			releaseConfigFile(path)
+		}()
 	}
-	go bot.startChatUpdateRoutine()
-	go bot.startUpdateProcessing()
-	cfg.Dump(bot.botCfg)
-	go bot.botCfg.StartCfgUpdateRoutine()
 	var err error
 	if bot.botCfg.Webhook {
-		err = tba.StartWebHook(bot.botCfg, bot.interfaceUpdateChannel, bot.chatUpdateChannel)
+		err = bot.apiInterface.StartWebHook()
 	} else {
-		err = bot.apiInterface.StartUpdateRoutine()
+		err = bot.apiInterface.StartUpdateRoutineContext(ctx)
 	}
 	if err != nil {
+		stopProcessing()
+		wg.Wait()
 		return err
 	}
-	ch := make(chan bool)
-	<-ch
+	<-ctx.Done()
+	return bot.shutdown(stopProcessing, &wg)
+}
+
+/*configFiles holds the config files used by the running bots of this process, by their absolute paths. Each file is used by one bot at a time so the bots don't overwrite each other's configs.*/
+var configFiles = make(map[string]*Bot)
+var configFilesLock sync.Mutex
+
+/*claimConfigFile reserves the config file of the bot for it. Returns the absolute path of the file and false if the config file is disabled or is being used by another running bot.*/
+func (bot *Bot) claimConfigFile() (string, bool) {
+	if bot.botCfg.DisableConfigFile {
+		return "", false
+	}
+	path, err := filepath.Abs(bot.botCfg.GetConfigFile())
+	if err != nil {
+		logger.Logger.Println("Unable to use the config file.", err)
+		return "", false
+	}
+	configFilesLock.Lock()
+	defer configFilesLock.Unlock()
+	if other, ok := configFiles[path]; ok && other != bot {
+		logger.Logger.Println("The config file", path, "is used by another bot. The configs are neither saved nor reloaded. Set \"ConfigFile\" field of the configs to use a different file.")
+		return "", false
+	}
+	configFiles[path] = bot
+	return path, true
+}
+
+//releaseConfigFile lets other bots use the given config file.
+func releaseConfigFile(path string) {
+	configFilesLock.Lock()
+	delete(configFiles, path)
+	configFilesLock.Unlock()
+}
+
+func (bot *Bot) shutdown(stopProcessing context.CancelFunc, processingRoutines *sync.WaitGroup) error {
+	logger.Logger.Println("Stopping the bot ...")
+	sdCtx := context.Background()
+	if timeout := bot.botCfg.GetShutdownTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		sdCtx, cancel = context.WithTimeout(sdCtx, timeout)
+		defer cancel()
+	}
+	var err error
+	if bot.botCfg.Webhook {
+		err = bot.apiInterface.StopWebHook(sdCtx)
+	} else {
+		stopped := make(chan bool)
+		go func() {
+			bot.apiInterface.StopUpdateRoutine()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-sdCtx.Done():
+			err = errors.New("timed out waiting for the update routine to stop")
+		}
+	}
+	stopProcessing()
+	processingRoutines.Wait()
+	err2 := bot.parser.WaitForHandlers(sdCtx)
+	if err != nil {
+		return err
+	}
+	if err2 != nil {
+		return errors.New("timed out waiting for the running handlers to return")
+	}
+	logger.Logger.Println("Bot stopped.")
 	return nil
 }
 
-func (bot *Bot) checkWebHook() bool {
+/*checkWebHook sets or deletes the webhook in the API server based on the configs. "certChanged" is true if a new certificate has been generated for the webhook.*/
+func (bot *Bot) checkWebHook(certChanged bool) bool {
 	wi, err := bot.apiInterface.GetWebhookInfo()
 	if err != nil {
 		logger.Logger.Println(err)
@@ -53,7 +204,7 @@ func (bot *Bot) checkWebHook() bool {
 	}
 	if wi.Result.URL == "" {
 		if bot.botCfg.Webhook {
-			err2 := bot.setWebhook()
+			err2 := bot.setWebhook(true)
 			if err2 != nil {
 				logger.Logger.Println("Unable to set a new webhook.", err2)
 				return false
@@ -68,11 +219,18 @@ func (bot *Bot) checkWebHook() bool {
 					logger.Logger.Println("Unable to delete webhook.", err2)
 					return false
 				}
-				err3 := bot.setWebhook()
+				err3 := bot.setWebhook(true)
 				if err3 != nil {
 					logger.Logger.Println("Unable to set webhook.", err3)
 					return false
 				}
+			} else if bot.botCfg.WebHookConfigs.GenerateCertificate && (certChanged || !wi.Result.HasCustomCertificate) {
+				//The certificate has been renewed since the webhook was set. The pending updates are kept since the webhook itself has not changed.
+				err2 := bot.setWebhook(false)
+				if err2 != nil {
+					logger.Logger.Println("Unable to upload the certificate.", err2)
+					return false
+				}
 			}
 		} else {
 			logger.Logger.Println("A webhook has been set.")
@@ -86,19 +244,32 @@ func (bot *Bot) checkWebHook() bool {
 	return true
 }
 
-/*Sets a new webhook*/
-func (bot *Bot) setWebhook() error {
+/*WebhookHandler returns an http.Handler that processes the webhook requests of the bot, so the webhook can be served by your own HTTP server or router. It should be used with "ExternalServer" field of the webhook configs set to true, and can be mounted on any path (the URL in the webhook configs should point to it). The handler responds with 503 (Service Unavailable) while the bot is not running, so telegram sends the update again later.*/
+func (bot *Bot) WebhookHandler() http.Handler {
+	return bot.apiInterface.WebhookHandler()
+}
+
+/*RespondInWebhook answers the webhook request of the given update with a call of the given method, so the method is executed without sending a separate request to the API server. The result of the method is not returned. It works only if "ResponseTimeout" field of the webhook configs is set and must be called by a handler of the update before the timeout passes. Only one method can be sent for each update.
+
+Returns false if the response can't be set, in which case the method should be called normally.*/
+func (bot *Bot) RespondInWebhook(update *objs.Update, method string, args objs.MethodArguments) bool {
+	return bot.apiInterface.RespondInWebhook(update, method, args)
+}
+
+/*Sets a new webhook. Pending updates are dropped if "dropPending" is true and "DropPendingUpdates" field of the webhook configs is true.*/
+func (bot *Bot) setWebhook(dropPending bool) error {
 	logger.Logger.Println("Setting webhook ...")
 	whcfg := bot.botCfg.WebHookConfigs
-	var fl *os.File
+	var cert io.Reader
 	if whcfg.SelfSigned {
-		var err2 error
-		fl, err2 = os.Open(whcfg.CertFile)
+		fl, err2 := os.Open(whcfg.CertFile)
 		if err2 != nil {
 			return err2
 		}
+		defer fl.Close()
+		cert = fl
 	}
-	res, err3 := bot.apiInterface.SetWebhook(whcfg.URL, whcfg.IP, whcfg.MaxConnections, whcfg.AllowedUpdates, whcfg.DropPendingUpdates, fl)
+	res, err3 := bot.apiInterface.SetWebhook(whcfg.URL, whcfg.IP, whcfg.MaxConnections, whcfg.AllowedUpdates, dropPending && whcfg.DropPendingUpdates, cert)
 	if err3 != nil {
 		return err3
 	}
@@ -123,18 +294,62 @@ func (bot *Bot) deleteWebhook() error {
 
 //BlockUser blocks a user based on their ID and username.
 func (bot *Bot) BlockUser(user *objs.User) {
-	for _, us := range bot.botCfg.BlockedUsers {
-		if us.UserID == user.Id {
-			return
-		}
-	}
-	us := cfg.BlockedUser{UserID: user.Id, UserName: user.Username}
-	bot.botCfg.BlockedUsers = append(bot.botCfg.BlockedUsers, us)
+	bot.botCfg.AddBlockedUser(cfg.BlockedUser{UserID: user.Id, UserName: user.Username})
+}
+
+/*GetQueueDepth returns the number of requests waiting in the rate limiter queue to be sent. It is always 0 if "RateLimitConfigs" field of the bot configs is nil.*/
+func (bot *Bot) GetQueueDepth() int {
+	return bot.apiInterface.GetQueueDepth()
 }
 
 /*GetUpdateChannel returns the channel which new updates received from api server are pushed into.*/
 func (bot *Bot) GetUpdateChannel() *chan *objs.Update {
-	return bot.channelsMap["global"]["all"]
+	return bot.getChannel("global", "all")
+}
+
+/*AddContextHandler adds a handler for a text message that matches the given regex pattern and chatType. It works like "AddHandler" but the handler receives a Context which holds the update, the submatches of the regex and several helper methods. Errors returned by the handler are logged.
+
+"chatType" must be "private","group","supergroup","channel" or "all". Any other value will cause the function to return an error.*/
+func (bot *Bot) AddContextHandler(pattern string, handler func(*Context) error, chatTypes ...string) error {
+	return bot.AddContextHandlerWithOptions(pattern, handler, upp.HandlerOptions{}, chatTypes...)
+}
+
+/*AddContextHandlerWithOptions works like "AddContextHandler" but the priority and fallthrough options of the handler can be specified. Read the documentation of "AddHandlerWithOptions" for more information.*/
+func (bot *Bot) AddContextHandlerWithOptions(pattern string, handler func(*Context) error, options upp.HandlerOptions, chatTypes ...string) error {
+	err := checkChatTypes(chatTypes)
+	if err != nil {
+		return err
+	}
+	return bot.parser.AddMatchHandlerWithOptions(pattern, bot.matchHandler(handler), options, chatTypes...)
+}
+
+/*AddCommandHandler adds a context handler for a command with typed arguments. "syntax" is like "/ban <user:int> [reason]" where arguments in <> are required and arguments in [] are optional. Type of an argument comes after its name and can be "string", "int", "float" or "bool" (default is string). The last argument, if it's a string, takes the rest of the text.
+
+Parsed arguments can be accessed using "Arg" methods of the context. If the received arguments don't match the syntax, the handler is not called and the usage of the command is replied to the user.
+
+"chatType" must be "private","group","supergroup","channel" or "all". Any other value will cause the function to return an error.*/
+func (bot *Bot) AddCommandHandler(syntax string, handler func(*Context) error, chatTypes ...string) error {
+	err := checkChatTypes(chatTypes)
+	if err != nil {
+		return err
+	}
+	cmd, err := upp.ParseCommand(syntax)
+	if err != nil {
+		return err
+	}
+	return bot.parser.AddMatchHandler(cmd.Pattern(), func(up *objs.Update, match *upp.Match) {
+		c := bot.createContext(up, match)
+		args, err2 := cmd.ParseArgs(up.Message.Text)
+		if err2 != nil {
+			_, err2 = c.Reply(err2.Error())
+			if err2 != nil {
+				logger.Log("Error", "\t\t\t", "Could not send the usage of "+cmd.Name()+" : "+err2.Error(), "", logger.BOLD+logger.FAIL, logger.WARNING, "")
+			}
+			return
+		}
+		c.args = args
+		bot.runContextHandler(handler, c)
+	}, chatTypes...)
 }
 
 /*AddHandler adds a handler for a text message that matches the given regex pattern and chatType.
@@ -143,13 +358,84 @@ func (bot *Bot) GetUpdateChannel() *chan *objs.Update {
 
 "chatType" must be "private","group","supergroup","channel" or "all". Any other value will cause the function to return an error.*/
 func (bot *Bot) AddHandler(pattern string, handler func(*objs.Update), chatTypes ...string) error {
+	return bot.AddHandlerWithOptions(pattern, handler, upp.HandlerOptions{}, chatTypes...)
+}
+
+/*AddHandlerWithOptions works like "AddHandler" but the options of the handler can be specified.
+
+Handlers are checked in a deterministic order : handlers with higher "Priority" are checked first, then handlers with longer patterns (which are considered more specific) and then the handlers that have been added earlier. By default only the first matching handler is executed. If "Fallthrough" option is true, the next matching handler is executed after this one too. Use "SetDispatchMode" to execute all the matching handlers and "ExplainHandlers" to see which handler handles a message and why.*/
+func (bot *Bot) AddHandlerWithOptions(pattern string, handler func(*objs.Update), options upp.HandlerOptions, chatTypes ...string) error {
+	err := checkChatTypes(chatTypes)
+	if err != nil {
+		return err
+	}
+	return bot.parser.AddMatchHandlerWithOptions(pattern, func(up *objs.Update, match *upp.Match) {
+		handler(up)
+	}, options, chatTypes...)
+}
+
+/*AddUpdateHandler adds a context handler for the updates of the given type. Update types are "message", "edited_message", "channel_post", "edited_channel_post", "inline_query", "chosen_inline_result", "shipping_query", "pre_checkout_query", "poll_answer", "my_chat_member", "chat_member" and "chat_join_request".
+
+"pattern" is a regex that is matched against the text of the update, which is the text or the caption for messages and channel posts, the query for inline queries and chosen inline results and the invoice payload for shipping and pre-checkout queries. Pass empty string to match all the updates. Other update types have no text, so their pattern must be empty. Submatches of the pattern can be accessed from the context.
+
+The update is processed by the handler only if all the given filters accept it. The parser package contains filters for chat types, chat ids, senders and media types. Update handlers are checked in the order they have been added and only the first matching one is executed. Text messages are passed to the update handlers only if no text handler matches them. Updates that are processed by a handler are not passed into the channels.*/
+func (bot *Bot) AddUpdateHandler(updateType, pattern string, handler func(*Context) error, filters ...upp.Filter) error {
+	return bot.parser.AddUpdateHandler(updateType, pattern, bot.matchHandler(handler), filters...)
+}
+
+/*AddMediaHandler adds a context handler for the messages that contain the given media type. Media types are "photo", "video", "audio", "voice", "animation", "document", "sticker", "video_note", "contact", "location", "venue", "poll", "dice" and "game".
+
+"captionPattern" is a regex that is matched against the caption of the message. Pass empty string to match all the messages. Read the documentation of "AddUpdateHandler" for more information about filters.*/
+func (bot *Bot) AddMediaHandler(mediaType, captionPattern string, handler func(*Context) error, filters ...upp.Filter) error {
+	switch mediaType {
+	case "photo", "video", "audio", "voice", "animation", "document", "sticker", "video_note", "contact", "location", "venue", "poll", "dice", "game":
+	default:
+		return errors.New("unknown media type : " + mediaType)
+	}
+	return bot.AddUpdateHandler("message", captionPattern, handler, append([]upp.Filter{upp.MediaTypes(mediaType)}, filters...)...)
+}
+
+/*AddCallbackPrefixHandler adds a context handler for the callback queries whose data starts with the given prefix, like "item:" for the buttons with "item:42:delete" data. Matches[1] of the context holds the data after the prefix.
+
+Callback handlers added with exact data (using inline keyboard) are checked first and then the prefix and regex handlers are checked in the order they have been added. If the handler does not answer the callback query, it is answered automatically with no text after the handler returns.*/
+func (bot *Bot) AddCallbackPrefixHandler(prefix string, handler func(*Context) error) {
+	bot.parser.AddCallbackPrefixHandler(prefix, bot.callbackHandler(handler, nil))
+}
+
+/*AddCallbackRegexHandler adds a context handler for the callback queries whose data matches the given regex pattern. Submatches of the pattern can be accessed from the context. Read the documentation of "AddCallbackPrefixHandler" for more information.*/
+func (bot *Bot) AddCallbackRegexHandler(pattern string, handler func(*Context) error) error {
+	return bot.parser.AddCallbackRegexHandler(pattern, bot.callbackHandler(handler, nil))
+}
+
+/*AddCallbackCodecHandler adds a context handler for the callback queries whose data has been encoded by the given codec. Decoded fields can be accessed using "Arg" methods of the context. Use "AddCodecButton" method of the inline keyboard to create buttons for this handler. Read the documentation of "AddCallbackPrefixHandler" for more information.*/
+func (bot *Bot) AddCallbackCodecHandler(codec *upp.CallbackCodec, handler func(*Context) error) {
+	bot.parser.AddCallbackPrefixHandler(codec.Prefix(), bot.callbackHandler(handler, codec))
+}
+
+/*SetDispatchMode sets how many of the matching text handlers are executed for a message. In "parser.FirstMatch" mode (default) only the first matching handler is executed unless it has the "Fallthrough" option. In "parser.AllMatches" mode all the matching handlers are executed in order.*/
+func (bot *Bot) SetDispatchMode(mode upp.DispatchMode) {
+	bot.parser.SetDispatchMode(mode)
+}
+
+/*ExplainHandlers returns a human readable report which lists the text handlers in the order they are checked and explains which of them would handle a message with the given text in the given chat type and why. It is meant for debugging the handlers.*/
+func (bot *Bot) ExplainHandlers(text, chatType string) string {
+	return bot.parser.Explain(text, chatType)
+}
+
+func checkChatTypes(chatTypes []string) error {
 	for _, val := range chatTypes {
 		if val != "private" && val != "group" && val != "supergroup" && val != "channel" && val != "all" {
 			return errors.New("unknown chat type : " + val)
 		}
 	}
-	return upp.AddHandler(pattern, handler, chatTypes...)
+	return nil
+}
+
+/*Use adds middlewares to the bot. Middlewares wrap every handler (text handlers, callback handlers and button handlers) and also the delivery of the updates into the channels, so they can be used for logging, authorization, panic recovery, metrics and etc.
 
+Middlewares are executed in the order they are added. A middleware can stop an update by not calling "next". Note that for the updates that are passed into channels, middlewares are executed in the update routine so they should not block for a long time.*/
+func (bot *Bot) Use(middlewares ...upp.Middleware) {
+	bot.parser.Use(middlewares...)
 }
 
 /*GetMe returns the received informations about the bot from api server.
@@ -160,7 +446,12 @@ Official telegarm doc :
 
 A simple method for testing your bot's authentication token. Requires no parameters. Returns basic information about the bot in form of a User object.*/
 func (bot *Bot) GetMe() (*objs.UserResult, error) {
-	return bot.apiInterface.GetMe()
+	return bot.GetMeContext(context.Background())
+}
+
+/*GetMeContext works like "GetMe" but sends the request with the given context.*/
+func (bot *Bot) GetMeContext(ctx context.Context) (*objs.UserResult, error) {
+	return bot.apiInterface.GetMeContext(ctx)
 }
 
 /*SendMessage sens a text message to a chat (not channel, use SendMessageUN method for sending messages to channles) and returns the sent message on success
@@ -170,7 +461,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendMessage(chatId int, text, parseMode string, replyTo int, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendMessage(chatId, "", text, parseMode, nil, false, silent, false, protectContent, replyTo, nil)
+	return bot.SendMessageContext(context.Background(), chatId, text, parseMode, replyTo, silent, protectContent)
+}
+
+/*SendMessageContext works like "SendMessage" but sends the request with the given context.*/
+func (bot *Bot) SendMessageContext(ctx context.Context, chatId int, text, parseMode string, replyTo int, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendMessageContext(ctx, chatId, "", text, parseMode, nil, false, silent, false, protectContent, replyTo, nil)
 }
 
 /*SendMesssageUN sens a text message to a channel and returns the sent message on success
@@ -180,7 +476,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendMessageUN(chatId, text, parseMode string, replyTo int, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendMessage(0, chatId, text, parseMode, nil, false, silent, false, protectContent, replyTo, nil)
+	return bot.SendMessageUNContext(context.Background(), chatId, text, parseMode, replyTo, silent, protectContent)
+}
+
+/*SendMessageUNContext works like "SendMessageUN" but sends the request with the given context.*/
+func (bot *Bot) SendMessageUNContext(ctx context.Context, chatId, text, parseMode string, replyTo int, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendMessageContext(ctx, 0, chatId, text, parseMode, nil, false, silent, false, protectContent, replyTo, nil)
 }
 
 /*ForwardMessage returns a MessageForwarder which has several methods for forwarding a message
@@ -361,7 +662,7 @@ func (bot *Bot) SendVideoNoteUN(chatId string, replyTo int, caption, parseMode s
 /*CreateAlbum returns a MediaGroup for grouping media messages.
 To ignore replyTo argument, pass 0.*/
 func (bot *Bot) CreateAlbum(replyTo int) *MediaGroup {
-	return &MediaGroup{replyTo: replyTo, bot: bot, media: make([]objs.InputMedia, 0), files: make([]*os.File, 0)}
+	return &MediaGroup{replyTo: replyTo, bot: bot, media: make([]objs.InputMedia, 0), files: make([]io.Reader, 0)}
 }
 
 /*SendVenue sends a venue to all types of chat but channels. To send it to channels use "SendVenueUN" method.
@@ -376,7 +677,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendVenue(chatId, replyTo int, latitude, longitude float32, title, address string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendVenue(
+	return bot.SendVenueContext(context.Background(), chatId, replyTo, latitude, longitude, title, address, silent, protectContent)
+}
+
+/*SendVenueContext works like "SendVenue" but sends the request with the given context.*/
+func (bot *Bot) SendVenueContext(ctx context.Context, chatId, replyTo int, latitude, longitude float32, title, address string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendVenueContext(ctx,
 		chatId, "", latitude, longitude, title, address, "", "", "", "", replyTo, silent, false, protectContent, nil,
 	)
 }
@@ -393,7 +699,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendVenueUN(chatId string, replyTo int, latitude, longitude float32, title, address string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendVenue(
+	return bot.SendVenueUNContext(context.Background(), chatId, replyTo, latitude, longitude, title, address, silent, protectContent)
+}
+
+/*SendVenueUNContext works like "SendVenueUN" but sends the request with the given context.*/
+func (bot *Bot) SendVenueUNContext(ctx context.Context, chatId string, replyTo int, latitude, longitude float32, title, address string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendVenueContext(ctx,
 		0, chatId, latitude, longitude, title, address, "", "", "", "", replyTo, silent, false, protectContent, nil,
 	)
 }
@@ -410,7 +721,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendContact(chatId, replyTo int, phoneNumber, firstName, lastName string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendContact(
+	return bot.SendContactContext(context.Background(), chatId, replyTo, phoneNumber, firstName, lastName, silent, protectContent)
+}
+
+/*SendContactContext works like "SendContact" but sends the request with the given context.*/
+func (bot *Bot) SendContactContext(ctx context.Context, chatId, replyTo int, phoneNumber, firstName, lastName string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendContactContext(ctx,
 		chatId, "", phoneNumber, firstName, lastName, "", replyTo, silent, false, protectContent, nil,
 	)
 }
@@ -427,7 +743,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendContactUN(chatId string, replyTo int, phoneNumber, firstName, lastName string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendContact(
+	return bot.SendContactUNContext(context.Background(), chatId, replyTo, phoneNumber, firstName, lastName, silent, protectContent)
+}
+
+/*SendContactUNContext works like "SendContactUN" but sends the request with the given context.*/
+func (bot *Bot) SendContactUNContext(ctx context.Context, chatId string, replyTo int, phoneNumber, firstName, lastName string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendContactContext(ctx,
 		0, chatId, phoneNumber, firstName, lastName, "", replyTo, silent, false, protectContent, nil,
 	)
 }
@@ -466,7 +787,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendDice(chatId, replyTo int, emoji string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendDice(
+	return bot.SendDiceContext(context.Background(), chatId, replyTo, emoji, silent, protectContent)
+}
+
+/*SendDiceContext works like "SendDice" but sends the request with the given context.*/
+func (bot *Bot) SendDiceContext(ctx context.Context, chatId, replyTo int, emoji string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendDiceContext(ctx,
 		chatId, "", emoji, replyTo, silent, false, protectContent, nil,
 	)
 }
@@ -485,7 +811,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendDiceUN(chatId string, replyTo int, emoji string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendDice(
+	return bot.SendDiceUNContext(context.Background(), chatId, replyTo, emoji, silent, protectContent)
+}
+
+/*SendDiceUNContext works like "SendDiceUN" but sends the request with the given context.*/
+func (bot *Bot) SendDiceUNContext(ctx context.Context, chatId string, replyTo int, emoji string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendDiceContext(ctx,
 		0, chatId, emoji, replyTo, silent, false, protectContent, nil,
 	)
 }
@@ -504,7 +835,12 @@ We only recommend using this method when a response from the bot will take a not
 
 action is the type of action to broadcast. Choose one, depending on what the user is about to receive: typing for text messages, upload_photo for photos, record_video or upload_video for videos, record_voice or upload_voice for voice notes, upload_document for general files, choose_sticker for stickers, find_location for location data, record_video_note or upload_video_note for video notes.*/
 func (bot *Bot) SendChatAction(chatId int, action string) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendChatAction(chatId, "", action)
+	return bot.SendChatActionContext(context.Background(), chatId, action)
+}
+
+/*SendChatActionContext works like "SendChatAction" but sends the request with the given context.*/
+func (bot *Bot) SendChatActionContext(ctx context.Context, chatId int, action string) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendChatActionContext(ctx, chatId, "", action)
 }
 
 /*SendChatActionUN sends a chat action message to a channel.
@@ -521,7 +857,12 @@ We only recommend using this method when a response from the bot will take a not
 
 action is the type of action to broadcast. Choose one, depending on what the user is about to receive: typing for text messages, upload_photo for photos, record_video or upload_video for videos, record_voice or upload_voice for voice notes, upload_document for general files, choose_sticker for stickers, find_location for location data, record_video_note or upload_video_note for video notes.*/
 func (bot *Bot) SendChatActionUN(chatId, action string) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendChatAction(0, chatId, action)
+	return bot.SendChatActionUNContext(context.Background(), chatId, action)
+}
+
+/*SendChatActionUNContext works like "SendChatActionUN" but sends the request with the given context.*/
+func (bot *Bot) SendChatActionUNContext(ctx context.Context, chatId, action string) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendChatActionContext(ctx, 0, chatId, action)
 }
 
 /*SendLocation sends a location (not live) to all types of chats but channels. To send it to channel use "SendLocationUN" method.
@@ -538,7 +879,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendLocation(chatId int, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendLocation(
+	return bot.SendLocationContext(context.Background(), chatId, silent, protectContent, latitude, longitude, accuracy, replyTo)
+}
+
+/*SendLocationContext works like "SendLocation" but sends the request with the given context.*/
+func (bot *Bot) SendLocationContext(ctx context.Context, chatId int, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendLocationContext(ctx,
 		chatId, "", latitude, longitude, accuracy, 0, 0, 0, replyTo, silent, false, protectContent, nil,
 	)
 }
@@ -557,7 +903,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (bot *Bot) SendLocationUN(chatId string, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendLocation(
+	return bot.SendLocationUNContext(context.Background(), chatId, silent, protectContent, latitude, longitude, accuracy, replyTo)
+}
+
+/*SendLocationUNContext works like "SendLocationUN" but sends the request with the given context.*/
+func (bot *Bot) SendLocationUNContext(ctx context.Context, chatId string, silent, protectContent bool, latitude, longitude, accuracy float32, replyTo int) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendLocationContext(ctx,
 		0, chatId, latitude, longitude, accuracy, 0, 0, 0, replyTo, silent, false, protectContent, nil,
 	)
 }
@@ -572,19 +923,29 @@ Official telegram doc :
 
 Use this method to get a list of profile pictures for a user. Returns a UserProfilePhotos object.*/
 func (bot *Bot) GetUserProfilePhotos(userId, offset, limit int) (*objs.ProfilePhototsResult, error) {
-	return bot.apiInterface.GetUserProfilePhotos(userId, offset, limit)
+	return bot.GetUserProfilePhotosContext(context.Background(), userId, offset, limit)
+}
+
+/*GetUserProfilePhotosContext works like "GetUserProfilePhotos" but sends the request with the given context.*/
+func (bot *Bot) GetUserProfilePhotosContext(ctx context.Context, userId, offset, limit int) (*objs.ProfilePhototsResult, error) {
+	return bot.apiInterface.GetUserProfilePhotosContext(ctx, userId, offset, limit)
 }
 
 /*GetFile gets a file from telegram server. If it is successful the File object is returned.
 
 If "download option is true, the file will be saved into the given file and if the given file is nil file will be saved in the same name as it has been saved in telegram servers.*/
 func (bot *Bot) GetFile(fileId string, download bool, file *os.File) (*objs.File, error) {
-	res, err := bot.apiInterface.GetFile(fileId)
+	return bot.GetFileContext(context.Background(), fileId, download, file)
+}
+
+/*GetFileContext works like "GetFile" but sends the request with the given context.*/
+func (bot *Bot) GetFileContext(ctx context.Context, fileId string, download bool, file *os.File) (*objs.File, error) {
+	res, err := bot.apiInterface.GetFileContext(ctx, fileId)
 	if err != nil {
 		return nil, err
 	}
 	if download {
-		err2 := bot.apiInterface.DownloadFile(res.Result, file)
+		err2 := bot.apiInterface.DownloadFileContext(ctx, res.Result, file)
 		if err2 != nil {
 			return res.Result, err2
 		}
@@ -610,7 +971,13 @@ func (bot *Bot) GetChatManagerByUsrename(userName string) *ChatManager {
 
 Alternatively, the user can be redirected to the specified Game URL. For this option to work, you must first create a game for your bot via @Botfather and accept the terms. Otherwise, you may use links like t.me/your_bot?start=XXXX that open your bot with a parameter.*/
 func (bot *Bot) AnswerCallbackQuery(callbackQueryId, text string, showAlert bool) (*objs.LogicalResult, error) {
-	return bot.apiInterface.AnswerCallbackQuery(callbackQueryId, text, "", showAlert, 0)
+	return bot.AnswerCallbackQueryContext(context.Background(), callbackQueryId, text, showAlert)
+}
+
+/*AnswerCallbackQueryContext works like "AnswerCallbackQuery" but sends the request with the given context.*/
+func (bot *Bot) AnswerCallbackQueryContext(ctx context.Context, callbackQueryId, text string, showAlert bool) (*objs.LogicalResult, error) {
+	bot.pendingCallbacks.Delete(callbackQueryId)
+	return bot.apiInterface.AnswerCallbackQueryContext(ctx, callbackQueryId, text, "", showAlert, 0)
 }
 
 /*GetCommandManager returns a command manager which has several method for manaing bot commands.*/
@@ -657,7 +1024,12 @@ func (bot *Bot) SendStickerWithUn(chatId string, replyTo int) *MediaSender {
 
 /*GetStickerSet returns an sticker set with the given name*/
 func (bot *Bot) GetStickerSet(name string) (*StickerSet, error) {
-	res, err := bot.apiInterface.GetStickerSet(name)
+	return bot.GetStickerSetContext(context.Background(), name)
+}
+
+/*GetStickerSetContext works like "GetStickerSet" but sends the request with the given context.*/
+func (bot *Bot) GetStickerSetContext(ctx context.Context, name string) (*StickerSet, error) {
+	res, err := bot.apiInterface.GetStickerSetContext(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -666,11 +1038,30 @@ func (bot *Bot) GetStickerSet(name string) (*StickerSet, error) {
 
 /*UploadStickerFile can be used to upload a .PNG file with a sticker for later use in CreateNewStickerSet and AddStickerToSet methods (can be used multiple times). Returns the uploaded File on success.*/
 func (bot *Bot) UploadStickerFile(userId int, stickerFile *os.File) (*objs.GetFileResult, error) {
+	return bot.UploadStickerFileContext(context.Background(), userId, stickerFile)
+}
+
+/*UploadStickerFileContext works like "UploadStickerFile" but sends the request with the given context.*/
+func (bot *Bot) UploadStickerFileContext(ctx context.Context, userId int, stickerFile *os.File) (*objs.GetFileResult, error) {
 	stat, err := stickerFile.Stat()
 	if err != nil {
 		return nil, err
 	}
-	return bot.apiInterface.UploadStickerFile(userId, "attach://"+stat.Name(), stickerFile)
+	return bot.apiInterface.UploadStickerFileContext(ctx, userId, "attach://"+stat.Name(), stickerFile)
+}
+
+/*UploadStickerReader uploads the .PNG sticker read from the given reader, like UploadStickerFile. "fileName" is the name of the file sent to telegram.*/
+func (bot *Bot) UploadStickerReader(userId int, reader io.Reader, fileName string) (*objs.GetFileResult, error) {
+	return bot.UploadStickerReaderContext(context.Background(), userId, reader, fileName)
+}
+
+/*UploadStickerReaderContext works like "UploadStickerReader" but sends the request with the given context.*/
+func (bot *Bot) UploadStickerReaderContext(ctx context.Context, userId int, reader io.Reader, fileName string) (*objs.GetFileResult, error) {
+	file, err := newInputFile(reader, fileName, "image/png")
+	if err != nil {
+		return nil, err
+	}
+	return bot.apiInterface.UploadStickerFileContext(ctx, userId, "attach://"+fileName, file)
 }
 
 /*CreateNewStickerSet can be used to create a new sticker set owned by a user. The bot will be able to edit the sticker set thus created. You must use exactly one of the fields pngSticker or tgsSticker or webmSticker. Returns the created sticker set on success.
@@ -679,6 +1070,11 @@ png sticker can be passed as an file id or url (pngStickerFileIdOrUrl) or file(p
 
 "name" is the short name of sticker set, to be used in t.me/addstickers/ URLs (e.g., animals). Can contain only english letters, digits and underscores. Must begin with a letter, can't contain consecutive underscores and must end in “_by_<bot username>”. <bot_username> is case insensitive. 1-64 characters.*/
 func (bot *Bot) CreateNewStickerSet(userId int, name, title, pngStickerFileIdOrUrl string, pngStickerFile *os.File, tgsSticker *os.File, webmSticker *os.File, emojies string, containsMask bool, maskPosition *objs.MaskPosition) (*StickerSet, error) {
+	return bot.CreateNewStickerSetContext(context.Background(), userId, name, title, pngStickerFileIdOrUrl, pngStickerFile, tgsSticker, webmSticker, emojies, containsMask, maskPosition)
+}
+
+/*CreateNewStickerSetContext works like "CreateNewStickerSet" but sends the request with the given context.*/
+func (bot *Bot) CreateNewStickerSetContext(ctx context.Context, userId int, name, title, pngStickerFileIdOrUrl string, pngStickerFile *os.File, tgsSticker *os.File, webmSticker *os.File, emojies string, containsMask bool, maskPosition *objs.MaskPosition) (*StickerSet, error) {
 	var res *objs.LogicalResult
 	var err error
 	if tgsSticker == nil {
@@ -691,12 +1087,12 @@ func (bot *Bot) CreateNewStickerSet(userId int, name, title, pngStickerFileIdOrU
 					if er != nil {
 						return nil, er
 					}
-					res, err = bot.apiInterface.CreateNewStickerSet(
+					res, err = bot.apiInterface.CreateNewStickerSetContext(ctx,
 						userId, name, title, "", "", "attach://"+stat.Name(), emojies, containsMask, maskPosition, pngStickerFile,
 					)
 				}
 			}
-			res, err = bot.apiInterface.CreateNewStickerSet(
+			res, err = bot.apiInterface.CreateNewStickerSetContext(ctx,
 				userId, name, title, pngStickerFileIdOrUrl, "", "", emojies, containsMask, maskPosition, nil,
 			)
 		} else {
@@ -704,7 +1100,7 @@ func (bot *Bot) CreateNewStickerSet(userId int, name, title, pngStickerFileIdOrU
 			if er != nil {
 				return nil, er
 			}
-			res, err = bot.apiInterface.CreateNewStickerSet(
+			res, err = bot.apiInterface.CreateNewStickerSetContext(ctx,
 				userId, name, title, "attach://"+stat.Name(), "", "", emojies, containsMask, maskPosition, pngStickerFile,
 			)
 		}
@@ -713,7 +1109,7 @@ func (bot *Bot) CreateNewStickerSet(userId int, name, title, pngStickerFileIdOrU
 		if er != nil {
 			return nil, er
 		}
-		res, err = bot.apiInterface.CreateNewStickerSet(
+		res, err = bot.apiInterface.CreateNewStickerSetContext(ctx,
 			userId, name, title, "", "attach://"+stat.Name(), "", emojies, containsMask, maskPosition, tgsSticker,
 		)
 	}
@@ -726,7 +1122,7 @@ func (bot *Bot) CreateNewStickerSet(userId int, name, title, pngStickerFileIdOrU
 	out := &StickerSet{bot: bot, userId: userId, stickerSet: &objs.StickerSet{
 		Name: name, Title: title, ContainsMask: containsMask, Stickers: make([]objs.Sticker, 0),
 	}}
-	out.update()
+	out.update(ctx)
 	return out, nil
 }
 
@@ -788,7 +1184,12 @@ If you sent an invoice requesting a shipping address and the parameter is_flexib
 
 "errorMessage" : Required if ok is False. Error message in human readable form that explains why it is impossible to complete the order (e.g. "Sorry, delivery to your desired address is unavailable'). Telegram will display this message to the user.*/
 func (bot *Bot) AnswerShippingQuery(shippingQueryId string, ok bool, shippingOptions []objs.ShippingOption, errorMessage string) (*objs.LogicalResult, error) {
-	return bot.apiInterface.AnswerShippingQuery(shippingQueryId, ok, shippingOptions, errorMessage)
+	return bot.AnswerShippingQueryContext(context.Background(), shippingQueryId, ok, shippingOptions, errorMessage)
+}
+
+/*AnswerShippingQueryContext works like "AnswerShippingQuery" but sends the request with the given context.*/
+func (bot *Bot) AnswerShippingQueryContext(ctx context.Context, shippingQueryId string, ok bool, shippingOptions []objs.ShippingOption, errorMessage string) (*objs.LogicalResult, error) {
+	return bot.apiInterface.AnswerShippingQueryContext(ctx, shippingQueryId, ok, shippingOptions, errorMessage)
 }
 
 /*AnswerPreCheckoutQuery answers a pre checkout query.
@@ -803,7 +1204,12 @@ Once the user has confirmed their payment and shipping details, the Bot API send
 
 "errorMessage" : Required if ok is False. Error message in human readable form that explains the reason for failure to proceed with the checkout (e.g. "Sorry, somebody just bought the last of our amazing black T-shirts while you were busy filling out your payment details. Please choose a different color or garment!"). Telegram will display this message to the user.*/
 func (bot *Bot) AnswerPreCheckoutQuery(shippingQueryId string, ok bool, errorMessage string) (*objs.LogicalResult, error) {
-	return bot.apiInterface.AnswerPreCheckoutQuery(shippingQueryId, ok, errorMessage)
+	return bot.AnswerPreCheckoutQueryContext(context.Background(), shippingQueryId, ok, errorMessage)
+}
+
+/*AnswerPreCheckoutQueryContext works like "AnswerPreCheckoutQuery" but sends the request with the given context.*/
+func (bot *Bot) AnswerPreCheckoutQueryContext(ctx context.Context, shippingQueryId string, ok bool, errorMessage string) (*objs.LogicalResult, error) {
+	return bot.apiInterface.AnswerPreCheckoutQueryContext(ctx, shippingQueryId, ok, errorMessage)
 }
 
 /*SendGame sends a game to the chat.
@@ -816,7 +1222,12 @@ Official telegram doc :
 
 Use this method to send a game. On success, the sent Message is returned.*/
 func (bot *Bot) SendGame(chatId int, gameShortName string, silent bool, replyTo int) (*objs.SendMethodsResult, error) {
-	return bot.apiInterface.SendGame(
+	return bot.SendGameContext(context.Background(), chatId, gameShortName, silent, replyTo)
+}
+
+/*SendGameContext works like "SendGame" but sends the request with the given context.*/
+func (bot *Bot) SendGameContext(ctx context.Context, chatId int, gameShortName string, silent bool, replyTo int) (*objs.SendMethodsResult, error) {
+	return bot.apiInterface.SendGameContext(ctx,
 		chatId, gameShortName, silent, replyTo, false, nil,
 	)
 }
@@ -834,7 +1245,12 @@ Use this method to set the score of the specified user in a game message. On suc
 "score" is new score, must be non-negative.
 */
 func (bot *Bot) SetGameScore(userId, score, chatId, messageId int) (*objs.DefaultResult, error) {
-	return bot.apiInterface.SetGameScore(
+	return bot.SetGameScoreContext(context.Background(), userId, score, chatId, messageId)
+}
+
+/*SetGameScoreContext works like "SetGameScore" but sends the request with the given context.*/
+func (bot *Bot) SetGameScoreContext(ctx context.Context, userId, score, chatId, messageId int) (*objs.DefaultResult, error) {
+	return bot.apiInterface.SetGameScoreContext(ctx,
 		userId, score, false, false, chatId, messageId, "",
 	)
 }
@@ -856,7 +1272,12 @@ This method will currently return scores for the target user, plus two of their
 
 "inlineMessageId" : Required if chat_id and message_id are not specified. Identifier of the inline message.*/
 func (bot *Bot) GetGameHighScores(userId, chatId, messageId int, inlineMessageId string) (*objs.GameHighScoresResult, error) {
-	return bot.apiInterface.GetGameHighScores(userId, chatId, messageId, inlineMessageId)
+	return bot.GetGameHighScoresContext(context.Background(), userId, chatId, messageId, inlineMessageId)
+}
+
+/*GetGameHighScoresContext works like "GetGameHighScores" but sends the request with the given context.*/
+func (bot *Bot) GetGameHighScoresContext(ctx context.Context, userId, chatId, messageId int, inlineMessageId string) (*objs.GameHighScoresResult, error) {
+	return bot.apiInterface.GetGameHighScoresContext(ctx, userId, chatId, messageId, inlineMessageId)
 }
 
 /*GetChatMenuButton gets the current menu button of given chat.
@@ -867,7 +1288,12 @@ Official telegram doc :
 
 Use this method to get the current value of the bot's menu button in a private chat, or the default menu button. Returns MenuButton on success.*/
 func (bot *Bot) GetChatMenuButton(chatId int64) (*objs.MenuButtonResult, error) {
-	return bot.apiInterface.GetChatMenuButton(chatId)
+	return bot.GetChatMenuButtonContext(context.Background(), chatId)
+}
+
+/*GetChatMenuButtonContext works like "GetChatMenuButton" but sends the request with the given context.*/
+func (bot *Bot) GetChatMenuButtonContext(ctx context.Context, chatId int64) (*objs.MenuButtonResult, error) {
+	return bot.apiInterface.GetChatMenuButtonContext(ctx, chatId)
 }
 
 /*SetCommandChatMenuButton sets the current menu button of given chat to command meaning that it opens the bot's list of commands.
@@ -878,7 +1304,12 @@ Official telegram doc :
 
 Use this method to change the bot's menu button in a private chat, or the default menu button. Returns True on success.*/
 func (bot *Bot) SetCommandChatMenuButton(chatId int64) (*objs.LogicalResult, error) {
-	return bot.apiInterface.SetChatMenuButton(chatId, &objs.MenuButton{Type: "commands"})
+	return bot.SetCommandChatMenuButtonContext(context.Background(), chatId)
+}
+
+/*SetCommandChatMenuButtonContext works like "SetCommandChatMenuButton" but sends the request with the given context.*/
+func (bot *Bot) SetCommandChatMenuButtonContext(ctx context.Context, chatId int64) (*objs.LogicalResult, error) {
+	return bot.apiInterface.SetChatMenuButtonContext(ctx, chatId, &objs.MenuButton{Type: "commands"})
 }
 
 /*SetDefaultChatMenuButton sets the current menu button of given chat to command meaning that it describes that no specific value for the menu button was set.
@@ -889,7 +1320,12 @@ Official telegram doc :
 
 Use this method to change the bot's menu button in a private chat, or the default menu button. Returns True on success.*/
 func (bot *Bot) SetDefaultChatMenuButton(chatId int64) (*objs.LogicalResult, error) {
-	return bot.apiInterface.SetChatMenuButton(chatId, &objs.MenuButton{Type: "default"})
+	return bot.SetDefaultChatMenuButtonContext(context.Background(), chatId)
+}
+
+/*SetDefaultChatMenuButtonContext works like "SetDefaultChatMenuButton" but sends the request with the given context.*/
+func (bot *Bot) SetDefaultChatMenuButtonContext(ctx context.Context, chatId int64) (*objs.LogicalResult, error) {
+	return bot.apiInterface.SetChatMenuButtonContext(ctx, chatId, &objs.MenuButton{Type: "default"})
 }
 
 /*SetWebAppChatMenuButton sets the current menu button of given chat to web_app meaning that it launches a Web App.
@@ -900,7 +1336,12 @@ Official telegram doc :
 
 Use this method to change the bot's menu button in a private chat, or the default menu button. Returns True on success.*/
 func (bot *Bot) SetWebAppChatMenuButton(chatId int64, text, url string) (*objs.LogicalResult, error) {
-	return bot.apiInterface.SetChatMenuButton(chatId, &objs.MenuButton{Type: "web_app", Text: text, WebApp: &objs.WebAppInfo{URL: url}})
+	return bot.SetWebAppChatMenuButtonContext(context.Background(), chatId, text, url)
+}
+
+/*SetWebAppChatMenuButtonContext works like "SetWebAppChatMenuButton" but sends the request with the given context.*/
+func (bot *Bot) SetWebAppChatMenuButtonContext(ctx context.Context, chatId int64, text, url string) (*objs.LogicalResult, error) {
+	return bot.apiInterface.SetChatMenuButtonContext(ctx, chatId, &objs.MenuButton{Type: "web_app", Text: text, WebApp: &objs.WebAppInfo{URL: url}})
 }
 
 /*CreateKeyboard creates a keyboard an returns it. The created keyboard has some methods for adding buttons to it.
@@ -922,6 +1363,7 @@ Example: A user requests to change the bot's language, bot replies to the reques
 */
 func (bot *Bot) CreateKeyboard(resizeKeyboard, oneTimeKeyboard, selective bool, inputFieldPlaceholder string) *keyboard {
 	return &keyboard{
+		bot:                   bot,
 		keys:                  make([][]*objs.KeyboardButton, 0),
 		resizeKeyBoard:        resizeKeyboard,
 		oneTimeKeyboard:       oneTimeKeyboard,
@@ -934,7 +1376,49 @@ func (bot *Bot) CreateKeyboard(resizeKeyboard, oneTimeKeyboard, selective bool,
 
 You can send the keyboard along with messages by passing the keyboard as the "keyboard" argument of a method. The methods that supoort keyboard are mostly located in the advanced mode.*/
 func (bot *Bot) CreateInlineKeyboard() *inlineKeyboard {
-	return &inlineKeyboard{}
+	return &inlineKeyboard{bot: bot}
+}
+
+/*CreateConversation creates a conversation and returns it. Conversations can be used for multi-step dialogs like forms. Add the states and entry points of the conversation using its methods.
+
+"timeout" is the time a conversation waits for the next update of the user. If no update is received in this time, the conversation is ended. Pass 0 for no timeout.
+
+If "perChat" is true, all the members of a chat share the same conversation state, otherwise each user in each chat has their own state.*/
+func (bot *Bot) CreateConversation(timeout time.Duration, perChat bool) *Conversation {
+	conv := &Conversation{
+		bot:     bot,
+		perChat: perChat,
+		timeout: timeout,
+		states:  make(map[string]ConversationHandler),
+		active:  make(map[conversationKey]*conversationState),
+	}
+	bot.parser.AddInterceptor(conv.intercept)
+	return conv
+}
+
+/*SetSessionStorage sets the storage that sessions of the users are stored in. Each session expires after "ttl" of its last change, pass 0 for sessions that never expire. By default sessions are stored in memory and never expire.
+
+Session package contains in-memory, file and RESP (redis protocol) storages. Custom storages can be used by implementing the session.Storage interface.*/
+func (bot *Bot) SetSessionStorage(storage ss.Storage, ttl time.Duration) {
+	manager := ss.NewManager(storage, ttl)
+	bot.sessionsLock.Lock()
+	defer bot.sessionsLock.Unlock()
+	bot.sessions = manager
+}
+
+/*GetSession loads the session of the sender of the given update in the chat the update belongs to. If the update has no chat or sender, their id is considered 0.*/
+func (bot *Bot) GetSession(update *objs.Update) (*ss.Session, error) {
+	chatId, userId := 0, 0
+	if chat := upp.GetChat(update); chat != nil {
+		chatId = chat.Id
+	}
+	if user := upp.GetSender(update); user != nil {
+		userId = user.Id
+	}
+	bot.sessionsLock.RLock()
+	sessions := bot.sessions
+	bot.sessionsLock.RUnlock()
+	return sessions.Load(chatId, userId)
 }
 
 /*GetTextFormatter returns a MessageFormatter that can be used for formatting a text message. You can add bold,italic,underline,spoiler,mention,url,link and some other texts with this tool.*/
@@ -944,14 +1428,22 @@ func (bot *Bot) GetTextFormatter() *TextFormatter {
 
 /*VerifyJoin verifies if the user has joined the given channel or supergroup. Returns true if the user is present in the given chat, returns false if not or an error has occured.*/
 func (bot *Bot) VerifyJoin(userID int, UserName string) bool {
-	_, err := bot.apiInterface.GetChatMember(0, UserName, userID)
+	return bot.VerifyJoinContext(context.Background(), userID, UserName)
+}
+
+/*VerifyJoinContext works like "VerifyJoin" but sends the request with the given context.*/
+func (bot *Bot) VerifyJoinContext(ctx context.Context, userID int, UserName string) bool {
+	_, err := bot.apiInterface.GetChatMemberContext(ctx, 0, UserName, userID)
 	return err == nil
 }
 
-/*Stop stops the bot*/
+/*Stop stops the bot. If the bot has been started with "Run" or "RunContext", they will return after the bot is shut down.*/
 func (bot *Bot) Stop() {
-	bot.apiInterface.StopUpdateRoutine()
-	*bot.prcRoutineChannel <- true
+	bot.runLock.Lock()
+	defer bot.runLock.Unlock()
+	if bot.cancelRun != nil {
+		bot.cancelRun()
+	}
 }
 
 /*AdvancedMode returns and advanced version of the bot which gives more customized functions to iteract with the bot*/
@@ -959,15 +1451,17 @@ func (bot *Bot) AdvancedMode() *AdvancedBot {
 	return bot.ab
 }
 
-func (bot *Bot) processUpdate(update *objs.Update, mapKey string) bool {
+func (bot *Bot) processUpdate(ctx context.Context, update *objs.Update, mapKey string) bool {
 	out := true
 	upType := update.GetType()
 	if upType == "poll" {
-		bot.processPoll(update)
+		bot.processPoll(ctx, update)
+	} else if fc := bot.getFilteredChannel(update); fc != nil {
+		bot.deliver(ctx, fc, update)
 	} else {
-		ch := bot.channelsMap[mapKey][upType]
+		ch := bot.getChannel(mapKey, upType)
 		if ch != nil {
-			*ch <- update
+			bot.deliver(ctx, ch, update)
 		} else {
 			out = false
 		}
@@ -975,26 +1469,61 @@ func (bot *Bot) processUpdate(update *objs.Update, mapKey string) bool {
 	return out
 }
 
-func (bot *Bot) startUpdateProcessing() {
+//getChannel returns the channel registered for the given chat id and update type. Returns nil if no channel is registered.
+func (bot *Bot) getChannel(chatId, upType string) *chan *objs.Update {
+	bot.channelsLock.RLock()
+	defer bot.channelsLock.RUnlock()
+	return bot.channelsMap[chatId][upType]
+}
+
+/*deliver passes the update through the middlewares and into the given channel. Panics of the middlewares are passed to the error handler. If the channel is full, the full queue policy of the dispatcher configs is applied. If the bot is stopped while no one is receiving from the channel, the update is dropped.*/
+func (bot *Bot) deliver(ctx context.Context, ch *chan *objs.Update, update *objs.Update) {
+	//Middlewares run in the update processing routine, so their panics should not stop the bot.
+	defer bot.parser.Recover(update)
+	bot.parser.Wrap(func(up *objs.Update) {
+		bot.parser.SendUpdate(ctx, *ch, up, bot.dispatcherConfigs().Policy())
+	})(update)
+}
+
+//dispatcherConfigs returns the dispatcher configs of the bot. It may return nil.
+func (bot *Bot) dispatcherConfigs() *cfg.DispatcherConfigs {
+	if bot.botCfg == nil {
+		return nil
+	}
+	return bot.botCfg.DispatcherConfigs
+}
+
+//newChannel creates a channel for passing updates to the user, buffered according to the dispatcher configs.
+func (bot *Bot) newChannel() *chan *objs.Update {
+	ch := make(chan *objs.Update, bot.dispatcherConfigs().BufferSize())
+	return &ch
+}
+
+//GetDroppedUpdates returns the number of updates that have been dropped because of full queues. See "DispatcherConfigs" field of the bot configs.
+func (bot *Bot) GetDroppedUpdates() int64 {
+	return bot.parser.DroppedUpdates()
+}
+
+func (bot *Bot) startUpdateProcessing(ctx context.Context) {
 loop:
 	for {
 		select {
-		case <-*bot.prcRoutineChannel:
+		case <-ctx.Done():
 			break loop
 		case up := <-*bot.interfaceUpdateChannel:
-			if !bot.processUpdate(up, "global") {
-				*bot.channelsMap["global"]["all"] <- up
+			if !bot.processUpdate(ctx, up, "global") {
+				bot.deliver(ctx, bot.getChannel("global", "all"), up)
 			}
 		}
 	}
 }
 
-func (bot *Bot) processPoll(update *objs.Update) {
+func (bot *Bot) processPoll(ctx context.Context, update *objs.Update) {
 	id := update.Poll.Id
-	pl := Polls[id]
+	pl := GetPoll(id)
 	if pl == nil {
 		logger.Log("Error", "\t\t\t", "Could not update poll `"+id+"`. Not found in the Polls map", "917", logger.BOLD+logger.FAIL, logger.WARNING, "")
-		*bot.channelsMap["global"]["all"] <- update
+		bot.deliver(ctx, bot.getChannel("global", "all"), update)
 	} else {
 		err3 := pl.Update(update.Poll)
 		if err3 != nil {
@@ -1003,25 +1532,67 @@ func (bot *Bot) processPoll(update *objs.Update) {
 	}
 }
 
-func (bot *Bot) startChatUpdateRoutine() {
+func (bot *Bot) startChatUpdateRoutine(ctx context.Context) {
 loop:
 	for {
 		select {
-		case <-*bot.prcRoutineChannel:
+		case <-ctx.Done():
 			break loop
 		case up := <-*bot.chatUpdateChannel:
-			if !bot.processUpdate(up.Update, up.ChatId) {
-				chatChannel := bot.channelsMap[up.ChatId]["all"]
+			if !bot.processUpdate(ctx, up.Update, up.ChatId) {
+				chatChannel := bot.getChannel(up.ChatId, "all")
 				if chatChannel != nil {
-					*chatChannel <- up.Update
+					bot.deliver(ctx, chatChannel, up.Update)
 				} else {
-					*bot.interfaceUpdateChannel <- up.Update
+					bot.parser.SendUpdate(ctx, *bot.interfaceUpdateChannel, up.Update, bot.dispatcherConfigs().Policy())
 				}
 			}
 		}
 	}
 }
 
+/*SetErrorHandler sets a function that is called when a handler fails. Handlers fail when they panic or, for the handlers that return an error (like context handlers), when they return a non nil error. Panics are passed as *errors.HandlerPanic which contains the recovered value and the stack trace of the handler. A panic in a handler never stops the bot.
+
+If no error handler is set, the errors are logged.*/
+func (bot *Bot) SetErrorHandler(handler func(update *objs.Update, err error)) {
+	bot.errorLock.Lock()
+	defer bot.errorLock.Unlock()
+	bot.errorHandler = handler
+}
+
+/*SetFailureReply sets a message that is sent to the chat of the update when a handler fails, like "Something went wrong, please try again later.". If the update is a message, the reply is sent as a reply to it. Pass empty string to disable it (default).*/
+func (bot *Bot) SetFailureReply(text string) {
+	bot.errorLock.Lock()
+	defer bot.errorLock.Unlock()
+	bot.failureReply = text
+}
+
+//handleError passes the error of a handler to the error handler and sends the failure reply.
+func (bot *Bot) handleError(up *objs.Update, err error) {
+	bot.errorLock.RLock()
+	handler, reply := bot.errorHandler, bot.failureReply
+	bot.errorLock.RUnlock()
+	if handler != nil {
+		handler(up, err)
+	} else {
+		logger.Log("Error", "\t\t\t", "Handler failed : "+err.Error(), "", logger.BOLD+logger.FAIL, logger.WARNING, "")
+		if hp, ok := err.(*errs.HandlerPanic); ok {
+			logger.Logger.Println(string(hp.Stack))
+		}
+	}
+	chat := upp.GetChat(up)
+	if reply == "" || chat == nil {
+		return
+	}
+	replyTo := 0
+	if up.Message != nil {
+		replyTo = up.Message.MessageId
+	}
+	if _, err2 := bot.SendMessage(chat.Id, reply, "", replyTo, false, false); err2 != nil {
+		logger.Log("Error", "\t\t\t", "Could not send the failure reply : "+err2.Error(), "", logger.BOLD+logger.FAIL, logger.WARNING, "")
+	}
+}
+
 /*NewBot returns a new bot instance with the specified configs*/
 func NewBot(cfg *cfg.BotConfigs) (*Bot, error) {
 	if cfg == nil {
@@ -1034,11 +1605,10 @@ func NewBot(cfg *cfg.BotConfigs) (*Bot, error) {
 	if err != nil {
 		return nil, err
 	}
-	ch := make(chan bool)
-	uc := make(chan *objs.Update)
-	bt := &Bot{botCfg: cfg, apiInterface: api, interfaceUpdateChannel: api.GetUpdateChannel(), chatUpdateChannel: api.GetChatUpdateChannel(), prcRoutineChannel: &ch, channelsMap: make(map[string]map[string]*chan *objs.Update)}
+	bt := &Bot{botCfg: cfg, apiInterface: api, interfaceUpdateChannel: api.GetUpdateChannel(), chatUpdateChannel: api.GetChatUpdateChannel(), parser: api.GetParser(), channelsMap: make(map[string]map[string]*chan *objs.Update), sessions: ss.NewManager(ss.NewMemoryStorage(), 0)}
 	bt.channelsMap["global"] = make(map[string]*chan *objs.Update)
-	bt.channelsMap["global"]["all"] = &uc
+	bt.channelsMap["global"]["all"] = bt.newChannel()
 	bt.ab = &AdvancedBot{bot: bt}
+	bt.parser.SetErrorHandler(bt.handleError)
 	return bt, nil
 }
@@ -0,0 +1,174 @@
+package telego
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	logger "github.com/SakoDroid/telego/logger"
+	ss "github.com/SakoDroid/telego/session"
+)
+
+func TestConfigFileOfConcurrentBots(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/getWebhookInfo"):
+			wr.Write([]byte(`{"ok":true,"result":{"url":""}}`))
+		case strings.HasSuffix(req.URL.Path, "/getUpdates"):
+			time.Sleep(50 * time.Millisecond)
+			wr.Write([]byte(`{"ok":true,"result":[]}`))
+		default:
+			wr.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}))
+	defer srv.Close()
+	file := filepath.Join(t.TempDir(), "configs.json")
+	keys := []string{"1:token", "2:token"}
+	bots := make([]*Bot, len(keys))
+	for i, key := range keys {
+		cfg := cfgs.Default(key)
+		cfg.BotAPI = srv.URL + "/bot"
+		cfg.ConfigFile = file
+		bot, err := NewBot(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bots[i] = bot
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, len(bots))
+	for _, bot := range bots {
+		go func(bot *Bot) {
+			done <- bot.RunContext(ctx)
+		}(bot)
+	}
+	//Let the configs be reloaded a few times.
+	time.Sleep(2500 * time.Millisecond)
+	cancel()
+	for range bots {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, bot := range bots {
+		if bot.botCfg.APIKey != keys[i] {
+			t.Errorf("configs of bot %d have been overwritten by the other bot : %s", i, bot.botCfg.APIKey)
+		}
+	}
+	saved, err := cfgs.LoadFile(file)
+	if err != nil || (saved.APIKey != keys[0] && saved.APIKey != keys[1]) {
+		t.Fatal("configs were not saved", err)
+	}
+	configFilesLock.Lock()
+	defer configFilesLock.Unlock()
+	if len(configFiles) != 0 {
+		t.Error("config files were not released", configFiles)
+	}
+}
+
+func TestCheckWebhookCertificate(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	dropped := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/getWebhookInfo") {
+			wr.Write([]byte(`{"ok":true,"result":{"url":"https://example.com/hook","has_custom_certificate":true}}`))
+			return
+		}
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(req.URL.Path, err)
+		}
+		dropped = append(dropped, req.FormValue("drop_pending_updates"))
+		wr.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+	cfg := cfgs.Default("1:token")
+	cfg.BotAPI = srv.URL + "/bot"
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com/hook", Path: "/hook", GenerateCertificate: true, DropPendingUpdates: true, CertFile: filepath.Join(dir, "cert.crt"), KeyFile: filepath.Join(dir, "cert.key")}
+	if err := os.WriteFile(cfg.WebHookConfigs.CertFile, []byte("certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bot, err := NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bot.checkWebHook(false) || len(dropped) != 0 {
+		t.Fatal("certificate was uploaded although it has not changed", dropped)
+	}
+	if !bot.checkWebHook(true) || len(dropped) != 1 {
+		t.Fatal("renewed certificate was not uploaded", dropped)
+	}
+	if dropped[0] == "true" {
+		t.Error("pending updates were dropped when uploading the renewed certificate")
+	}
+}
+
+func TestSetSessionStorageConcurrently(t *testing.T) {
+	bot := &Bot{sessions: ss.NewManager(ss.NewMemoryStorage(), 0)}
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			if _, err := bot.GetSession(createTestMessage(1, "hi")); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		bot.SetSessionStorage(ss.NewMemoryStorage(), time.Minute)
+	}
+	<-done
+}
+
+func TestShutdownWithUnreadChannels(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/getWebhookInfo"):
+			wr.Write([]byte(`{"ok":true,"result":{"url":""}}`))
+		case strings.HasSuffix(req.URL.Path, "/getUpdates"):
+			//Every request returns a new message that no one receives from the channels.
+			wr.Write([]byte(`{"ok":true,"result":[{"update_id":1,"message":{"message_id":1,"text":"hi","chat":{"id":10,"type":"private"},"from":{"id":1}}}]}`))
+		default:
+			wr.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}))
+	defer srv.Close()
+	cfg := cfgs.Default("1:token")
+	cfg.BotAPI = srv.URL + "/bot"
+	cfg.DisableConfigFile = true
+	cfg.ShutdownTimeout = 3 * time.Second
+	cfg.UpdateConfigs.UpdateFrequency = 10 * time.Millisecond
+	bot, err := NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//The channel is never received from.
+	_ = bot.GetUpdateChannel()
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err = bot.RunContext(ctx); err != nil {
+		t.Error("bot was not shut down gracefully :", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Error("shutdown waited for the unread channels :", elapsed)
+	}
+}
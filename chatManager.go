@@ -1,6 +1,7 @@
 package telego
 
 import (
+	"context"
 	"os"
 
 	objs "github.com/SakoDroid/telego/objects"
@@ -28,14 +29,24 @@ func (cm *ChatManager) fixThePerms(canSendMessages, canSendMediaMessages, canSen
 
 /*BanMember bans a user in a group, a supergroup or a channel. In the case of supergroups and channels, the user will not be able to return to the chat on their own using invite links, etc., unless unbanned first. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) BanMember(userId, untilDate int, revokeMessages bool) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.BanChatMember(
+	return cm.BanMemberContext(context.Background(), userId, untilDate, revokeMessages)
+}
+
+/*BanMemberContext works like "BanMember" but sends the request with the given context.*/
+func (cm *ChatManager) BanMemberContext(ctx context.Context, userId, untilDate int, revokeMessages bool) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.BanChatMemberContext(ctx,
 		cm.chatIdInt, cm.chatIdString, userId, untilDate, revokeMessages,
 	)
 }
 
 /*UnbanMember ubans a previously banned user in a supergroup or channel. The user will not return to the group or channel automatically, but will be able to join via link, etc. The bot must be an administrator for this to work. By default, this method guarantees that after the call the user is not a member of the chat, but will be able to join it. So if the user is a member of the chat they will also be removed from the chat. If you don't want this, use the parameter only_if_banned. Returns True on success.*/
 func (cm *ChatManager) UnbanMember(userId int, onlyIfBanned bool) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.UnbanChatMember(
+	return cm.UnbanMemberContext(context.Background(), userId, onlyIfBanned)
+}
+
+/*UnbanMemberContext works like "UnbanMember" but sends the request with the given context.*/
+func (cm *ChatManager) UnbanMemberContext(ctx context.Context, userId int, onlyIfBanned bool) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.UnbanChatMemberContext(ctx,
 		cm.chatIdInt, cm.chatIdString, userId, onlyIfBanned,
 	)
 }
@@ -43,7 +54,12 @@ func (cm *ChatManager) UnbanMember(userId int, onlyIfBanned bool) (*objs.Logical
 /*
 Use this method to restrict a user in a supergroup. The bot must be an administrator in the supergroup for this to work and must have the appropriate administrator rights. Pass True for all permissions to lift restrictions from a user. Returns True on success.*/
 func (cm *ChatManager) RestrictMember(userId int, untilDate int, canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.RestrictChatMember(
+	return cm.RestrictMemberContext(context.Background(), userId, untilDate, canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages)
+}
+
+/*RestrictMemberContext works like "RestrictMember" but sends the request with the given context.*/
+func (cm *ChatManager) RestrictMemberContext(ctx context.Context, userId int, untilDate int, canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.RestrictChatMemberContext(ctx,
 		cm.chatIdInt, cm.chatIdString, userId, cm.fixThePerms(
 			canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages,
 		), untilDate,
@@ -52,7 +68,12 @@ func (cm *ChatManager) RestrictMember(userId int, untilDate int, canSendMessages
 
 /*PromoteChatMember promotes or demote a user in a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Pass False for all boolean parameters to demote a user. Returns True on success.*/
 func (cm *ChatManager) PromoteChatMember(userId int, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.PromoteChatMember(
+	return cm.PromoteChatMemberContext(context.Background(), userId, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages)
+}
+
+/*PromoteChatMemberContext works like "PromoteChatMember" but sends the request with the given context.*/
+func (cm *ChatManager) PromoteChatMemberContext(ctx context.Context, userId int, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.PromoteChatMemberContext(ctx,
 		cm.chatIdInt, cm.chatIdString, userId, isAnonymous, canManageChat,
 		canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats,
 		canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages,
@@ -61,28 +82,48 @@ func (cm *ChatManager) PromoteChatMember(userId int, isAnonymous, canManageChat,
 
 /*SetCustomTitle sets a custom title for an administrator in a supergroup promoted by the bot. Returns True on success.*/
 func (cm *ChatManager) SetCustomTitle(userId int, customTitle string) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.SetChatAdministratorCustomTitle(
+	return cm.SetCustomTitleContext(context.Background(), userId, customTitle)
+}
+
+/*SetCustomTitleContext works like "SetCustomTitle" but sends the request with the given context.*/
+func (cm *ChatManager) SetCustomTitleContext(ctx context.Context, userId int, customTitle string) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.SetChatAdministratorCustomTitleContext(ctx,
 		cm.chatIdInt, cm.chatIdString, userId, customTitle,
 	)
 }
 
 /*BanChatSender bans a channel chat in a supergroup or a channel. Until the chat is unbanned, the owner of the banned chat won't be able to send messages on behalf of any of their channels. The bot must be an administrator in the supergroup or channel for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) BanChatSender(senderChatId int) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.BanOrUnbanChatSenderChat(
+	return cm.BanChatSenderContext(context.Background(), senderChatId)
+}
+
+/*BanChatSenderContext works like "BanChatSender" but sends the request with the given context.*/
+func (cm *ChatManager) BanChatSenderContext(ctx context.Context, senderChatId int) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.BanOrUnbanChatSenderChatContext(ctx,
 		cm.chatIdInt, cm.chatIdString, senderChatId, true,
 	)
 }
 
 /*UnbanChatSender unbans a previously banned channel chat in a supergroup or channel. The bot must be an administrator for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) UnbanChatSender(senderChatId int) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.BanOrUnbanChatSenderChat(
+	return cm.UnbanChatSenderContext(context.Background(), senderChatId)
+}
+
+/*UnbanChatSenderContext works like "UnbanChatSender" but sends the request with the given context.*/
+func (cm *ChatManager) UnbanChatSenderContext(ctx context.Context, senderChatId int) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.BanOrUnbanChatSenderChatContext(ctx,
 		cm.chatIdInt, cm.chatIdString, senderChatId, false,
 	)
 }
 
 /*SetGeneralPermissions sets default chat permissions for all members. The bot must be an administrator in the group or a supergroup for this to work and must have the can_restrict_members administrator rights. Returns True on success.*/
 func (cm *ChatManager) SetGeneralPermissions(canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.SetChatPermissions(
+	return cm.SetGeneralPermissionsContext(context.Background(), canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages)
+}
+
+/*SetGeneralPermissionsContext works like "SetGeneralPermissions" but sends the request with the given context.*/
+func (cm *ChatManager) SetGeneralPermissionsContext(ctx context.Context, canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.SetChatPermissionsContext(ctx,
 		cm.chatIdInt, cm.chatIdString, cm.fixThePerms(
 			canSendMessages, canSendMediaMessages, canSendPolls, canSendOtherMessages, canAddWebPagePreviews, canChangeInfo, canInviteUsers, canPinMessages,
 		),
@@ -93,119 +134,204 @@ func (cm *ChatManager) SetGeneralPermissions(canSendMessages, canSendMediaMessag
 
 Note: Each administrator in a chat generates their own invite links. Bots can't use invite links generated by other administrators. If you want your bot to work with invite links, it will need to generate its own link using this method or by calling the getChat method. If your bot needs to generate a new primary invite link replacing its previous one, use this method again.*/
 func (cm *ChatManager) ExportInviteLink() (*objs.StringResult, error) {
-	return cm.bot.apiInterface.ExportChatInviteLink(
+	return cm.ExportInviteLinkContext(context.Background())
+}
+
+/*ExportInviteLinkContext works like "ExportInviteLink" but sends the request with the given context.*/
+func (cm *ChatManager) ExportInviteLinkContext(ctx context.Context) (*objs.StringResult, error) {
+	return cm.bot.apiInterface.ExportChatInviteLinkContext(ctx,
 		cm.chatIdInt, cm.chatIdString,
 	)
 }
 
 /*CreateInviteLink creates an additional invite link for a chat. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. The link can be revoked using the method RevokeInviteLink. Returns the new invite link as ChatInviteLink object.*/
 func (cm *ChatManager) CreateInviteLink(name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.ChatInviteLinkResult, error) {
-	return cm.bot.apiInterface.CreateChatInviteLink(
+	return cm.CreateInviteLinkContext(context.Background(), name, expireDate, memberLimit, createsJoinRequest)
+}
+
+/*CreateInviteLinkContext works like "CreateInviteLink" but sends the request with the given context.*/
+func (cm *ChatManager) CreateInviteLinkContext(ctx context.Context, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.ChatInviteLinkResult, error) {
+	return cm.bot.apiInterface.CreateChatInviteLinkContext(ctx,
 		cm.chatIdInt, cm.chatIdString, name, expireDate, memberLimit, createsJoinRequest,
 	)
 }
 
 /*EditInviteLink edits a non-primary invite link created by the bot. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the edited invite link as a ChatInviteLink object.*/
 func (cm *ChatManager) EditInviteLink(inviteLink, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.ChatInviteLinkResult, error) {
-	return cm.bot.apiInterface.EditChatInviteLink(
+	return cm.EditInviteLinkContext(context.Background(), inviteLink, name, expireDate, memberLimit, createsJoinRequest)
+}
+
+/*EditInviteLinkContext works like "EditInviteLink" but sends the request with the given context.*/
+func (cm *ChatManager) EditInviteLinkContext(ctx context.Context, inviteLink, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.ChatInviteLinkResult, error) {
+	return cm.bot.apiInterface.EditChatInviteLinkContext(ctx,
 		cm.chatIdInt, cm.chatIdString, inviteLink, name, expireDate, memberLimit, createsJoinRequest,
 	)
 }
 
 /*RevokeInviteLink revokes an invite link created by the bot. If the primary link is revoked, a new link is automatically generated. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the revoked invite link as ChatInviteLink object.*/
 func (cm *ChatManager) RevokeInviteLink(inviteLink string) (*objs.ChatInviteLinkResult, error) {
-	return cm.bot.apiInterface.RevokeChatInviteLink(
+	return cm.RevokeInviteLinkContext(context.Background(), inviteLink)
+}
+
+/*RevokeInviteLinkContext works like "RevokeInviteLink" but sends the request with the given context.*/
+func (cm *ChatManager) RevokeInviteLinkContext(ctx context.Context, inviteLink string) (*objs.ChatInviteLinkResult, error) {
+	return cm.bot.apiInterface.RevokeChatInviteLinkContext(ctx,
 		cm.chatIdInt, cm.chatIdString, inviteLink,
 	)
 }
 
 /*ApproveJoinRequest approves a chat join request. The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right. Returns True on success.*/
 func (cm *ChatManager) ApproveJoinRequest(userId int) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.ApproveChatJoinRequest(
+	return cm.ApproveJoinRequestContext(context.Background(), userId)
+}
+
+/*ApproveJoinRequestContext works like "ApproveJoinRequest" but sends the request with the given context.*/
+func (cm *ChatManager) ApproveJoinRequestContext(ctx context.Context, userId int) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.ApproveChatJoinRequestContext(ctx,
 		cm.chatIdInt, cm.chatIdString, userId,
 	)
 }
 
 /*DeclineJoinRequest can be used to decline a chat join request. The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right. Returns True on success.*/
 func (cm *ChatManager) DeclineJoinRequest(userId int) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.DeclineChatJoinRequest(
+	return cm.DeclineJoinRequestContext(context.Background(), userId)
+}
+
+/*DeclineJoinRequestContext works like "DeclineJoinRequest" but sends the request with the given context.*/
+func (cm *ChatManager) DeclineJoinRequestContext(ctx context.Context, userId int) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.DeclineChatJoinRequestContext(ctx,
 		cm.chatIdInt, cm.chatIdString, userId,
 	)
 }
 
 /*SetPhoto can be used to set a new profile photo for the chat. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) SetPhoto(photoFile *os.File) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.SetChatPhoto(
+	return cm.SetPhotoContext(context.Background(), photoFile)
+}
+
+/*SetPhotoContext works like "SetPhoto" but sends the request with the given context.*/
+func (cm *ChatManager) SetPhotoContext(ctx context.Context, photoFile *os.File) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.SetChatPhotoContext(ctx,
 		cm.chatIdInt, cm.chatIdString, photoFile,
 	)
 }
 
 /*DeletePhoto can be used to delete a chat photo. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) DeletePhoto() (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.DeleteChatPhoto(
+	return cm.DeletePhotoContext(context.Background())
+}
+
+/*DeletePhotoContext works like "DeletePhoto" but sends the request with the given context.*/
+func (cm *ChatManager) DeletePhotoContext(ctx context.Context) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.DeleteChatPhotoContext(ctx,
 		cm.chatIdInt, cm.chatIdString,
 	)
 }
 
 /*SetTitle changes the title of a chat. Titles can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) SetTitle(title string) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.SetChatTitle(
+	return cm.SetTitleContext(context.Background(), title)
+}
+
+/*SetTitleContext works like "SetTitle" but sends the request with the given context.*/
+func (cm *ChatManager) SetTitleContext(ctx context.Context, title string) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.SetChatTitleContext(ctx,
 		cm.chatIdInt, cm.chatIdString, title,
 	)
 }
 
 /*SetDescription changes the description of a group, a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) SetDescription(description string) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.SetChatDescription(
+	return cm.SetDescriptionContext(context.Background(), description)
+}
+
+/*SetDescriptionContext works like "SetDescription" but sends the request with the given context.*/
+func (cm *ChatManager) SetDescriptionContext(ctx context.Context, description string) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.SetChatDescriptionContext(ctx,
 		cm.chatIdInt, cm.chatIdString, description,
 	)
 }
 
 /*PinMessage adds a message to the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.*/
 func (cm *ChatManager) PinMessage(messageId int, disableNotif bool) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.PinChatMessage(
+	return cm.PinMessageContext(context.Background(), messageId, disableNotif)
+}
+
+/*PinMessageContext works like "PinMessage" but sends the request with the given context.*/
+func (cm *ChatManager) PinMessageContext(ctx context.Context, messageId int, disableNotif bool) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.PinChatMessageContext(ctx,
 		cm.chatIdInt, cm.chatIdString, messageId, disableNotif,
 	)
 }
 
 /*UnpinMessage removes a message from the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.*/
 func (cm *ChatManager) UnpinMessage(messageId int) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.UnpinChatMessage(
+	return cm.UnpinMessageContext(context.Background(), messageId)
+}
+
+/*UnpinMessageContext works like "UnpinMessage" but sends the request with the given context.*/
+func (cm *ChatManager) UnpinMessageContext(ctx context.Context, messageId int) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.UnpinChatMessageContext(ctx,
 		cm.chatIdInt, cm.chatIdString, messageId,
 	)
 }
 
 /*UnpinAllMessages clears the list of pinned messages in a chat. If the chat is not a private chat, the bot must be an administrator in the chat for this to work and must have the 'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in a channel. Returns True on success.*/
 func (cm *ChatManager) UnpinAllMessages() (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.UnpinAllChatMessages(
+	return cm.UnpinAllMessagesContext(context.Background())
+}
+
+/*UnpinAllMessagesContext works like "UnpinAllMessages" but sends the request with the given context.*/
+func (cm *ChatManager) UnpinAllMessagesContext(ctx context.Context) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.UnpinAllChatMessagesContext(ctx,
 		cm.chatIdInt, cm.chatIdString,
 	)
 }
 
 /*Leave can be used for your bot to leave a group, supergroup or channel. Returns True on success.*/
 func (cm *ChatManager) Leave() (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.LeaveChat(
+	return cm.LeaveContext(context.Background())
+}
+
+/*LeaveContext works like "Leave" but sends the request with the given context.*/
+func (cm *ChatManager) LeaveContext(ctx context.Context) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.LeaveChatContext(ctx,
 		cm.chatIdInt, cm.chatIdString,
 	)
 }
 
 /*GetChatInfo gets up to date information about the chat (current name of the user for one-on-one conversations, current username of a user, group or channel, etc.). Returns a Chat object on success.*/
 func (cm *ChatManager) GetChatInfo() (*objs.ChatResult, error) {
-	return cm.bot.apiInterface.GetChat(
+	return cm.GetChatInfoContext(context.Background())
+}
+
+/*GetChatInfoContext works like "GetChatInfo" but sends the request with the given context.*/
+func (cm *ChatManager) GetChatInfoContext(ctx context.Context) (*objs.ChatResult, error) {
+	return cm.bot.apiInterface.GetChatContext(ctx,
 		cm.chatIdInt, cm.chatIdString,
 	)
 }
 
 /*GetAdmins gets a list of administrators in a chat. On success, returns an Array of ChatMember objects that contains information about all chat administrators except other bots. If the chat is a group or a supergroup and no administrators were appointed, only the creator will be returned.*/
 func (cm *ChatManager) GetAdmins() (*objs.ChatAdministratorsResult, error) {
-	return cm.bot.apiInterface.GetChatAdministrators(
+	return cm.GetAdminsContext(context.Background())
+}
+
+/*GetAdminsContext works like "GetAdmins" but sends the request with the given context.*/
+func (cm *ChatManager) GetAdminsContext(ctx context.Context) (*objs.ChatAdministratorsResult, error) {
+	return cm.bot.apiInterface.GetChatAdministratorsContext(ctx,
 		cm.chatIdInt, cm.chatIdString,
 	)
 }
 
 /*GetMembersCount gets the number of members in a chat. Returns Int on success.*/
 func (cm *ChatManager) GetMembersCount() (*objs.IntResult, error) {
-	return cm.bot.apiInterface.GetChatMemberCount(
+	return cm.GetMembersCountContext(context.Background())
+}
+
+/*GetMembersCountContext works like "GetMembersCount" but sends the request with the given context.*/
+func (cm *ChatManager) GetMembersCountContext(ctx context.Context) (*objs.IntResult, error) {
+	return cm.bot.apiInterface.GetChatMemberCountContext(ctx,
 		cm.chatIdInt,
 		cm.chatIdString,
 	)
@@ -213,7 +339,12 @@ func (cm *ChatManager) GetMembersCount() (*objs.IntResult, error) {
 
 /*GetMember gets information about a member of a chat. Returns a json serialized object of the member in string form on success.*/
 func (cm *ChatManager) GetMember(userid int) (string, error) {
-	res, err := cm.bot.apiInterface.GetChatMember(
+	return cm.GetMemberContext(context.Background(), userid)
+}
+
+/*GetMemberContext works like "GetMember" but sends the request with the given context.*/
+func (cm *ChatManager) GetMemberContext(ctx context.Context, userid int) (string, error) {
+	res, err := cm.bot.apiInterface.GetChatMemberContext(ctx,
 		cm.chatIdInt, cm.chatIdString, userid,
 	)
 	if err != nil {
@@ -224,14 +355,24 @@ func (cm *ChatManager) GetMember(userid int) (string, error) {
 
 /*SetStickerSet sets a new group sticker set for a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Use the field can_set_sticker_set optionally returned in "GetChatInfo" to check if the bot can use this method. Returns True on success.*/
 func (cm *ChatManager) SetStickerSet(stickerSetName string) (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.SetChatStickerSet(
+	return cm.SetStickerSetContext(context.Background(), stickerSetName)
+}
+
+/*SetStickerSetContext works like "SetStickerSet" but sends the request with the given context.*/
+func (cm *ChatManager) SetStickerSetContext(ctx context.Context, stickerSetName string) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.SetChatStickerSetContext(ctx,
 		cm.chatIdInt, cm.chatIdString, stickerSetName,
 	)
 }
 
 /*DeleteStickerSet deletes a group sticker set from a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Use the field can_set_sticker_set optionally returned in "GetChatInfo" to check if the bot can use this method. Returns True on success.*/
 func (cm *ChatManager) DeleteStickerSet() (*objs.LogicalResult, error) {
-	return cm.bot.apiInterface.DeleteChatStickerSet(
+	return cm.DeleteStickerSetContext(context.Background())
+}
+
+/*DeleteStickerSetContext works like "DeleteStickerSet" but sends the request with the given context.*/
+func (cm *ChatManager) DeleteStickerSetContext(ctx context.Context) (*objs.LogicalResult, error) {
+	return cm.bot.apiInterface.DeleteChatStickerSetContext(ctx,
 		cm.chatIdInt, cm.chatIdString,
 	)
 }
@@ -1,6 +1,7 @@
 package telego
 
 import (
+	"context"
 	"errors"
 
 	objs "github.com/SakoDroid/telego/objects"
@@ -60,26 +61,41 @@ Official telegram doc :
 
 Use this method to change the list of the bot's commands. See https://core.telegram.org/bots#commands for more details about bot commands. Returns True on success.*/
 func (cm *CommandsManager) SetCommands(languageCode string) (*objs.LogicalResult, error) {
+	return cm.SetCommandsContext(context.Background(), languageCode)
+}
+
+/*SetCommandsContext works like "SetCommands" but sends the request with the given context.*/
+func (cm *CommandsManager) SetCommandsContext(ctx context.Context, languageCode string) (*objs.LogicalResult, error) {
 	if cm.scope == nil {
 		return nil, errors.New("scope is not set. Use `SetScope` method")
 	}
-	return cm.bot.apiInterface.SetMyCommands(cm.commands, cm.scope, languageCode)
+	return cm.bot.apiInterface.SetMyCommandsContext(ctx, cm.commands, cm.scope, languageCode)
 }
 
 /*DeleteCommands can be used to delete the list of the bot's commands for the given scope and user language. After deletion, higher level commands will be shown to affected users. Returns True on success.*/
 func (cm *CommandsManager) DeleteCommands(languageCode string) (*objs.LogicalResult, error) {
+	return cm.DeleteCommandsContext(context.Background(), languageCode)
+}
+
+/*DeleteCommandsContext works like "DeleteCommands" but sends the request with the given context.*/
+func (cm *CommandsManager) DeleteCommandsContext(ctx context.Context, languageCode string) (*objs.LogicalResult, error) {
 	if cm.scope == nil {
 		return nil, errors.New("scope is not set. Use `SetScope` method")
 	}
-	return cm.bot.apiInterface.DeleteMyCommands(cm.scope, languageCode)
+	return cm.bot.apiInterface.DeleteMyCommandsContext(ctx, cm.scope, languageCode)
 }
 
 //GetCommands returns the commands of this bot.
 func (cm *CommandsManager) GetCommands(languageCode string) ([]objs.BotCommand, error) {
+	return cm.GetCommandsContext(context.Background(), languageCode)
+}
+
+/*GetCommandsContext works like "GetCommands" but sends the request with the given context.*/
+func (cm *CommandsManager) GetCommandsContext(ctx context.Context, languageCode string) ([]objs.BotCommand, error) {
 	if cm.scope == nil {
 		return nil, errors.New("scope is not set. Use `SetScope` method")
 	}
-	res, err := cm.bot.apiInterface.GetMyCommands(cm.scope, languageCode)
+	res, err := cm.bot.apiInterface.GetMyCommandsContext(ctx, cm.scope, languageCode)
 	if err != nil {
 		return nil, err
 	}
@@ -2,8 +2,10 @@ package configs
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 )
 
 type cfgTest struct {
@@ -22,6 +24,30 @@ func TestConfigCheck(t *testing.T) {
 	}
 }
 
+func TestWebhookPathAndAddress(t *testing.T) {
+	whc := &WebHookConfigs{URL: "https://example.com/hook", PlainHTTP: true, Path: "updates"}
+	if !whc.check("key") {
+		t.Fatal("check failed")
+	}
+	if whc.URL != "https://example.com/hook" || whc.GetPath("key") != "/updates" || whc.GetListenAddress() != ":80" {
+		t.Error("wrong url, path or address :", whc.URL, whc.GetPath("key"), whc.GetListenAddress())
+	}
+	whc = &WebHookConfigs{URL: "https://example.com", KeyFile: "k", CertFile: "c", ListenAddress: "127.0.0.1:8080"}
+	if !whc.check("key") {
+		t.Fatal("check failed")
+	}
+	if whc.URL != "https://example.com/key" || whc.GetPath("key") != "/key" || whc.GetListenAddress() != "127.0.0.1:8080" {
+		t.Error("wrong url, path or address :", whc.URL, whc.GetPath("key"), whc.GetListenAddress())
+	}
+	whc = &WebHookConfigs{URL: "https://example.com", GenerateCertificate: true}
+	if !whc.check("123:abc") {
+		t.Fatal("check failed")
+	}
+	if whc.CertFile != "webhook-123.crt" || whc.KeyFile != "webhook-123.key" || !whc.SelfSigned {
+		t.Error("wrong certificate files :", whc.CertFile, whc.KeyFile)
+	}
+}
+
 func TestLoadAndDump(t *testing.T) {
 	bc1 := Default("123hUHASDa66aDTDAFshdASDKabda6dg982edua")
 	err := Dump(bc1)
@@ -40,6 +66,30 @@ func TestLoadAndDump(t *testing.T) {
 	_ = os.Remove("configs.json")
 }
 
+func TestDumpFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bot.json")
+	bc1 := Default("123hUHASDa66aDTDAFshdASDKabda6dg982edua")
+	bc1.BlockedUsers = []BlockedUser{{UserID: 1, UserName: "a_long_user_name"}}
+	if err := DumpFile(bc1, path); err != nil {
+		t.Fatal(err)
+	}
+	//A shorter dump should replace the whole file.
+	bc2 := Default("1:a")
+	if err := DumpFile(bc2, path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bc2, loaded) {
+		t.Error("loaded configs are not equal to the last dumped configs")
+	}
+	if Default("1:a").GetConfigFile() != DefaultConfigFile {
+		t.Error("wrong default config file")
+	}
+}
+
 func initTheCfgs() {
 	cfg1 := cfgTest{&BotConfigs{}, false}
 	cfg2 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI}, false}
@@ -47,5 +97,51 @@ func initTheCfgs() {
 	cfg4 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{}}, false}
 	cfg5 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: false}, false}
 	cfg6 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: false, UpdateConfigs: DefaultUpdateConfigs()}, true}
-	cfgs = []cfgTest{cfg1, cfg2, cfg3, cfg4, cfg5, cfg6}
+	cfg7 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", UpdateConfigs: DefaultUpdateConfigs(), DispatcherConfigs: DefaultDispatcherConfigs()}, true}
+	cfg8 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", UpdateConfigs: DefaultUpdateConfigs(), DispatcherConfigs: &DispatcherConfigs{FullQueuePolicy: "wait"}}, false}
+	cfg9 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", UpdateConfigs: DefaultUpdateConfigs(), DispatcherConfigs: &DispatcherConfigs{Workers: -1}}, false}
+	cfg10 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", PlainHTTP: true}}, true}
+	cfg11 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", ExternalServer: true}}, true}
+	cfg12 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", KeyFile: "key"}}, false}
+	cfg13 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", ExternalServer: true, MaxBodySize: -1}}, false}
+	cfg14 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", ExternalServer: true, VerifySourceIP: true, ResponseTimeout: time.Second}}, true}
+	cfg15 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", GenerateCertificate: true}}, true}
+	cfg16 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", Webhook: true, WebHookConfigs: &WebHookConfigs{URL: "https://example.com", PlainHTTP: true, GenerateCertificate: true}}, false}
+	cfg17 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", UpdateConfigs: DefaultUpdateConfigs(), MaxDownloadSize: -1}, false}
+	cfg18 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", UpdateConfigs: DefaultUpdateConfigs(), HTTPConfigs: &HTTPConfigs{Proxy: "socks5://127.0.0.1:1080", Timeout: time.Minute}}, true}
+	cfg19 := cfgTest{&BotConfigs{BotAPI: DefaultBotAPI, APIKey: "sisduifhdsfsdf", UpdateConfigs: DefaultUpdateConfigs(), HTTPConfigs: &HTTPConfigs{Proxy: "127.0.0.1"}}, false}
+	cfgs = []cfgTest{cfg1, cfg2, cfg3, cfg4, cfg5, cfg6, cfg7, cfg8, cfg9, cfg10, cfg11, cfg12, cfg13, cfg14, cfg15, cfg16, cfg17, cfg18, cfg19}
+}
+
+func TestReloadRuntimeFields(t *testing.T) {
+	bc := Default("1:token")
+	bc.DispatcherConfigs = DefaultDispatcherConfigs()
+	dc := bc.DispatcherConfigs
+	done := make(chan bool)
+	go func() {
+		//Reads of the running bot.
+		for i := 0; i < 100; i++ {
+			bc.GetBlockedUsers()
+			bc.GetMaxDownloadSize()
+			_ = dc.QueueSize
+		}
+		close(done)
+	}()
+	err := bc.reload([]byte(`{"api_key":"2:token","blocked_users":[{"user_id":5}],"max_download_size":100,"dispatcher_configs":{"queue_size":5}}`))
+	<-done
+	if err != nil {
+		t.Fatal(err)
+	}
+	if users := bc.GetBlockedUsers(); len(users) != 1 || users[0].UserID != 5 || bc.GetMaxDownloadSize() != 100 {
+		t.Error("runtime fields were not reloaded :", users, bc.GetMaxDownloadSize())
+	}
+	if bc.GetShutdownTimeout() != DefaultShutdownTimeout {
+		t.Error("missing field was changed :", bc.GetShutdownTimeout())
+	}
+	if bc.APIKey != "1:token" || bc.DispatcherConfigs != dc || dc.QueueSize != 100 {
+		t.Error("fields used by the running bot were changed")
+	}
+	if err = bc.reload([]byte(`{"max_download_size":-1}`)); err == nil || bc.GetMaxDownloadSize() != 100 {
+		t.Error("invalid configs were applied")
+	}
 }
@@ -0,0 +1,284 @@
+package telego
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	errs "github.com/SakoDroid/telego/errors"
+	logger "github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+	upp "github.com/SakoDroid/telego/parser"
+	ss "github.com/SakoDroid/telego/session"
+)
+
+/*Context is passed to the context handlers. It holds the received update, the bot and the submatches of the handler's regex, and has several methods for replying to the update without re-deriving the chat id and the sender.
+
+Context also implements context.Context. It is cancelled when the bot has stopped, after the running handlers have returned or "ShutdownTimeout" of the configs has passed, so it can be passed to the long running operations of the handler and the handlers can still send their requests while the bot is shutting down. The requests sent by the methods of Context are sent with it too.*/
+type Context struct {
+	context.Context
+	//Update is the received update.
+	Update *objs.Update
+	//Bot is the bot which has received the update.
+	Bot *Bot
+	//Matches holds the submatches of the handler's regex. Matches[0] is the whole matched text. It's nil for callback handlers.
+	Matches []string
+	params  map[string]string
+	args    map[string]interface{}
+	session *ss.Session
+}
+
+//Chat returns the chat this update belongs to. Returns nil if the update does not belong to a chat.
+func (c *Context) Chat() *objs.Chat {
+	return upp.GetChat(c.Update)
+}
+
+//Sender returns the user who has sent this update. Returns nil if the update has no sender.
+func (c *Context) Sender() *objs.User {
+	return upp.GetSender(c.Update)
+}
+
+//ChatId returns the id of the chat this update belongs to. Returns 0 if the update does not belong to a chat.
+func (c *Context) ChatId() int {
+	chat := c.Chat()
+	if chat == nil {
+		return 0
+	}
+	return chat.Id
+}
+
+/*Message returns the message of the update. For callback queries, the message with the callback button is returned. Returns nil if the update has no message.*/
+func (c *Context) Message() *objs.Message {
+	switch {
+	case c.Update.Message != nil:
+		return c.Update.Message
+	case c.Update.EditedMessage != nil:
+		return c.Update.EditedMessage
+	case c.Update.ChannelPost != nil:
+		return c.Update.ChannelPost
+	case c.Update.EditedChannelPost != nil:
+		return c.Update.EditedChannelPost
+	case c.Update.CallbackQuery != nil && c.Update.CallbackQuery.InlineMessageId == "":
+		return &c.Update.CallbackQuery.Message
+	}
+	return nil
+}
+
+/*Session loads the session of the sender in this chat. The session is loaded once and cached in the context.*/
+func (c *Context) Session() (*ss.Session, error) {
+	if c.session != nil {
+		return c.session, nil
+	}
+	session, err := c.Bot.GetSession(c.Update)
+	if err != nil {
+		return nil, err
+	}
+	c.session = session
+	return session, nil
+}
+
+//Send sends a text message to the chat this update belongs to.
+func (c *Context) Send(text string) (*objs.SendMethodsResult, error) {
+	chatId, err := c.getChatId()
+	if err != nil {
+		return nil, err
+	}
+	return c.Bot.SendMessageContext(c.requestContext(), chatId, text, "", 0, false, false)
+}
+
+//Reply sends a text message to the chat this update belongs to as a reply to the received message.
+func (c *Context) Reply(text string) (*objs.SendMethodsResult, error) {
+	chatId, err := c.getChatId()
+	if err != nil {
+		return nil, err
+	}
+	return c.Bot.SendMessageContext(c.requestContext(), chatId, text, "", c.getReplyTo(), false, false)
+}
+
+//RespondInWebhook answers the webhook request of this update with a call of the given method. See "RespondInWebhook" method of the bot.
+func (c *Context) RespondInWebhook(method string, args objs.MethodArguments) bool {
+	return c.Bot.RespondInWebhook(c.Update, method, args)
+}
+
+/*ReplyInWebhook answers the webhook request of this update with a text message sent to the chat this update belongs to as a reply to the received message. Returns false if the update has no chat or the response can't be set (see "RespondInWebhook" method of the bot), in which case "Reply" should be used.*/
+func (c *Context) ReplyInWebhook(text string) bool {
+	chatId, err := c.getChatId()
+	if err != nil {
+		return false
+	}
+	args := &objs.SendMessageArgs{Text: text}
+	args.ChatId = json.RawMessage(strconv.Itoa(chatId))
+	args.ReplyToMessageId = c.getReplyTo()
+	return c.RespondInWebhook("sendMessage", args)
+}
+
+//ReplyPhoto sends a photo (using its file id or url) to the chat this update belongs to as a reply to the received message. To ignore caption pass empty string.
+func (c *Context) ReplyPhoto(fileIdOrUrl, caption string) (*objs.SendMethodsResult, error) {
+	chatId, err := c.getChatId()
+	if err != nil {
+		return nil, err
+	}
+	return c.Bot.SendPhoto(chatId, c.getReplyTo(), caption, "").SendByFileIdOrUrlContext(c.requestContext(), fileIdOrUrl, false, false)
+}
+
+//ReplyPhotoFile uploads the given photo and sends it to the chat this update belongs to as a reply to the received message. To ignore caption pass empty string.
+func (c *Context) ReplyPhotoFile(file *os.File, caption string) (*objs.SendMethodsResult, error) {
+	chatId, err := c.getChatId()
+	if err != nil {
+		return nil, err
+	}
+	return c.Bot.SendPhoto(chatId, c.getReplyTo(), caption, "").SendByFileContext(c.requestContext(), file, false, false)
+}
+
+/*EditCallbackMessage edits the text of the message that the pressed callback button belongs to. Pass nil for keyboard to remove the inline keyboard of the message. Returns an error if the update is not a callback query.*/
+func (c *Context) EditCallbackMessage(text string, keyboard *inlineKeyboard) (*objs.DefaultResult, error) {
+	cq := c.Update.CallbackQuery
+	if cq == nil {
+		return nil, &errs.UpdateTypeMismatch{Expected: "a callback query"}
+	}
+	if cq.InlineMessageId != "" {
+		return c.Bot.GetMsgEditor(0).EditTextContext(c.requestContext(), 0, text, cq.InlineMessageId, "", nil, false, keyboard)
+	}
+	chatId, err := c.getChatId()
+	if err != nil {
+		return nil, err
+	}
+	return c.Bot.GetMsgEditor(chatId).EditTextContext(c.requestContext(), cq.Message.MessageId, text, "", "", nil, false, keyboard)
+}
+
+/*AnswerCallback answers the callback query of the update. The answer is displayed to the user as a notification at the top of the chat screen or as an alert if "showAlert" is true. Pass empty string for text to just stop the loading animation of the button. Returns an error if the update is not a callback query.*/
+func (c *Context) AnswerCallback(text string, showAlert bool) (*objs.LogicalResult, error) {
+	if c.Update.CallbackQuery == nil {
+		return nil, &errs.UpdateTypeMismatch{Expected: "a callback query"}
+	}
+	return c.Bot.AnswerCallbackQueryContext(c.requestContext(), c.Update.CallbackQuery.Id, text, showAlert)
+}
+
+/*Param returns the value of the named group of the handler's regex, like "id" in `^/user (?P<id>\d+)$`. Returns an empty string if the group does not exist or has not matched.*/
+func (c *Context) Param(name string) string {
+	return c.params[name]
+}
+
+/*Arg returns the value of the given argument of a command handler. The value is an int, float64, bool or string according to the type of the argument in the command syntax. Returns nil if the argument is not given.*/
+func (c *Context) Arg(name string) interface{} {
+	return c.args[name]
+}
+
+//ArgString returns the value of the given string argument of a command handler. Returns an empty string if the argument is not given.
+func (c *Context) ArgString(name string) string {
+	out, _ := c.args[name].(string)
+	return out
+}
+
+//ArgInt returns the value of the given int argument of a command handler. Returns 0 if the argument is not given.
+func (c *Context) ArgInt(name string) int {
+	out, _ := c.args[name].(int)
+	return out
+}
+
+//ArgFloat returns the value of the given float argument of a command handler. Returns 0 if the argument is not given.
+func (c *Context) ArgFloat(name string) float64 {
+	out, _ := c.args[name].(float64)
+	return out
+}
+
+//ArgBool returns the value of the given bool argument of a command handler. Returns false if the argument is not given.
+func (c *Context) ArgBool(name string) bool {
+	out, _ := c.args[name].(bool)
+	return out
+}
+
+//HasArg returns true if the given argument of a command handler is given.
+func (c *Context) HasArg(name string) bool {
+	_, ok := c.args[name]
+	return ok
+}
+
+/*requestContext returns the context the requests of the handler are sent with, so they are cancelled when the bot has stopped.*/
+func (c *Context) requestContext() context.Context {
+	if c.Context == nil {
+		return context.Background()
+	}
+	return c.Context
+}
+
+func (c *Context) getChatId() (int, error) {
+	chat := c.Chat()
+	if chat == nil {
+		return 0, &errs.UpdateTypeMismatch{Expected: "a chat"}
+	}
+	return chat.Id, nil
+}
+
+func (c *Context) getReplyTo() int {
+	switch {
+	case c.Update.Message != nil:
+		return c.Update.Message.MessageId
+	case c.Update.ChannelPost != nil:
+		return c.Update.ChannelPost.MessageId
+	}
+	return 0
+}
+
+func (bot *Bot) createContext(up *objs.Update, match *upp.Match) *Context {
+	bot.runLock.Lock()
+	ctx := bot.handlerCtx
+	bot.runLock.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c := &Context{Context: ctx, Update: up, Bot: bot}
+	if match != nil {
+		c.Matches = match.Groups
+		c.params = match.Named
+	}
+	return c
+}
+
+//matchHandler converts a context handler to a text handler of the parser.
+func (bot *Bot) matchHandler(handler func(*Context) error) upp.MatchHandlerFunc {
+	return func(up *objs.Update, match *upp.Match) {
+		bot.runContextHandler(handler, bot.createContext(up, match))
+	}
+}
+
+/*callbackHandler converts a context handler to a callback handler of the parser. If codec is not nil, the callback data is decoded into the arguments of the context.*/
+func (bot *Bot) callbackHandler(handler func(*Context) error, codec *upp.CallbackCodec) upp.MatchHandlerFunc {
+	return func(up *objs.Update, match *upp.Match) {
+		bot.answerIfForgotten(up, func(up *objs.Update) {
+			c := bot.createContext(up, match)
+			if codec != nil {
+				args, err := codec.Decode(up.CallbackQuery.Data)
+				if err != nil {
+					logger.Log("Error", "\t\t\t", err.Error(), "", logger.BOLD+logger.FAIL, logger.WARNING, "")
+					return
+				}
+				c.args = args
+			}
+			bot.runContextHandler(handler, c)
+		})
+	}
+}
+
+/*answerIfForgotten executes the given callback handler and answers the callback query if the handler has not answered it.*/
+func (bot *Bot) answerIfForgotten(up *objs.Update, handler func(*objs.Update)) {
+	id := up.CallbackQuery.Id
+	bot.pendingCallbacks.Store(id, true)
+	defer func() {
+		if _, ok := bot.pendingCallbacks.LoadAndDelete(id); ok {
+			_, err := bot.AnswerCallbackQuery(id, "", false)
+			if err != nil {
+				logger.Log("Error", "\t\t\t", "Could not answer callback query : "+err.Error(), "", logger.BOLD+logger.FAIL, logger.WARNING, "")
+			}
+		}
+	}()
+	handler(up)
+}
+
+func (bot *Bot) runContextHandler(handler func(*Context) error, c *Context) {
+	err := handler(c)
+	if err != nil {
+		bot.handleError(c.Update, err)
+	}
+}
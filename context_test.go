@@ -0,0 +1,184 @@
+package telego
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	errs "github.com/SakoDroid/telego/errors"
+	logger "github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+	upp "github.com/SakoDroid/telego/parser"
+)
+
+func TestContextHandler(t *testing.T) {
+	bot := &Bot{parser: upp.NewParser()}
+	var received *Context
+	handler := bot.matchHandler(func(c *Context) error {
+		received = c
+		return nil
+	})
+	up := createTestMessage(1, "/ban 42")
+	handler(up, &upp.Match{Groups: []string{"/ban 42", "42"}, Named: map[string]string{"user": "42"}})
+	if received == nil || received.Update != up || received.Bot != bot {
+		t.Fatal("context was not created properly")
+	}
+	if len(received.Matches) != 2 || received.Matches[1] != "42" {
+		t.Error("wrong matches :", received.Matches)
+	}
+	if received.Param("user") != "42" {
+		t.Error("wrong named group :", received.Param("user"))
+	}
+	if received.ChatId() != 10 || received.Sender().Id != 1 || received.Message() != up.Message {
+		t.Error("wrong chat, sender or message")
+	}
+	if received.Err() != nil {
+		t.Error("context of a bot that is not running should not be cancelled")
+	}
+	var mismatch *errs.UpdateTypeMismatch
+	if _, err := received.AnswerCallback("", false); !errors.As(err, &mismatch) {
+		t.Error("AnswerCallback did not fail for a message update :", err)
+	}
+	if _, err := (&Context{Update: &objs.Update{}}).Send("hi"); !errors.As(err, &mismatch) {
+		t.Error("Send did not fail for an update without chat :", err)
+	}
+}
+
+func TestContextHandlerError(t *testing.T) {
+	bot := &Bot{parser: upp.NewParser()}
+	failure := errors.New("failed")
+	var received error
+	bot.SetErrorHandler(func(up *objs.Update, err error) {
+		received = err
+	})
+	bot.matchHandler(func(c *Context) error {
+		return failure
+	})(createTestMessage(1, "hi"), &upp.Match{})
+	if received != failure {
+		t.Error("returned error was not passed to the error handler :", received)
+	}
+}
+
+func TestContextRequests(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	release := make(chan bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		io.Copy(io.Discard, req.Body)
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+	cfg := cfgs.Default("1:token")
+	cfg.BotAPI = srv.URL + "/bot"
+	bot, err := NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//Requests of the context handlers are cancelled with the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	c := &Context{Context: ctx, Update: createTestMessage(1, "hi"), Bot: bot}
+	if _, err = c.Reply("hello"); !errors.Is(err, context.Canceled) {
+		t.Fatal("reply was not cancelled", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = bot.SendDocument(10, 0, "", "").SendByReaderContext(ctx, strings.NewReader("report"), "report.txt", "", false, false)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("upload was not cancelled", err)
+	}
+
+	//Requests of the tools are cancelled with the given context.
+	cancelled, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	if _, err = bot.GetChatManagerById(10).BanMemberContext(cancelled, 1, 0, false); !errors.Is(err, context.Canceled) {
+		t.Error("ban was not cancelled", err)
+	}
+	if _, err = bot.GetMsgEditor(10).EditMediaPhoto(1, "", "", nil, nil).EditByFileIdOrURLContext(cancelled, "file"); !errors.Is(err, context.Canceled) {
+		t.Error("media edit was not cancelled", err)
+	}
+	if _, err = bot.AdvancedMode().ASendGameContext(cancelled, 10, "game", false, 0, false, nil); !errors.Is(err, context.Canceled) {
+		t.Error("game was not cancelled", err)
+	}
+	if _, err = bot.GetStickerSetContext(cancelled, "set"); !errors.Is(err, context.Canceled) {
+		t.Error("getting the sticker set was not cancelled", err)
+	}
+}
+
+func TestContextDuringShutdown(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	var served int32
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/getWebhookInfo"):
+			wr.Write([]byte(`{"ok":true,"result":{"url":""}}`))
+		case strings.HasSuffix(req.URL.Path, "/getUpdates"):
+			if atomic.AddInt32(&served, 1) == 1 {
+				wr.Write([]byte(`{"ok":true,"result":[{"update_id":1,"message":{"message_id":1,"text":"/slow","chat":{"id":10,"type":"private"},"from":{"id":1}}}]}`))
+				return
+			}
+			select {
+			case <-req.Context().Done():
+			case <-time.After(100 * time.Millisecond):
+			}
+			wr.Write([]byte(`{"ok":true,"result":[]}`))
+		case strings.HasSuffix(req.URL.Path, "/sendMessage"):
+			wr.Write([]byte(`{"ok":true,"result":{"message_id":2,"chat":{"id":10,"type":"private"}}}`))
+		default:
+			wr.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}))
+	defer srv.Close()
+	cfg := cfgs.Default("1:token")
+	cfg.BotAPI = srv.URL + "/bot"
+	cfg.DisableConfigFile = true
+	bot, err := NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started, release, sent := make(chan bool), make(chan bool), make(chan error, 1)
+	err = bot.AddContextHandler("^/slow$", func(c *Context) error {
+		close(started)
+		<-release
+		_, err := c.Send("done")
+		sent <- err
+		return nil
+	}, "all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- bot.RunContext(ctx)
+	}()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler was not called")
+	}
+	cancel()
+	//Let the shutdown begin before the handler sends its request.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	if err := <-sent; err != nil {
+		t.Error("request of the handler failed during the shutdown :", err)
+	}
+	if err := <-stopped; err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,273 @@
+package telego
+
+import (
+	"errors"
+	"regexp"
+	"sync"
+	"time"
+
+	logger "github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+	upp "github.com/SakoDroid/telego/parser"
+)
+
+//EndConversation is the state that should be returned by a conversation handler to end the conversation.
+const EndConversation = ""
+
+/*ConversationHandler processes an update received in a conversation and returns the next state of the conversation. Returning the current state keeps the conversation in the same state and returning "EndConversation" ends the conversation.*/
+type ConversationHandler func(*objs.Update) string
+
+/*Conversation is a multi-step dialog with the users. A conversation consists of some states and a handler for each state. Conversation begins when one of its entry points is triggered. After that, messages and callback queries received from the user are passed to the handler of the current state instead of the handlers and channels of the bot, until the conversation is ended, cancelled or timed out.*/
+type Conversation struct {
+	bot            *Bot
+	perChat        bool
+	timeout        time.Duration
+	states         map[string]ConversationHandler
+	cancelRegex    *regexp.Regexp
+	cancelHandler  func(*objs.Update)
+	timeoutHandler func(*objs.Update)
+	active         map[conversationKey]*conversationState
+	lock           sync.Mutex
+}
+
+type conversationKey struct {
+	chatId, userId int
+}
+
+type conversationState struct {
+	state      string
+	lastUpdate *objs.Update
+	timer      *time.Timer
+	lock       sync.Mutex
+}
+
+/*AddState adds a state to the conversation. Everytime an update is received in this state, the given handler is called and the returned value will be the next state of the conversation.*/
+func (conv *Conversation) AddState(state string, handler ConversationHandler) {
+	conv.lock.Lock()
+	defer conv.lock.Unlock()
+	conv.states[state] = handler
+}
+
+/*AddEntryCommand adds an entry point to the conversation. When a text message matching the given regex pattern is received in one of the given chat types and the sender is not already in this conversation, the conversation begins and the state returned by the handler will be the first state of the conversation.
+
+"chatType" must be "private","group","supergroup","channel" or "all".*/
+func (conv *Conversation) AddEntryCommand(pattern string, handler ConversationHandler, chatTypes ...string) error {
+	return conv.bot.AddHandler(pattern, func(up *objs.Update) {
+		conv.begin(up, handler)
+	}, chatTypes...)
+}
+
+/*AddEntryCallback adds an entry point to the conversation. When a callback query with the given data is received, the conversation begins and the state returned by the handler will be the first state of the conversation. If the handler does not answer the callback query, it is answered automatically after the handler returns.*/
+func (conv *Conversation) AddEntryCallback(callbackData string, handler ConversationHandler) {
+	conv.bot.parser.AddCallbackHandler(callbackData, func(up *objs.Update) {
+		conv.bot.answerIfForgotten(up, func(up *objs.Update) {
+			conv.begin(up, handler)
+		})
+	})
+}
+
+/*SetCancelCommand sets a command for cancelling the conversation. When a text message matching the given regex pattern is received during the conversation, the conversation is ended and the given handler is called. handler can be nil.*/
+func (conv *Conversation) SetCancelCommand(pattern string, handler func(*objs.Update)) error {
+	rgx, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	conv.lock.Lock()
+	defer conv.lock.Unlock()
+	conv.cancelRegex = rgx
+	conv.cancelHandler = handler
+	return nil
+}
+
+/*SetTimeoutHandler sets a function that is called with the last received update of the conversation when the conversation times out. Panics of the handler are passed to the error handler of the bot.*/
+func (conv *Conversation) SetTimeoutHandler(handler func(*objs.Update)) {
+	conv.lock.Lock()
+	defer conv.lock.Unlock()
+	conv.timeoutHandler = handler
+}
+
+/*Start begins the conversation for the given user in the given chat with the given state. This method can be used to start a conversation from any place of the code. If the user is already in this conversation, their state is changed.
+
+If the conversation is per chat, "userId" is ignored.*/
+func (conv *Conversation) Start(chatId, userId int, state string) error {
+	conv.lock.Lock()
+	defer conv.lock.Unlock()
+	if conv.states[state] == nil {
+		return errors.New("unknown state : " + state)
+	}
+	key := conv.createKey(chatId, userId)
+	st := conv.active[key]
+	if st == nil {
+		st = &conversationState{}
+		conv.active[key] = st
+	}
+	st.state = state
+	conv.resetTimer(key, st)
+	return nil
+}
+
+/*GetState returns the current state of the given user in the given chat. If the user is not in this conversation, "EndConversation" is returned.
+
+If the conversation is per chat, "userId" is ignored.*/
+func (conv *Conversation) GetState(chatId, userId int) string {
+	conv.lock.Lock()
+	defer conv.lock.Unlock()
+	st := conv.active[conv.createKey(chatId, userId)]
+	if st == nil {
+		return EndConversation
+	}
+	return st.state
+}
+
+/*End ends the conversation for the given user in the given chat.
+
+If the conversation is per chat, "userId" is ignored.*/
+func (conv *Conversation) End(chatId, userId int) {
+	conv.lock.Lock()
+	defer conv.lock.Unlock()
+	conv.end(conv.createKey(chatId, userId))
+}
+
+func (conv *Conversation) begin(up *objs.Update, handler ConversationHandler) {
+	key, ok := conv.getKey(up)
+	if !ok {
+		return
+	}
+	next := handler(up)
+	conv.lock.Lock()
+	defer conv.lock.Unlock()
+	if next == EndConversation {
+		return
+	}
+	if conv.states[next] == nil {
+		logger.Logger.Println("Conversation : entry handler returned an unknown state :", next)
+		return
+	}
+	st := &conversationState{state: next, lastUpdate: up}
+	conv.active[key] = st
+	conv.resetTimer(key, st)
+}
+
+/*intercept is the interceptor of the conversation. It returns a function that processes the update if the sender of the update is in this conversation. Callback queries that are not answered by the handler of the state are answered automatically.*/
+func (conv *Conversation) intercept(up *objs.Update) upp.HandlerFunc {
+	if up.Message == nil && up.CallbackQuery == nil {
+		return nil
+	}
+	key, ok := conv.getKey(up)
+	if !ok {
+		return nil
+	}
+	conv.lock.Lock()
+	st := conv.active[key]
+	conv.lock.Unlock()
+	if st == nil {
+		return nil
+	}
+	return func(up *objs.Update) {
+		if up.CallbackQuery != nil {
+			conv.bot.answerIfForgotten(up, func(up *objs.Update) {
+				conv.step(key, st, up)
+			})
+			return
+		}
+		conv.step(key, st, up)
+	}
+}
+
+//step processes the given update in the current state of the conversation. Steps of a conversation are executed one at a time, in the order of their updates since the parser orders the functions returned by the interceptors by their chat.
+func (conv *Conversation) step(key conversationKey, st *conversationState, up *objs.Update) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	conv.lock.Lock()
+	if conv.active[key] != st {
+		//Conversation has been ended while this update was waiting.
+		conv.lock.Unlock()
+		return
+	}
+	st.lastUpdate = up
+	if conv.cancelRegex != nil && up.Message != nil && conv.cancelRegex.MatchString(up.Message.Text) {
+		conv.end(key)
+		cancelHandler := conv.cancelHandler
+		conv.lock.Unlock()
+		if cancelHandler != nil {
+			cancelHandler(up)
+		}
+		return
+	}
+	handler := conv.states[st.state]
+	conv.lock.Unlock()
+	next := handler(up)
+	conv.lock.Lock()
+	defer conv.lock.Unlock()
+	if conv.active[key] != st {
+		return
+	}
+	if next == EndConversation {
+		conv.end(key)
+		return
+	}
+	if conv.states[next] == nil {
+		logger.Logger.Println("Conversation : handler of state", st.state, "returned an unknown state :", next, ". Ending the conversation.")
+		conv.end(key)
+		return
+	}
+	st.state = next
+	conv.resetTimer(key, st)
+}
+
+func (conv *Conversation) resetTimer(key conversationKey, st *conversationState) {
+	if conv.timeout <= 0 {
+		return
+	}
+	if st.timer != nil {
+		st.timer.Stop()
+	}
+	st.timer = time.AfterFunc(conv.timeout, func() {
+		conv.lock.Lock()
+		if conv.active[key] != st {
+			conv.lock.Unlock()
+			return
+		}
+		conv.end(key)
+		timeoutHandler := conv.timeoutHandler
+		lastUpdate := st.lastUpdate
+		conv.lock.Unlock()
+		if timeoutHandler != nil && lastUpdate != nil {
+			defer conv.bot.parser.Recover(lastUpdate)
+			timeoutHandler(lastUpdate)
+		}
+	})
+}
+
+func (conv *Conversation) end(key conversationKey) {
+	st := conv.active[key]
+	if st == nil {
+		return
+	}
+	if st.timer != nil {
+		st.timer.Stop()
+	}
+	delete(conv.active, key)
+}
+
+func (conv *Conversation) getKey(up *objs.Update) (conversationKey, bool) {
+	chat := upp.GetChat(up)
+	if chat == nil {
+		return conversationKey{}, false
+	}
+	if conv.perChat {
+		return conversationKey{chatId: chat.Id}, true
+	}
+	user := upp.GetSender(up)
+	if user == nil {
+		return conversationKey{}, false
+	}
+	return conversationKey{chatId: chat.Id, userId: user.Id}, true
+}
+
+func (conv *Conversation) createKey(chatId, userId int) conversationKey {
+	if conv.perChat {
+		return conversationKey{chatId: chatId}
+	}
+	return conversationKey{chatId: chatId, userId: userId}
+}
@@ -0,0 +1,184 @@
+package telego
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	errs "github.com/SakoDroid/telego/errors"
+	logger "github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+	upp "github.com/SakoDroid/telego/parser"
+)
+
+func TestConversationFlow(t *testing.T) {
+	conv := &Conversation{states: make(map[string]ConversationHandler), active: make(map[conversationKey]*conversationState)}
+	answers := make([]string, 0)
+	conv.AddState("name", func(u *objs.Update) string {
+		answers = append(answers, u.Message.Text)
+		return "age"
+	})
+	conv.AddState("age", func(u *objs.Update) string {
+		answers = append(answers, u.Message.Text)
+		return EndConversation
+	})
+	_ = conv.SetCancelCommand("^/cancel$", nil)
+	conv.begin(createTestMessage(1, "/register"), func(u *objs.Update) string { return "name" })
+	if conv.GetState(10, 1) != "name" {
+		t.Fatal("conversation did not begin, state :", conv.GetState(10, 1))
+	}
+	if conv.intercept(createTestMessage(2, "someone else")) != nil {
+		t.Error("update of another user was intercepted")
+	}
+	for _, text := range []string{"John", "25"} {
+		fn := conv.intercept(createTestMessage(1, text))
+		if fn == nil {
+			t.Fatal("update was not intercepted :", text)
+		}
+		fn(createTestMessage(1, text))
+	}
+	if len(answers) != 2 || answers[0] != "John" || answers[1] != "25" {
+		t.Error("wrong answers :", answers)
+	}
+	if conv.GetState(10, 1) != EndConversation {
+		t.Error("conversation was not ended")
+	}
+	_ = conv.Start(10, 1, "name")
+	conv.intercept(createTestMessage(1, "/cancel"))(createTestMessage(1, "/cancel"))
+	if conv.GetState(10, 1) != EndConversation || len(answers) != 2 {
+		t.Error("conversation was not cancelled")
+	}
+}
+
+func TestConversationTimeout(t *testing.T) {
+	conv := &Conversation{bot: &Bot{parser: upp.NewParser()}, timeout: 20 * time.Millisecond, states: make(map[string]ConversationHandler), active: make(map[conversationKey]*conversationState)}
+	conv.AddState("waiting", func(u *objs.Update) string { return "waiting" })
+	timedOut := make(chan bool, 1)
+	conv.SetTimeoutHandler(func(u *objs.Update) { timedOut <- true })
+	conv.begin(createTestMessage(1, "/start"), func(u *objs.Update) string { return "waiting" })
+	select {
+	case <-timedOut:
+	case <-time.After(time.Second):
+		t.Fatal("timeout handler was not called")
+	}
+	if conv.GetState(10, 1) != EndConversation {
+		t.Error("conversation was not ended after timeout")
+	}
+}
+
+func TestConversationTimeoutPanic(t *testing.T) {
+	bot := &Bot{parser: upp.NewParser()}
+	bot.parser.SetErrorHandler(bot.handleError)
+	failed := make(chan error, 1)
+	bot.SetErrorHandler(func(u *objs.Update, err error) { failed <- err })
+	conv := &Conversation{bot: bot, timeout: 20 * time.Millisecond, states: make(map[string]ConversationHandler), active: make(map[conversationKey]*conversationState)}
+	conv.AddState("waiting", func(u *objs.Update) string { return "waiting" })
+	conv.SetTimeoutHandler(func(u *objs.Update) { panic("timeout failed") })
+	conv.begin(createTestMessage(1, "/start"), func(u *objs.Update) string { return "waiting" })
+	select {
+	case err := <-failed:
+		if hp, ok := err.(*errs.HandlerPanic); !ok || hp.Value != "timeout failed" {
+			t.Error("wrong error :", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("panic of the timeout handler was not passed to the error handler")
+	}
+}
+
+func TestConversationStepsOrder(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	bot := &Bot{parser: upp.NewParser()}
+	conv := &Conversation{bot: bot, states: make(map[string]ConversationHandler), active: make(map[conversationKey]*conversationState)}
+	bot.parser.AddInterceptor(conv.intercept)
+	//The middleware holds the earlier updates longer, so their steps would run last if the steps were not ordered.
+	bot.parser.Use(func(next upp.HandlerFunc) upp.HandlerFunc {
+		return func(u *objs.Update) {
+			n, _ := strconv.Atoi(u.Message.Text)
+			time.Sleep(time.Duration(50-n) * 100 * time.Microsecond)
+			next(u)
+		}
+	})
+	answers := make([]string, 0)
+	conv.AddState("counting", func(u *objs.Update) string {
+		answers = append(answers, u.Message.Text)
+		return "counting"
+	})
+	if err := conv.Start(10, 1, "counting"); err != nil {
+		t.Fatal(err)
+	}
+	cfg := cfgs.Default("1:token")
+	uc, cu := make(chan *objs.Update, 50), make(chan *objs.ChatUpdate, 50)
+	for i := 0; i < 50; i++ {
+		bot.parser.ParseSingleUpdate(createTestMessage(1, strconv.Itoa(i)), &uc, &cu, cfg)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bot.parser.WaitForHandlers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) != 50 {
+		t.Fatal("expected 50 steps, got", len(answers))
+	}
+	for i, answer := range answers {
+		if answer != strconv.Itoa(i) {
+			t.Fatal("steps were executed out of order :", answers)
+		}
+	}
+}
+
+func TestConversationAnswersCallbacks(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	var answered int32
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "/answerCallbackQuery") {
+			atomic.AddInt32(&answered, 1)
+		}
+		wr.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+	cfg := cfgs.Default("1:token")
+	cfg.BotAPI = srv.URL + "/bot"
+	bot, err := NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conv := bot.CreateConversation(0, false)
+	conv.AddState("choosing", func(u *objs.Update) string { return EndConversation })
+	conv.AddEntryCallback("begin", func(u *objs.Update) string { return "choosing" })
+	uc, cu := make(chan *objs.Update, 2), make(chan *objs.ChatUpdate, 2)
+	for _, data := range []string{"begin", "choice"} {
+		up := &objs.Update{CallbackQuery: &objs.CallbackQuery{Id: data, Data: data, From: objs.User{Id: 1}, Message: objs.Message{Chat: &objs.Chat{Id: 10, Type: "private"}}}}
+		bot.parser.ParseSingleUpdate(up, &uc, &cu, cfg)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err = bot.parser.WaitForHandlers(ctx)
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(uc) != 0 {
+		t.Error("callback query of the conversation reached the update channel")
+	}
+	if conv.GetState(10, 1) != EndConversation {
+		t.Error("conversation did not process the callback query, state :", conv.GetState(10, 1))
+	}
+	if n := atomic.LoadInt32(&answered); n != 2 {
+		t.Error("callback queries were not answered :", n)
+	}
+}
+
+func createTestMessage(userId int, text string) *objs.Update {
+	return &objs.Update{Message: &objs.Message{Text: text, From: &objs.User{Id: userId}, Chat: &objs.Chat{Id: 10, Type: "private"}}}
+}
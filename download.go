@@ -0,0 +1,156 @@
+package telego
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	errs "github.com/SakoDroid/telego/errors"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+//downloadAttempts is the number of times an interrupted download is resumed in one call.
+const downloadAttempts = 3
+
+/*OpenFile gets the file with the given id and opens it for reading. The file is streamed from the bot API server (or read from the disk if the bot uses a local bot API server), so it's not loaded into the memory. The returned reader must be closed.*/
+func (bot *Bot) OpenFile(ctx context.Context, fileId string) (io.ReadCloser, *objs.File, error) {
+	res, err := bot.apiInterface.GetFileContext(ctx, fileId)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, _, err := bot.apiInterface.OpenFile(ctx, res.Result, 0)
+	if err != nil {
+		return nil, res.Result, err
+	}
+	return body, res.Result, nil
+}
+
+/*DownloadFile downloads the file with the given id and saves it in the given path. If the path is empty, the file is saved in the current directory with the name it has in telegram servers.
+
+The file is written into "<path>.<file unique id>.part" and is renamed when the download is finished. If the download is interrupted, it's resumed from where it has stopped (in this call or a later one) using Range requests. Since the part file is named after the file, a download is never resumed from the part of another file. The part file is removed if the download fails with an error that can't be resumed. Files larger than "MaxDownloadSize" field of the bot configs are not downloaded and errs.FileTooLarge is returned.*/
+func (bot *Bot) DownloadFile(ctx context.Context, fileId, path string) (*objs.File, error) {
+	res, err := bot.apiInterface.GetFileContext(ctx, fileId)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result, bot.downloadFile(ctx, res.Result, path)
+}
+
+/*DownloadPhoto downloads the largest size of the given photo (like the "Photo" field of a message) and saves it in the given path. See "DownloadFile" method.*/
+func (bot *Bot) DownloadPhoto(ctx context.Context, photo []objs.PhotoSize, path string) (*objs.File, error) {
+	largest := LargestPhotoSize(photo)
+	if largest == nil {
+		return nil, errors.New("photo has no size")
+	}
+	return bot.DownloadFile(ctx, largest.FileId, path)
+}
+
+/*DownloadPhotoSizes downloads all the sizes of the given photo into the given directory. The files are named "<width>x<height>" followed by the extension they have in telegram servers. Returns the paths of the saved files.*/
+func (bot *Bot) DownloadPhotoSizes(ctx context.Context, photo []objs.PhotoSize, dir string) ([]string, error) {
+	paths := make([]string, 0, len(photo))
+	for _, size := range photo {
+		res, err := bot.apiInterface.GetFileContext(ctx, size.FileId)
+		if err != nil {
+			return paths, err
+		}
+		path := filepath.Join(dir, strconv.Itoa(size.Width)+"x"+strconv.Itoa(size.Height)+filepath.Ext(res.Result.FilePath))
+		if err = bot.downloadFile(ctx, res.Result, path); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+//LargestPhotoSize returns the largest size of the given photo. Returns nil if the photo has no size.
+func LargestPhotoSize(photo []objs.PhotoSize) *objs.PhotoSize {
+	var largest *objs.PhotoSize
+	for i := range photo {
+		size := &photo[i]
+		if largest == nil || size.Width*size.Height > largest.Width*largest.Height ||
+			(size.Width*size.Height == largest.Width*largest.Height && size.FileSize > largest.FileSize) {
+			largest = size
+		}
+	}
+	return largest
+}
+
+func (bot *Bot) downloadFile(ctx context.Context, file *objs.File, path string) error {
+	if path == "" {
+		path = filepath.Base(file.FilePath)
+	}
+	part := partFile(file, path)
+	for attempt := 1; ; attempt++ {
+		resumable, err := bot.downloadPart(ctx, file, part)
+		if err == nil {
+			break
+		}
+		if !resumable {
+			os.Remove(part)
+			return err
+		}
+		if attempt == downloadAttempts || ctx.Err() != nil {
+			return err
+		}
+	}
+	return os.Rename(part, path)
+}
+
+/*partFile returns the file the given file is downloaded into before it's complete. It's named after the unique id of the file so the download is only resumed from the part of the same file.*/
+func partFile(file *objs.File, path string) string {
+	id := file.FileUniqueId
+	if id == "" {
+		id = file.FileId
+	}
+	return path + "." + id + ".part"
+}
+
+/*resumable returns true if the download that has failed with the given error can be resumed later.*/
+func resumable(ctx context.Context, err error) bool {
+	var tooLarge *errs.FileTooLarge
+	if errors.As(err, &tooLarge) {
+		return false
+	}
+	return ctx.Err() != nil || errors.Is(err, errs.ErrNetwork) || errors.Is(err, errs.ErrServer) || errors.Is(err, errs.ErrTooManyRequests)
+}
+
+/*downloadPart appends the rest of the file to the given part file. Returns true if the download has been interrupted and can be resumed.*/
+func (bot *Bot) downloadPart(ctx context.Context, file *objs.File, part string) (bool, error) {
+	out, err := os.OpenFile(part, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer out.Close()
+	offset, err := out.Seek(0, io.SeekEnd)
+	if err != nil {
+		return false, err
+	}
+	if file.FileSize > 0 && offset > file.FileSize {
+		offset = 0
+	}
+	body, start, err := bot.apiInterface.OpenFile(ctx, file, offset)
+	if err != nil {
+		return resumable(ctx, err), err
+	}
+	defer body.Close()
+	if start != offset || offset == 0 {
+		if err = out.Truncate(start); err != nil {
+			return false, err
+		}
+		if _, err = out.Seek(start, io.SeekStart); err != nil {
+			return false, err
+		}
+	}
+	written, err := io.Copy(out, body)
+	if err != nil {
+		var tooLarge *errs.FileTooLarge
+		return !errors.As(err, &tooLarge), err
+	}
+	if file.FileSize > 0 && start+written != file.FileSize {
+		return true, errors.New("download of file " + file.FileId + " is incomplete")
+	}
+	return false, nil
+}
@@ -0,0 +1,105 @@
+package telego
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	errs "github.com/SakoDroid/telego/errors"
+	logger "github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestDownloadFile(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	paths := map[string]string{"large": "photos/large.jpg", "small": "photos/small.jpg"}
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/bot1:token/getFile" {
+			var args objs.GetFileArgs
+			if err := json.NewDecoder(req.Body).Decode(&args); err != nil {
+				t.Error(err)
+			}
+			id := args.FileId
+			wr.Write([]byte(`{"ok":true,"result":{"file_id":"` + id + `","file_unique_id":"u-` + id + `","file_size":` + strconv.Itoa(len(content)) + `,"file_path":"` + paths[id] + `"}}`))
+			return
+		}
+		requests = append(requests, req.Header.Get("Range"))
+		if len(requests) == 1 {
+			//The first download is interrupted in the middle.
+			wr.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			wr.Write(content[:4000])
+			return
+		}
+		http.ServeContent(wr, req, "file.jpg", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+	cfg := cfgs.Default("1:token")
+	cfg.BotAPI = srv.URL + "/bot"
+	bot, err := NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "large.jpg")
+	file, err := bot.DownloadFile(context.Background(), "large", path)
+	if err != nil || file.FilePath != "photos/large.jpg" {
+		t.Fatal("download failed", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !bytes.Equal(data, content) {
+		t.Fatal("wrong content of the downloaded file", err)
+	}
+	if _, err = os.Stat(path + ".u-large.part"); !os.IsNotExist(err) {
+		t.Fatal("part file was not removed", err)
+	}
+	if len(requests) != 2 || requests[1] != "bytes=4000-" {
+		t.Fatal("interrupted download was not resumed", requests)
+	}
+
+	//The part of another file saved in the same path is not resumed.
+	other := filepath.Join(dir, "other.jpg")
+	if err = os.WriteFile(other+".u-small.part", bytes.Repeat([]byte("x"), 4000), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bot.DownloadFile(context.Background(), "large", other); err != nil {
+		t.Fatal("download failed", err)
+	}
+	if data, err = os.ReadFile(other); err != nil || !bytes.Equal(data, content) {
+		t.Fatal("download was resumed from the part of another file", err)
+	}
+
+	//The part file is removed when the download can't be resumed.
+	bot.botCfg.MaxDownloadSize = 100
+	var tooLarge *errs.FileTooLarge
+	if _, err = bot.DownloadFile(context.Background(), "large", filepath.Join(dir, "big.jpg")); !errors.As(err, &tooLarge) {
+		t.Fatal("large file was downloaded", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "big.jpg.u-large.part")); !os.IsNotExist(err) {
+		t.Fatal("part file of a file that can't be downloaded was not removed", err)
+	}
+	bot.botCfg.MaxDownloadSize = 0
+
+	photo := []objs.PhotoSize{{FileId: "small", Width: 90, Height: 60}, {FileId: "large", Width: 1280, Height: 853}}
+	if largest := LargestPhotoSize(photo); largest == nil || largest.FileId != "large" {
+		t.Fatal("wrong largest size", largest)
+	}
+	saved, err := bot.DownloadPhotoSizes(context.Background(), photo, dir)
+	if err != nil || len(saved) != 2 || saved[0] != filepath.Join(dir, "90x60.jpg") || saved[1] != filepath.Join(dir, "1280x853.jpg") {
+		t.Fatal("photo sizes were not downloaded", saved, err)
+	}
+}
@@ -0,0 +1,167 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+/*APIError is a kind of the errors returned by the bot API server. The errors returned by the methods can be matched with the kinds using errors.Is, like errors.Is(err, errors.ErrBotBlocked). Specific kinds are also matched with their general kind, so ErrBotBlocked is matched with ErrForbidden too.*/
+type APIError struct {
+	description string
+	parent      *APIError
+}
+
+func (ae *APIError) Error() string {
+	return ae.description
+}
+
+//Unwrap returns the general kind of the error.
+func (ae *APIError) Unwrap() error {
+	if ae.parent == nil {
+		return nil
+	}
+	return ae.parent
+}
+
+//General kinds of errors based on the status code of the response.
+var (
+	ErrBadRequest      = &APIError{description: "bad request"}
+	ErrUnauthorized    = &APIError{description: "unauthorized"}
+	ErrForbidden       = &APIError{description: "forbidden"}
+	ErrNotFound        = &APIError{description: "not found"}
+	ErrConflict        = &APIError{description: "conflict"}
+	ErrTooManyRequests = &APIError{description: "too many requests"}
+	ErrServer          = &APIError{description: "bot API server error"}
+	//ErrNetwork is matched when the request could not be sent or the response could not be received.
+	ErrNetwork = &APIError{description: "network error"}
+)
+
+//Specific kinds of errors based on the description of the response.
+var (
+	ErrBotBlocked            = &APIError{description: "bot was blocked by the user", parent: ErrForbidden}
+	ErrUserDeactivated       = &APIError{description: "user is deactivated", parent: ErrForbidden}
+	ErrBotKicked             = &APIError{description: "bot was kicked from the chat", parent: ErrForbidden}
+	ErrChatNotFound          = &APIError{description: "chat not found", parent: ErrBadRequest}
+	ErrMessageNotModified    = &APIError{description: "message is not modified", parent: ErrBadRequest}
+	ErrMessageToEditNotFound = &APIError{description: "message to edit not found", parent: ErrBadRequest}
+	ErrParseEntities         = &APIError{description: "can't parse entities", parent: ErrBadRequest}
+	ErrNotEnoughRights       = &APIError{description: "not enough rights", parent: ErrBadRequest}
+	ErrChatMigrated          = &APIError{description: "group chat was upgraded to a supergroup chat", parent: ErrBadRequest}
+)
+
+//descriptionErrors maps the parts of the descriptions sent by the API server to the kinds of errors. They are checked in order.
+var descriptionErrors = []struct {
+	text string
+	err  *APIError
+}{
+	{"bot was blocked by the user", ErrBotBlocked},
+	{"user is deactivated", ErrUserDeactivated},
+	{"bot was kicked", ErrBotKicked},
+	{"bot is not a member", ErrBotKicked},
+	{"chat not found", ErrChatNotFound},
+	{"message is not modified", ErrMessageNotModified},
+	{"message to edit not found", ErrMessageToEditNotFound},
+	{"can't parse entities", ErrParseEntities},
+	{"not enough rights", ErrNotEnoughRights},
+	{"need administrator rights", ErrNotEnoughRights},
+	{"have no rights", ErrNotEnoughRights},
+	{"upgraded to a supergroup", ErrChatMigrated},
+}
+
+//TooManyRequests is matched when the bot has exceeded the flood limits. It's also matched with ErrTooManyRequests.
+type TooManyRequests struct {
+	//The time to wait before the request can be sent again. Zero if the API server has not specified it.
+	RetryAfter time.Duration
+}
+
+func (tmr *TooManyRequests) Error() string {
+	return "too many requests, retry after " + tmr.RetryAfter.String()
+}
+
+//Unwrap returns ErrTooManyRequests.
+func (tmr *TooManyRequests) Unwrap() error {
+	return ErrTooManyRequests
+}
+
+//ChatMigrated is matched when the group has been migrated to a supergroup. It's also matched with ErrChatMigrated.
+type ChatMigrated struct {
+	//The id of the new supergroup.
+	MigrateToChatId int
+}
+
+func (cm *ChatMigrated) Error() string {
+	return "group chat was upgraded to the supergroup " + strconv.Itoa(cm.MigrateToChatId)
+}
+
+//Unwrap returns ErrChatMigrated.
+func (cm *ChatMigrated) Unwrap() error {
+	return ErrChatMigrated
+}
+
+//NetworkError is matched when the request could not be sent or the response could not be received. It's also matched with ErrNetwork.
+type NetworkError struct {
+	Err error
+}
+
+func (ne *NetworkError) Error() string {
+	return "network error : " + ne.Err.Error()
+}
+
+//Unwrap returns the underlying error, so errors like context.DeadlineExceeded can be matched too.
+func (ne *NetworkError) Unwrap() error {
+	return ne.Err
+}
+
+//Is reports if the target is ErrNetwork.
+func (ne *NetworkError) Is(target error) bool {
+	return target == ErrNetwork
+}
+
+/*FromFailureResult returns the kind of the error the API server has responded with. Returns nil if the result is nil.*/
+func FromFailureResult(fr *objs.FailureResult) error {
+	if fr == nil {
+		return nil
+	}
+	if fr.Parameters != nil {
+		if fr.Parameters.MigrateToChatId != 0 {
+			return &ChatMigrated{MigrateToChatId: fr.Parameters.MigrateToChatId}
+		}
+		if fr.ErrorCode == 429 {
+			return &TooManyRequests{RetryAfter: time.Duration(fr.Parameters.RetryAfter) * time.Second}
+		}
+	}
+	if fr.ErrorCode == 429 {
+		return &TooManyRequests{}
+	}
+	description := strings.ToLower(fr.Description)
+	for _, de := range descriptionErrors {
+		if strings.Contains(description, de.text) {
+			return de.err
+		}
+	}
+	return fromStatusCode(fr.ErrorCode)
+}
+
+//fromStatusCode returns the general kind of the error based on the status code. Returns nil for unknown codes.
+func fromStatusCode(code int) error {
+	switch {
+	case code == 400:
+		return ErrBadRequest
+	case code == 401:
+		return ErrUnauthorized
+	case code == 403:
+		return ErrForbidden
+	case code == 404:
+		return ErrNotFound
+	case code == 409:
+		return ErrConflict
+	case code == 429:
+		return &TooManyRequests{}
+	case code >= 500:
+		return ErrServer
+	}
+	return nil
+}
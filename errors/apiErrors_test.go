@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"net/url"
+	"testing"
+	"time"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestErrorKinds(t *testing.T) {
+	type kindTest struct {
+		fr      *objs.FailureResult
+		matched []error
+		other   []error
+	}
+	tests := []kindTest{
+		{&objs.FailureResult{ErrorCode: 403, Description: "Forbidden: bot was blocked by the user"}, []error{ErrBotBlocked, ErrForbidden}, []error{ErrBadRequest, ErrChatNotFound}},
+		{&objs.FailureResult{ErrorCode: 403, Description: "Forbidden: user is deactivated"}, []error{ErrUserDeactivated, ErrForbidden}, []error{ErrBotBlocked}},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: chat not found"}, []error{ErrChatNotFound, ErrBadRequest}, []error{ErrForbidden}},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: message is not modified: specified new message content and reply markup are exactly the same"}, []error{ErrMessageNotModified, ErrBadRequest}, nil},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: message to edit not found"}, []error{ErrMessageToEditNotFound}, []error{ErrMessageNotModified}},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: can't parse entities: Unsupported start tag \"foo\" at byte offset 0"}, []error{ErrParseEntities, ErrBadRequest}, nil},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: not enough rights to send text messages to the chat"}, []error{ErrNotEnoughRights}, nil},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: wrong file identifier"}, []error{ErrBadRequest}, []error{ErrChatNotFound, ErrParseEntities}},
+		{&objs.FailureResult{ErrorCode: 401, Description: "Unauthorized"}, []error{ErrUnauthorized}, []error{ErrForbidden}},
+		{&objs.FailureResult{ErrorCode: 502, Description: "Bad Gateway"}, []error{ErrServer}, []error{ErrNetwork}},
+	}
+	for i, test := range tests {
+		err := error(&MethodNotSentError{Method: "sendMessage", FailureResult: test.fr, StatusCode: test.fr.ErrorCode})
+		for _, kind := range test.matched {
+			if !stderrors.Is(err, kind) {
+				t.Errorf("test %d : error was not matched with %q", i, kind)
+			}
+		}
+		for _, kind := range test.other {
+			if stderrors.Is(err, kind) {
+				t.Errorf("test %d : error was matched with %q", i, kind)
+			}
+		}
+	}
+}
+
+func TestErrorParameters(t *testing.T) {
+	err := error(&MethodNotSentError{Method: "sendMessage", FailureResult: &objs.FailureResult{ErrorCode: 429, Description: "Too Many Requests: retry after 5", Parameters: &objs.ResponseParameters{RetryAfter: 5}}})
+	var tooMany *TooManyRequests
+	if !stderrors.As(err, &tooMany) || tooMany.RetryAfter != 5*time.Second || !stderrors.Is(err, ErrTooManyRequests) {
+		t.Fatal("too many requests error was not matched", err)
+	}
+
+	err = &MethodNotSentError{Method: "sendMessage", FailureResult: &objs.FailureResult{ErrorCode: 400, Description: "Bad Request: group chat was upgraded to a supergroup chat", Parameters: &objs.ResponseParameters{MigrateToChatId: -1001234}}}
+	var migrated *ChatMigrated
+	if !stderrors.As(err, &migrated) || migrated.MigrateToChatId != -1001234 || !stderrors.Is(err, ErrChatMigrated) || !stderrors.Is(err, ErrBadRequest) {
+		t.Fatal("chat migrated error was not matched", err)
+	}
+
+	err = &MethodNotSentError{Method: "getMe", Err: &url.Error{Op: "Post", URL: "https://api.telegram.org", Err: context.DeadlineExceeded}}
+	var network *NetworkError
+	if !stderrors.As(err, &network) || !stderrors.Is(err, ErrNetwork) || !stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(err, ErrServer) {
+		t.Fatal("network error was not matched", err)
+	}
+
+	if stderrors.Is(&MethodNotSentError{Method: "getMe", Reason: "unknown"}, ErrBadRequest) {
+		t.Fatal("error without response was matched")
+	}
+}
@@ -1,15 +1,20 @@
 package errors
 
 import (
+	"fmt"
 	"strconv"
 
 	objs "github.com/SakoDroid/telego/objects"
 )
 
-//MethodNotSentError is returned when API server responds with any code other than 200
+/*MethodNotSentError is returned when API server responds with any code other than 200 or the request can't be sent. The kind of the error can be checked with errors.Is and errors.As (see APIError), like errors.Is(err, errors.ErrBotBlocked) or errors.As(err, &tooManyRequests).*/
 type MethodNotSentError struct {
 	Method, Reason string
 	FailureResult  *objs.FailureResult
+	//The status code of the response. Zero if no response has been received.
+	StatusCode int
+	//The network error that has caused the failure. Nil if the API server has responded.
+	Err error
 }
 
 func (mnse *MethodNotSentError) Error() string {
@@ -20,7 +25,20 @@ func (mnse *MethodNotSentError) Error() string {
 	return out
 }
 
-//BotInterfaceAlreadyCreated indicates that the bai is already created.
+//Unwrap returns the kind of the error (see APIError). Returns nil if the kind is unknown.
+func (mnse *MethodNotSentError) Unwrap() error {
+	if mnse.Err != nil {
+		return &NetworkError{Err: mnse.Err}
+	}
+	if mnse.FailureResult != nil {
+		if err := FromFailureResult(mnse.FailureResult); err != nil {
+			return err
+		}
+	}
+	return fromStatusCode(mnse.StatusCode)
+}
+
+//BotInterfaceAlreadyCreated indicates that the bai is already created. It's not returned anymore since several bot interfaces can be created, and is only kept for compatibility.
 type BotInterfaceAlreadyCreated struct {
 }
 
@@ -36,6 +54,14 @@ func (uras *UpdateRoutineAlreadyStarted) Error() string {
 	return "StartUpdateRoutine has already been called."
 }
 
+//BotAlreadyRunning indicates that the bot has already been started and has not been stopped yet.
+type BotAlreadyRunning struct {
+}
+
+func (bar *BotAlreadyRunning) Error() string {
+	return "the bot is already running."
+}
+
 //UpdateNotOk indicates that server returned "ok : false" in response.
 type UpdateNotOk struct {
 	Offset int
@@ -77,3 +103,41 @@ type LiveLocationNotStarted struct {
 func (llns *LiveLocationNotStarted) Error() string {
 	return "live location has not been started (sent)."
 }
+
+//UpdateTypeMismatch indicates that the update does not contain the object needed for the called method.
+type UpdateTypeMismatch struct {
+	Expected string
+}
+
+func (utm *UpdateTypeMismatch) Error() string {
+	return "the update does not contain " + utm.Expected + "."
+}
+
+//InvalidCommandArguments indicates that the arguments of a received command don't match the syntax of the command.
+type InvalidCommandArguments struct {
+	Usage, Reason string
+}
+
+func (ica *InvalidCommandArguments) Error() string {
+	return "Invalid arguments : " + ica.Reason + "\nUsage : " + ica.Usage
+}
+
+//HandlerPanic is passed to the error handler of the bot when a handler panics. It holds the recovered value and the stack trace of the handler.
+type HandlerPanic struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (hp *HandlerPanic) Error() string {
+	return fmt.Sprint("handler panicked : ", hp.Value)
+}
+
+//FileTooLarge is returned when a file being downloaded is larger than the maximum download size of the bot.
+type FileTooLarge struct {
+	FileId      string
+	Size, Limit int64
+}
+
+func (ftl *FileTooLarge) Error() string {
+	return "file " + ftl.FileId + " is larger than the download limit (" + strconv.FormatInt(ftl.Limit, 10) + " bytes)"
+}
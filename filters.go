@@ -0,0 +1,101 @@
+package telego
+
+import (
+	"sync"
+	"time"
+
+	objs "github.com/SakoDroid/telego/objects"
+	upp "github.com/SakoDroid/telego/parser"
+)
+
+//adminCacheTTL is the time the administrators of a chat are cached for.
+const adminCacheTTL = 5 * time.Minute
+
+//adminFailureTTL is the time a failure in fetching the administrators of a chat is cached for.
+const adminFailureTTL = 10 * time.Second
+
+type filteredChannel struct {
+	filter  upp.Filter
+	channel *chan *objs.Update
+}
+
+//adminCache caches the administrators of the chats so FromAdmin filter does not call the api for every update.
+type adminCache struct {
+	chats map[int]*chatAdmins
+	lock  sync.Mutex
+}
+
+/*chatAdmins holds the administrators of a chat. ids and expires are set before ready is closed and are not changed after that, so they can be read without the lock once ready is closed.*/
+type chatAdmins struct {
+	ids     map[int]bool
+	expires time.Time
+	ready   chan bool //Closed when the administrators have been fetched.
+}
+
+/*FromAdmin returns a filter that accepts the updates whose sender is an administrator (or the creator) of the chat the update belongs to. Administrators of each chat are fetched from the api server and cached for 5 minutes. If they can't be fetched, the update is rejected and the failure is cached for 10 seconds.*/
+func (bot *Bot) FromAdmin() upp.Filter {
+	return func(up *objs.Update) bool {
+		chat, user := upp.GetChat(up), upp.GetSender(up)
+		if chat == nil || user == nil || chat.Type == "private" {
+			return false
+		}
+		return bot.isAdmin(chat.Id, user.Id)
+	}
+}
+
+/*isAdmin checks if the given user is an administrator of the given chat. Administrators of each chat are fetched only once at a time, the updates of a chat that arrive while its administrators are being fetched wait for the same request.*/
+func (bot *Bot) isAdmin(chatId, userId int) bool {
+	bot.admins.lock.Lock()
+	if bot.admins.chats == nil {
+		bot.admins.chats = make(map[int]*chatAdmins)
+	}
+	admins := bot.admins.chats[chatId]
+	if admins == nil || admins.expired() {
+		admins = &chatAdmins{ready: make(chan bool)}
+		bot.admins.chats[chatId] = admins
+		bot.admins.lock.Unlock()
+		bot.fetchAdmins(chatId, admins)
+	} else {
+		bot.admins.lock.Unlock()
+		<-admins.ready
+	}
+	return admins.ids[userId]
+}
+
+//fetchAdmins gets the administrators of the given chat from the api server and stores them in the given entry.
+func (bot *Bot) fetchAdmins(chatId int, admins *chatAdmins) {
+	defer close(admins.ready)
+	admins.ids = make(map[int]bool)
+	res, err := bot.apiInterface.GetChatAdministrators(chatId, "")
+	if err != nil {
+		admins.expires = time.Now().Add(adminFailureTTL)
+		return
+	}
+	for _, member := range res.Result {
+		admins.ids[member.User.Id] = true
+	}
+	admins.expires = time.Now().Add(adminCacheTTL)
+}
+
+//expired returns true if the administrators have been fetched and have expired. Entries that are being fetched are never expired.
+func (ca *chatAdmins) expired() bool {
+	select {
+	case <-ca.ready:
+		return time.Now().After(ca.expires)
+	default:
+		return false
+	}
+}
+
+//getFilteredChannel returns the first filtered channel that accepts the given update.
+func (bot *Bot) getFilteredChannel(update *objs.Update) *chan *objs.Update {
+	bot.channelsLock.RLock()
+	fcs := bot.filteredChannels
+	bot.channelsLock.RUnlock()
+	for _, fc := range fcs {
+		if fc.filter(update) {
+			return fc.channel
+		}
+	}
+	return nil
+}
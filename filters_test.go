@@ -0,0 +1,94 @@
+package telego
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	logger "github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+	upp "github.com/SakoDroid/telego/parser"
+)
+
+func TestFilteredChannels(t *testing.T) {
+	bot := &Bot{channelsMap: map[string]map[string]*chan *objs.Update{"global": {}}, parser: upp.NewParser()}
+	bot.ab = &AdvancedBot{bot: bot}
+	photos := bot.AdvancedMode().RegisterFilteredChannel(upp.HasPhoto())
+	english := bot.AdvancedMode().RegisterFilteredChannel(upp.LanguageCodes("en"))
+	up := &objs.Update{Message: &objs.Message{From: &objs.User{LanguageCode: "en"}, Photo: []objs.PhotoSize{{}}}}
+	go bot.processUpdate(context.Background(), up, "global")
+	if received := <-*photos; received != up {
+		t.Error("wrong update was received")
+	}
+	bot.AdvancedMode().UnRegisterFilteredChannel(photos)
+	go bot.processUpdate(context.Background(), up, "global")
+	if received := <-*english; received != up {
+		t.Error("wrong update was received")
+	}
+	if bot.processUpdate(context.Background(), &objs.Update{Message: &objs.Message{}}, "global") {
+		t.Error("update was accepted without any matching channel")
+	}
+}
+
+func TestFromAdminCache(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	var requests int32
+	release := make(chan bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		var args objs.DefaultChatArgs
+		if err := json.NewDecoder(req.Body).Decode(&args); err != nil {
+			t.Error(err)
+		}
+		if string(args.ChatId) == "20" {
+			wr.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+			return
+		}
+		<-release
+		wr.Write([]byte(`{"ok":true,"result":[{"status":"creator","user":{"id":1}}]}`))
+	}))
+	defer srv.Close()
+	cfg := cfgs.Default("1:token")
+	cfg.BotAPI = srv.URL + "/bot"
+	bot, err := NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//Concurrent checks of a chat share one request and don't block the other chats.
+	var wg sync.WaitGroup
+	results := make([]bool, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = bot.isAdmin(10, 1)
+		}(i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if bot.isAdmin(20, 1) || bot.isAdmin(20, 1) {
+		t.Error("user is admin of a chat whose admins could not be fetched")
+	}
+	close(release)
+	wg.Wait()
+	for _, result := range results {
+		if !result {
+			t.Fatal("admin was not detected")
+		}
+	}
+	if bot.isAdmin(10, 2) {
+		t.Error("user who is not admin was detected as admin")
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Error("wrong number of requests :", n)
+	}
+}
@@ -1,6 +1,7 @@
 package telego
 
 import (
+	"context"
 	"errors"
 
 	objs "github.com/SakoDroid/telego/objects"
@@ -694,10 +695,15 @@ func (iqs *InlineQueryResponder) CreateInvoiceMessage(invoice *Invoice) objs.Inp
 The returned result can have two types : LogicalResult or SentWebAppMessage.
 */
 func (iqs *InlineQueryResponder) Send() (interface{}, error) {
+	return iqs.SendContext(context.Background())
+}
+
+/*SendContext works like "Send" but sends the request with the given context.*/
+func (iqs *InlineQueryResponder) SendContext(ctx context.Context) (interface{}, error) {
 	if iqs.isWebApp {
-		return iqs.bot.apiInterface.AnswerWebAppQuery(iqs.id, iqs.results[0])
+		return iqs.bot.apiInterface.AnswerWebAppQueryContext(ctx, iqs.id, iqs.results[0])
 	}
-	return iqs.bot.apiInterface.AnswerInlineQuery(
+	return iqs.bot.apiInterface.AnswerInlineQueryContext(ctx,
 		iqs.id, iqs.results, iqs.cacheTime, iqs.isPersonal, iqs.nextOffset,
 		iqs.switchPmText, iqs.switchPmParameter,
 	)
@@ -1,6 +1,10 @@
 package telego
 
-import objs "github.com/SakoDroid/telego/objects"
+import (
+	"context"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
 
 //Invoice is an invoice that can be modified and sent to the user.
 type Invoice struct {
@@ -30,7 +34,12 @@ Official telegram doc :
 
 Use this method to send invoices. On success, the sent Message is returned.*/
 func (is *Invoice) Send(replyTo int, silent bool) (*objs.SendMethodsResult, error) {
-	return is.bot.apiInterface.SendInvoice(
+	return is.SendContext(context.Background(), replyTo, silent)
+}
+
+/*SendContext works like "Send" but sends the request with the given context.*/
+func (is *Invoice) SendContext(ctx context.Context, replyTo int, silent bool) (*objs.SendMethodsResult, error) {
+	return is.bot.apiInterface.SendInvoiceContext(ctx,
 		is.chatIdInt, is.chatIdString, is.title, is.description, is.payload, is.providerToken,
 		is.currency, is.prices, is.maxTipAmount, is.suggestedTipAmounts, is.startParameter, is.providerData,
 		is.photoURL, is.photoSize, is.photoWidth, is.photoHeight, is.needName, is.needPhoneNumber, is.needEmail, is.needShippingAddress,
@@ -46,7 +55,12 @@ Official telegram doc :
 
 Use this method to create a link for an invoice. Returns the created invoice link as String on success.*/
 func (is *Invoice) CreateLink() (*objs.StringResult, error) {
-	return is.bot.apiInterface.CreateInvoiceLink(is.title, is.description, is.payload, is.providerToken,
+	return is.CreateLinkContext(context.Background())
+}
+
+/*CreateLinkContext works like "CreateLink" but sends the request with the given context.*/
+func (is *Invoice) CreateLinkContext(ctx context.Context) (*objs.StringResult, error) {
+	return is.bot.apiInterface.CreateInvoiceLinkContext(ctx, is.title, is.description, is.payload, is.providerToken,
 		is.currency, is.prices, is.maxTipAmount, is.suggestedTipAmounts, is.providerData,
 		is.photoURL, is.photoSize, is.photoWidth, is.photoHeight, is.needName, is.needPhoneNumber, is.needEmail, is.needShippingAddress,
 		is.sendPhoneNumberToProvider, is.sendEmailToProvider, is.isFlexible)
@@ -12,6 +12,7 @@ type MarkUps interface {
 
 //keyboard is a normal keyboard.
 type keyboard struct {
+	bot                                        *Bot
 	keys                                       [][]*objs.KeyboardButton
 	resizeKeyBoard, oneTimeKeyboard, selective bool
 	inputFieldPlaceHolder                      string
@@ -36,7 +37,15 @@ func (kb *keyboard) AddButton(text string, row int) {
 Note : row number starts from 1. (it's not zero based). If any number lower than 1 is passed, no button will be added*/
 func (kb *keyboard) AddButtonHandler(text string, row int, handler func(*objs.Update), chatTypes ...string) {
 	kb.addButton(text, row, false, false, nil, nil)
-	upp.AddHandler(text, handler, chatTypes...)
+	kb.bot.parser.AddHandler(text, handler, chatTypes...)
+}
+
+/*AddButtonContextHandler works like "AddButtonHandler" but the handler receives a Context. You can read the documentation of "AddContextHandler" for better understanding on context handlers.
+
+Note : row number starts from 1. (it's not zero based). If any number lower than 1 is passed, no button will be added*/
+func (kb *keyboard) AddButtonContextHandler(text string, row int, handler func(*Context) error, chatTypes ...string) {
+	kb.addButton(text, row, false, false, nil, nil)
+	kb.bot.parser.AddMatchHandler(text, kb.bot.matchHandler(handler), chatTypes...)
 }
 
 /*AddContactButton adds a new contact button. According to telegram bot api when this button is pressed,the user's phone number will be sent as a contact. Available in private chats only.
@@ -102,6 +111,7 @@ func (kb *keyboard) toMarkUp() objs.ReplyMarkup {
 }
 
 type inlineKeyboard struct {
+	bot  *Bot
 	keys [][]*objs.InlineKeyboardButton
 }
 
@@ -143,13 +153,40 @@ func (in *inlineKeyboard) AddCallbackButton(text, callbackData string, row int)
 	in.addButton(text, "", callbackData, "", "", nil, nil, nil, false, row)
 }
 
-/*AddCallbackButtonHandler adds a button that when its pressed, a call back query with the given data is sen to the bot. A handler is also added which will be called everytime a call back query is received for this button.
+/*AddCallbackButtonHandler adds a button that when its pressed, a call back query with the given data is sen to the bot. A handler is also added which will be called everytime a call back query is received for this button. If the handler does not answer the callback query, it is answered automatically after the handler returns.
 
 Note : row number starts from 1. (it's not zero based). If any number lower than 1 is passed, no button will be added.
 */
 func (in *inlineKeyboard) AddCallbackButtonHandler(text, callbackData string, row int, handler func(*objs.Update)) {
 	in.addButton(text, "", callbackData, "", "", nil, nil, nil, false, row)
-	upp.AddCallbackHandler(callbackData, handler)
+	in.bot.parser.AddCallbackHandler(callbackData, func(up *objs.Update) {
+		in.bot.answerIfForgotten(up, handler)
+	})
+}
+
+/*AddCallbackButtonContextHandler works like "AddCallbackButtonHandler" but the handler receives a Context.
+
+Note : row number starts from 1. (it's not zero based). If any number lower than 1 is passed, no button will be added.
+*/
+func (in *inlineKeyboard) AddCallbackButtonContextHandler(text, callbackData string, row int, handler func(*Context) error) {
+	in.addButton(text, "", callbackData, "", "", nil, nil, nil, false, row)
+	callbackHandler := in.bot.callbackHandler(handler, nil)
+	in.bot.parser.AddCallbackHandler(callbackData, func(up *objs.Update) {
+		callbackHandler(up, nil)
+	})
+}
+
+/*AddCodecButton adds a button whose callback data is the given values encoded by the given codec. Use "AddCallbackCodecHandler" method of the bot to add a handler for the buttons of the codec. Returns an error if the values don't match the fields of the codec or the encoded data is longer than 64 bytes.
+
+Note : row number starts from 1. (it's not zero based). If any number lower than 1 is passed, no button will be added.
+*/
+func (in *inlineKeyboard) AddCodecButton(text string, row int, codec *upp.CallbackCodec, values ...interface{}) error {
+	data, err := codec.Encode(values...)
+	if err != nil {
+		return err
+	}
+	in.addButton(text, "", data, "", "", nil, nil, nil, false, row)
+	return nil
 }
 
 /*AddSwitchInlineQueryButton adds a switch inline query button. According to tlegram bot api, pressing the button will prompt the user to select one of their chats, open that chat and insert the bot's username and the specified inline query in the input field. Can be empty, in which case just the bot's username will be inserted. Note: This offers an easy way for users to start using your bot in inline mode when they are currently in a private chat with it. Especially useful when combined with switch_pm… actions – in this case the user will be automatically returned to the chat they switched from, skipping the chat selection screen.
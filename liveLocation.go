@@ -1,6 +1,7 @@
 package telego
 
 import (
+	"context"
 	errs "github.com/SakoDroid/telego/errors"
 	objs "github.com/SakoDroid/telego/objects"
 )
@@ -30,8 +31,13 @@ Official telegram doc :
 
 Use this method to send point on the map. On success, the sent Message is returned.*/
 func (ll *LiveLocation) Send(chatId int, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return ll.SendContext(context.Background(), chatId, silent, protectContent)
+}
+
+/*SendContext works like "Send" but sends the request with the given context.*/
+func (ll *LiveLocation) SendContext(ctx context.Context, chatId int, silent, protectContent bool) (*objs.SendMethodsResult, error) {
 	ll.chatIdInt = chatId
-	res, err := ll.bot.apiInterface.SendLocation(
+	res, err := ll.bot.apiInterface.SendLocationContext(ctx,
 		chatId, "", ll.latitude, ll.longitude, ll.horizontalAccuracy, ll.livePeriod,
 		ll.heading, ll.proximityAlertRadius, ll.replyTo, silent, ll.allowSendingWihoutReply, protectContent,
 		ll.replyMarkUp,
@@ -54,8 +60,13 @@ Official telegram doc :
 
 Use this method to send point on the map. On success, the sent Message is returned.*/
 func (ll *LiveLocation) SendToChannel(chatId string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return ll.SendToChannelContext(context.Background(), chatId, silent, protectContent)
+}
+
+/*SendToChannelContext works like "SendToChannel" but sends the request with the given context.*/
+func (ll *LiveLocation) SendToChannelContext(ctx context.Context, chatId string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
 	ll.chatIdString = chatId
-	res, err := ll.bot.apiInterface.SendLocation(
+	res, err := ll.bot.apiInterface.SendLocationContext(ctx,
 		0, chatId, ll.latitude, ll.longitude, ll.horizontalAccuracy, ll.livePeriod,
 		ll.heading, ll.proximityAlertRadius, ll.replyTo, silent, ll.allowSendingWihoutReply, protectContent,
 		ll.replyMarkUp,
@@ -74,6 +85,11 @@ Official telegram doc :
 
 Use this method to edit live location messages. A location can be edited until its live_period expires or editing is explicitly disabled by a call to stopMessageLiveLocation. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.*/
 func (ll *LiveLocation) Edit(latitude, langitude, horizontalAccuracy float32, heading, proximtyAlertRadius int, replyMarkUp *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
+	return ll.EditContext(context.Background(), latitude, langitude, horizontalAccuracy, heading, proximtyAlertRadius, replyMarkUp)
+}
+
+/*EditContext works like "Edit" but sends the request with the given context.*/
+func (ll *LiveLocation) EditContext(ctx context.Context, latitude, langitude, horizontalAccuracy float32, heading, proximtyAlertRadius int, replyMarkUp *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
 	if ll.messageId != 0 {
 		ll.latitude = latitude
 		ll.longitude = langitude
@@ -81,7 +97,7 @@ func (ll *LiveLocation) Edit(latitude, langitude, horizontalAccuracy float32, he
 		ll.heading = heading
 		ll.proximityAlertRadius = proximtyAlertRadius
 		ll.replyMarkUp = replyMarkUp
-		return ll.bot.apiInterface.EditMessageLiveLocation(
+		return ll.bot.apiInterface.EditMessageLiveLocationContext(ctx,
 			ll.chatIdInt, ll.chatIdString, "", ll.messageId, ll.latitude, ll.longitude,
 			ll.horizontalAccuracy, ll.heading, ll.proximityAlertRadius, replyMarkUp,
 		)
@@ -97,8 +113,13 @@ Official telegram doc :
 
 Use this method to stop updating a live location message before live_period expires. On success, if the message is not an inline message, the edited Message is returned, otherwise True is returned.*/
 func (ll *LiveLocation) Stop(replyMarkrup objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
+	return ll.StopContext(context.Background(), replyMarkrup)
+}
+
+/*StopContext works like "Stop" but sends the request with the given context.*/
+func (ll *LiveLocation) StopContext(ctx context.Context, replyMarkrup objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
 	if ll.messageId != 0 {
-		return ll.bot.apiInterface.StopMessageLiveLocation(
+		return ll.bot.apiInterface.StopMessageLiveLocationContext(ctx,
 			ll.chatIdInt, ll.chatIdString, "", ll.messageId, &replyMarkrup,
 		)
 	} else {
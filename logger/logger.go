@@ -3,6 +3,7 @@ package logger
 import (
 	"log"
 	"os"
+	"sync"
 
 	cfg "github.com/SakoDroid/telego/configs"
 )
@@ -10,6 +11,9 @@ import (
 //Logger is the default logger of the bot.
 var Logger *log.Logger
 
+//initLock makes initializing the logger safe when several bots are started at the same time.
+var initLock sync.Mutex
+
 //colorized indicates if logs should be colored, default is true.
 var colorized = true
 
@@ -25,8 +29,10 @@ const (
 	UNDERLINE string = "\033[4m"
 )
 
-//InitiTheLogger initializes the default logger of the bot.
+//InitiTheLogger initializes the default logger of the bot. If the logger has already been initialized (by another bot for example) it does nothing.
 func InitTheLogger(botCfg *cfg.BotConfigs) {
+	initLock.Lock()
+	defer initLock.Unlock()
 	if Logger == nil {
 		var file *os.File
 		if botCfg.LogFileAddress == "STDOUT" {
@@ -1,7 +1,9 @@
 package telego
 
 import (
+	"context"
 	"errors"
+	"io"
 	"os"
 
 	errs "github.com/SakoDroid/telego/errors"
@@ -15,7 +17,20 @@ type MediaGroup struct {
 	allowSendingWihoutReply bool
 	replyMarkup             objs.ReplyMarkup
 	media                   []objs.InputMedia
-	files                   []*os.File
+	files                   []io.Reader
+}
+
+/*addFile adds the file to the files of the media group. Nil files and the files that are already added (like a thumbnail shared between the media) are skipped.*/
+func (mg *MediaGroup) addFile(file io.Reader) {
+	if file == nil {
+		return
+	}
+	for _, f := range mg.files {
+		if f == file {
+			return
+		}
+	}
+	mg.files = append(mg.files, file)
 }
 
 //PhotoInserter is a tool for inserting photos into the MediaGroup.
@@ -27,10 +42,7 @@ type PhotoInserter struct {
 
 /*AddByFileIdOrURL adds this file by file id or url*/
 func (pi *PhotoInserter) AddByFileIdOrURL(fileIdOrUrl string) {
-	im := &objs.InputMediaPhoto{
-		InputMediaDefault: fixTheDefault("photo", fileIdOrUrl, pi.caption, pi.parseMode, pi.captionEntities),
-	}
-	pi.mg.media = append(pi.mg.media, im)
+	pi.add(fileIdOrUrl, nil)
 }
 
 /*AddByFile adds an existing file in the device*/
@@ -39,12 +51,26 @@ func (pi *PhotoInserter) AddByFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
+	pi.add("attach://"+stat.Name(), file)
+	return nil
+}
+
+/*AddByReader adds the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (pi *PhotoInserter) AddByReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	pi.add("attach://"+fileName, file)
+	return nil
+}
+
+func (pi *PhotoInserter) add(media string, file io.Reader) {
 	im := &objs.InputMediaPhoto{
-		InputMediaDefault: fixTheDefault("photo", "attach://"+stat.Name(), pi.caption, pi.parseMode, pi.captionEntities),
+		InputMediaDefault: fixTheDefault("photo", media, pi.caption, pi.parseMode, pi.captionEntities),
 	}
 	pi.mg.media = append(pi.mg.media, im)
-	pi.mg.files = append(pi.mg.files, file)
-	return nil
+	pi.mg.addFile(file)
 }
 
 //VideoInserter is a tool for inserting videos into the MediaGroup.
@@ -52,31 +78,14 @@ type VideoInserter struct {
 	mg                        *MediaGroup
 	caption, parseMode, thumb string
 	captionEntities           []objs.MessageEntity
-	thumbFile                 *os.File
+	thumbFile                 io.Reader
 	width, height, duration   int
 	supportsStreaming         bool
 }
 
 /*AddByFileIdOrURL adds this file by file id or url*/
 func (vi *VideoInserter) AddByFileIdOrURL(fileIdOrUrl string) {
-	im := &objs.InputMediaVideo{
-		InputMediaDefault: fixTheDefault("video", fileIdOrUrl, vi.caption, vi.parseMode, vi.captionEntities),
-		Thumb:             vi.thumb,
-		SupportsStreaming: vi.supportsStreaming,
-	}
-	if vi.width != 0 {
-		im.Width = vi.width
-	}
-	if vi.height != 0 {
-		im.Height = vi.height
-	}
-	if vi.duration != 0 {
-		im.Duration = vi.duration
-	}
-	vi.mg.media = append(vi.mg.media, im)
-	if vi.thumbFile != nil {
-		vi.mg.files = append(vi.mg.files, vi.thumbFile)
-	}
+	vi.add(fileIdOrUrl, nil)
 }
 
 /*AddByFile adds an existing file in the device*/
@@ -85,8 +94,23 @@ func (vi *VideoInserter) AddByFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
+	vi.add("attach://"+stat.Name(), file)
+	return nil
+}
+
+/*AddByReader adds the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (vi *VideoInserter) AddByReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	vi.add("attach://"+fileName, file)
+	return nil
+}
+
+func (vi *VideoInserter) add(media string, file io.Reader) {
 	im := &objs.InputMediaVideo{
-		InputMediaDefault: fixTheDefault("video", "attach://"+stat.Name(), vi.caption, vi.parseMode, vi.captionEntities),
+		InputMediaDefault: fixTheDefault("video", media, vi.caption, vi.parseMode, vi.captionEntities),
 		Thumb:             vi.thumb,
 		SupportsStreaming: vi.supportsStreaming,
 	}
@@ -100,11 +124,8 @@ func (vi *VideoInserter) AddByFile(file *os.File) error {
 		im.Duration = vi.duration
 	}
 	vi.mg.media = append(vi.mg.media, im)
-	vi.mg.files = append(vi.mg.files, file)
-	if vi.thumbFile != nil {
-		vi.mg.files = append(vi.mg.files, vi.thumbFile)
-	}
-	return nil
+	vi.mg.addFile(file)
+	vi.mg.addFile(vi.thumbFile)
 }
 
 /*SetThumbnail sets the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -123,34 +144,29 @@ func (vi *VideoInserter) SetThumbnailFile(file *os.File) error {
 	return nil
 }
 
+/*SetThumbnailReader sets the tumbnail of the file. It takes a reader, the file name and the MIME type of the thumbnail.*/
+func (vi *VideoInserter) SetThumbnailReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	vi.thumbFile = file
+	vi.thumb = "attach://" + fileName
+	return nil
+}
+
 //AnimationInserter is a tool for inserting animations into the MediaGroup.
 type AnimationInserter struct {
 	mg                        *MediaGroup
 	caption, parseMode, thumb string
 	captionEntities           []objs.MessageEntity
-	thumbFile                 *os.File
+	thumbFile                 io.Reader
 	width, height, duration   int
 }
 
 /*AddByFileIdOrURL adds this file by file id or url*/
 func (ai *AnimationInserter) AddByFileIdOrURL(fileIdOrUrl string) {
-	im := &objs.InputMediaAnimation{
-		InputMediaDefault: fixTheDefault("animation", fileIdOrUrl, ai.caption, ai.parseMode, ai.captionEntities),
-		Thumb:             ai.thumb,
-	}
-	if ai.width != 0 {
-		im.Width = ai.width
-	}
-	if ai.height != 0 {
-		im.Height = ai.height
-	}
-	if ai.duration != 0 {
-		im.Duration = ai.duration
-	}
-	ai.mg.media = append(ai.mg.media, im)
-	if ai.thumbFile != nil {
-		ai.mg.files = append(ai.mg.files, ai.thumbFile)
-	}
+	ai.add(fileIdOrUrl, nil)
 }
 
 /*AddByFile adds an existing file in the device*/
@@ -159,8 +175,23 @@ func (ai *AnimationInserter) AddByFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
+	ai.add("attach://"+stat.Name(), file)
+	return nil
+}
+
+/*AddByReader adds the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (ai *AnimationInserter) AddByReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	ai.add("attach://"+fileName, file)
+	return nil
+}
+
+func (ai *AnimationInserter) add(media string, file io.Reader) {
 	im := &objs.InputMediaAnimation{
-		InputMediaDefault: fixTheDefault("animation", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("animation", media, ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 	}
 	if ai.width != 0 {
@@ -173,11 +204,8 @@ func (ai *AnimationInserter) AddByFile(file *os.File) error {
 		im.Duration = ai.duration
 	}
 	ai.mg.media = append(ai.mg.media, im)
-	ai.mg.files = append(ai.mg.files, file)
-	if ai.thumbFile != nil {
-		ai.mg.files = append(ai.mg.files, ai.thumbFile)
-	}
-	return nil
+	ai.mg.addFile(file)
+	ai.mg.addFile(ai.thumbFile)
 }
 
 /*SetThumbnail sets the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -196,30 +224,29 @@ func (ai *AnimationInserter) SetThumbnailFile(file *os.File) error {
 	return nil
 }
 
+/*SetThumbnailReader sets the tumbnail of the file. It takes a reader, the file name and the MIME type of the thumbnail.*/
+func (ai *AnimationInserter) SetThumbnailReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	ai.thumbFile = file
+	ai.thumb = "attach://" + fileName
+	return nil
+}
+
 //AudioInserter is a tool for inserting audios into the MediaGroup.
 type AudioInserter struct {
 	mg                                          *MediaGroup
 	caption, parseMode, thumb, performer, title string
 	captionEntities                             []objs.MessageEntity
-	thumbFile                                   *os.File
+	thumbFile                                   io.Reader
 	duration                                    int
 }
 
 /*AddByFileIdOrURL adds this file by file id or url*/
 func (ai *AudioInserter) AddByFileIdOrURL(fileIdOrUrl string) {
-	im := &objs.InputMediaAudio{
-		InputMediaDefault: fixTheDefault("audio", fileIdOrUrl, ai.caption, ai.parseMode, ai.captionEntities),
-		Thumb:             ai.thumb,
-		Performer:         ai.performer,
-		Title:             ai.title,
-	}
-	if ai.duration != 0 {
-		im.Duration = ai.duration
-	}
-	ai.mg.media = append(ai.mg.media, im)
-	if ai.thumbFile != nil {
-		ai.mg.files = append(ai.mg.files, ai.thumbFile)
-	}
+	ai.add(fileIdOrUrl, nil)
 }
 
 /*AddByFile adds an existing file in the device*/
@@ -228,8 +255,23 @@ func (ai *AudioInserter) AddByFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
+	ai.add("attach://"+stat.Name(), file)
+	return nil
+}
+
+/*AddByReader adds the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (ai *AudioInserter) AddByReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	ai.add("attach://"+fileName, file)
+	return nil
+}
+
+func (ai *AudioInserter) add(media string, file io.Reader) {
 	im := &objs.InputMediaAudio{
-		InputMediaDefault: fixTheDefault("audio", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("audio", media, ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 		Performer:         ai.performer,
 		Title:             ai.title,
@@ -238,11 +280,8 @@ func (ai *AudioInserter) AddByFile(file *os.File) error {
 		im.Duration = ai.duration
 	}
 	ai.mg.media = append(ai.mg.media, im)
-	ai.mg.files = append(ai.mg.files, file)
-	if ai.thumbFile != nil {
-		ai.mg.files = append(ai.mg.files, ai.thumbFile)
-	}
-	return nil
+	ai.mg.addFile(file)
+	ai.mg.addFile(ai.thumbFile)
 }
 
 /*SetThumbnail sets the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -261,26 +300,29 @@ func (ai *AudioInserter) SetThumbnailFile(file *os.File) error {
 	return nil
 }
 
+/*SetThumbnailReader sets the tumbnail of the file. It takes a reader, the file name and the MIME type of the thumbnail.*/
+func (ai *AudioInserter) SetThumbnailReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	ai.thumbFile = file
+	ai.thumb = "attach://" + fileName
+	return nil
+}
+
 //DocumentInserter is a tool for inserting documents into the MediaGroup.
 type DocumentInserter struct {
 	mg                          *MediaGroup
 	caption, parseMode, thumb   string
 	captionEntities             []objs.MessageEntity
-	thumbFile                   *os.File
+	thumbFile                   io.Reader
 	disableContentTypeDetection bool
 }
 
 /*AddByFileIdOrURL adds this file by file id or url*/
 func (di *DocumentInserter) AddByFileIdOrURL(fileIdOrUrl string) {
-	im := &objs.InputMediaDocument{
-		InputMediaDefault:           fixTheDefault("document", fileIdOrUrl, di.caption, di.parseMode, di.captionEntities),
-		Thumb:                       di.thumb,
-		DisableContentTypeDetection: di.disableContentTypeDetection,
-	}
-	di.mg.media = append(di.mg.media, im)
-	if di.thumbFile != nil {
-		di.mg.files = append(di.mg.files, di.thumbFile)
-	}
+	di.add(fileIdOrUrl, nil)
 }
 
 /*AddByFile adds an existing file in the device*/
@@ -289,17 +331,29 @@ func (di *DocumentInserter) AddByFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
+	di.add("attach://"+stat.Name(), file)
+	return nil
+}
+
+/*AddByReader adds the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (di *DocumentInserter) AddByReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	di.add("attach://"+fileName, file)
+	return nil
+}
+
+func (di *DocumentInserter) add(media string, file io.Reader) {
 	im := &objs.InputMediaDocument{
-		InputMediaDefault:           fixTheDefault("document", "attach://"+stat.Name(), di.caption, di.parseMode, di.captionEntities),
+		InputMediaDefault:           fixTheDefault("document", media, di.caption, di.parseMode, di.captionEntities),
 		Thumb:                       di.thumb,
 		DisableContentTypeDetection: di.disableContentTypeDetection,
 	}
 	di.mg.media = append(di.mg.media, im)
-	di.mg.files = append(di.mg.files, file)
-	if di.thumbFile != nil {
-		di.mg.files = append(di.mg.files, di.thumbFile)
-	}
-	return nil
+	di.mg.addFile(file)
+	di.mg.addFile(di.thumbFile)
 }
 
 /*SetThumbnail sets the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -318,6 +372,17 @@ func (di *DocumentInserter) SetThumbnailFile(file *os.File) error {
 	return nil
 }
 
+/*SetThumbnailReader sets the tumbnail of the file. It takes a reader, the file name and the MIME type of the thumbnail.*/
+func (di *DocumentInserter) SetThumbnailReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	di.thumbFile = file
+	di.thumb = "attach://" + fileName
+	return nil
+}
+
 /*Send sends this album (to all types of chat but channels, to send to channels use "SendToChannel" method)
 
 --------------------
@@ -331,10 +396,15 @@ If "silent" argument is true, the message will be sent without notification.
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
 func (mg *MediaGroup) Send(chatId int, silent, protectContent bool) (*objs.SendMediaGroupMethodResult, error) {
+	return mg.SendContext(context.Background(), chatId, silent, protectContent)
+}
+
+/*SendContext works like "Send" but sends the request with the given context.*/
+func (mg *MediaGroup) SendContext(ctx context.Context, chatId int, silent, protectContent bool) (*objs.SendMediaGroupMethodResult, error) {
 	if len(mg.media) < 2 {
 		return nil, errors.New("the number os medias should be greater than 1")
 	}
-	return mg.bot.apiInterface.SendMediaGroup(
+	return mg.bot.apiInterface.SendMediaGroupContext(ctx,
 		chatId, "", mg.replyTo, mg.media, silent, mg.allowSendingWihoutReply, protectContent,
 		mg.replyMarkup, mg.files...,
 	)
@@ -354,10 +424,15 @@ If "silent" argument is true, the message will be sent without notification.
 If "protectContent" argument is true, the message can't be forwarded or saved.
 */
 func (mg *MediaGroup) SendToChannel(chatId string, silent, protectContent bool) (*objs.SendMediaGroupMethodResult, error) {
+	return mg.SendToChannelContext(context.Background(), chatId, silent, protectContent)
+}
+
+/*SendToChannelContext works like "SendToChannel" but sends the request with the given context.*/
+func (mg *MediaGroup) SendToChannelContext(ctx context.Context, chatId string, silent, protectContent bool) (*objs.SendMediaGroupMethodResult, error) {
 	if len(mg.media) < 2 {
 		return nil, errors.New("the number os medias should be greater than 1")
 	}
-	return mg.bot.apiInterface.SendMediaGroup(
+	return mg.bot.apiInterface.SendMediaGroupContext(ctx,
 		0, chatId, mg.replyTo, mg.media, silent, mg.allowSendingWihoutReply, protectContent,
 		mg.replyMarkup, mg.files...,
 	)
@@ -0,0 +1,30 @@
+package telego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMediaGroupSharedThumbnail(t *testing.T) {
+	mg := (&Bot{}).CreateAlbum(0)
+	vi, err := mg.AddVideo("", "", 0, 0, 0, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = vi.SetThumbnailReader(strings.NewReader("thumb"), "thumb.jpg", "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"first.mp4", "second.mp4"} {
+		if err = vi.AddByReader(strings.NewReader(name), name, "video/mp4"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	vi.AddByFileIdOrURL("file-id")
+	if len(mg.media) != 3 {
+		t.Fatal("expected 3 media, got", len(mg.media))
+	}
+	//Two videos and the thumbnail they share.
+	if len(mg.files) != 3 {
+		t.Error("expected 3 files, got", len(mg.files))
+	}
+}
@@ -1,10 +1,13 @@
 package telego
 
 import (
+	"context"
 	"errors"
+	"io"
 	"os"
 
 	objs "github.com/SakoDroid/telego/objects"
+	tba "github.com/SakoDroid/telego/tba"
 )
 
 type MediaType int
@@ -32,113 +35,93 @@ type MediaSender struct {
 	replyMarkup                                               objs.ReplyMarkup
 	duration, length, width, height                           int
 	supportsStreaming, disableContentTypeDetection            bool
-	thumbFile                                                 *os.File
+	thumbFile                                                 io.Reader
 }
 
 /*SendByFileIdOrUrl sends a file that already exists on telegram servers (file id) or a url on the web.*/
 func (ms *MediaSender) SendByFileIdOrUrl(fileIdOrUrl string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
-	switch ms.mediaType {
-	case PHOTO:
-		return ms.bot.apiInterface.SendPhoto(
-			ms.chatIdInt, ms.chatidString, fileIdOrUrl, nil, ms.caption, ms.parseMode,
-			ms.replyTo, silent, ms.allowSendingWihoutReply, protectContent, ms.replyMarkup, ms.captionEntities,
-		)
-	case VIDEO:
-		return ms.bot.apiInterface.SendVideo(
-			ms.chatIdInt, ms.chatidString, fileIdOrUrl,
-			nil, ms.caption, ms.parseMode, ms.replyTo, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent,
-			ms.captionEntities, ms.duration, ms.supportsStreaming, ms.replyMarkup,
-		)
-	case AUDIO:
-		return ms.bot.apiInterface.SendAudio(
-			ms.chatIdInt, ms.chatidString, fileIdOrUrl, nil, ms.caption, ms.parseMode,
-			ms.replyTo, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent,
-			ms.captionEntities, ms.duration, ms.performer, ms.title, ms.replyMarkup,
-		)
-	case ANIMATION:
-		return ms.bot.apiInterface.SendAnimation(
-			ms.chatIdInt, ms.chatidString, fileIdOrUrl, nil, ms.caption, ms.parseMode,
-			ms.width, ms.height, ms.duration, ms.replyTo, ms.thumb, ms.thumbFile,
-			silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
-		)
-	case DOCUMENT:
-		return ms.bot.apiInterface.SendDocument(
-			ms.chatIdInt, ms.chatidString, fileIdOrUrl, nil, ms.caption, ms.parseMode,
-			ms.replyTo, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities,
-			ms.disableContentTypeDetection, ms.replyMarkup,
-		)
-	case VIDEONOTE:
-		return ms.bot.apiInterface.SendVideoNote(
-			ms.chatIdInt, ms.chatidString, fileIdOrUrl, nil, ms.caption, ms.parseMode,
-			ms.length, ms.duration, ms.replyTo, ms.thumb, ms.thumbFile, silent,
-			ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
-		)
-	case VOICE:
-		return ms.bot.apiInterface.SendVoice(
-			ms.chatIdInt, ms.chatidString, fileIdOrUrl, nil, ms.caption, ms.parseMode,
-			ms.duration, ms.replyTo, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
-		)
-	case STICKER:
-		return ms.bot.apiInterface.SendSticker(
-			ms.chatIdInt, ms.chatidString, fileIdOrUrl, silent, ms.allowSendingWihoutReply, protectContent,
-			ms.replyTo, ms.replyMarkup, nil,
-		)
-	default:
-		return nil, errors.New("wrong media type")
-	}
+	return ms.SendByFileIdOrUrlContext(context.Background(), fileIdOrUrl, silent, protectContent)
+}
 
+/*SendByFileIdOrUrlContext works like "SendByFileIdOrUrl" but sends the request with the given context.*/
+func (ms *MediaSender) SendByFileIdOrUrlContext(ctx context.Context, fileIdOrUrl string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return ms.send(ctx, fileIdOrUrl, nil, silent, protectContent)
 }
 
 /*SendByFile sends a file that is located in this device.*/
 func (ms *MediaSender) SendByFile(file *os.File, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return ms.SendByFileContext(context.Background(), file, silent, protectContent)
+}
+
+/*SendByFileContext works like "SendByFile" but sends the request with the given context.*/
+func (ms *MediaSender) SendByFileContext(ctx context.Context, file *os.File, silent, protectContent bool) (*objs.SendMethodsResult, error) {
 	stat, err := file.Stat()
 	if err != nil {
 		return nil, err
 	}
+	return ms.send(ctx, "attach://"+stat.Name(), file, silent, protectContent)
+}
+
+/*SendByReader uploads the file read from the given reader, like an image generated in memory. The file is streamed to the API server so large files are not loaded into the memory.
+"fileName" is the name of the file sent to telegram and "contentType" is its MIME type. Pass empty string for contentType to send the file as "application/octet-stream".*/
+func (ms *MediaSender) SendByReader(reader io.Reader, fileName, contentType string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	return ms.SendByReaderContext(context.Background(), reader, fileName, contentType, silent, protectContent)
+}
+
+/*SendByReaderContext works like "SendByReader" but sends the request with the given context.*/
+func (ms *MediaSender) SendByReaderContext(ctx context.Context, reader io.Reader, fileName, contentType string, silent, protectContent bool) (*objs.SendMethodsResult, error) {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return ms.send(ctx, "attach://"+fileName, file, silent, protectContent)
+}
+
+func (ms *MediaSender) send(ctx context.Context, media string, file io.Reader, silent, protectContent bool) (*objs.SendMethodsResult, error) {
 	switch ms.mediaType {
 	case PHOTO:
-		return ms.bot.apiInterface.SendPhoto(
-			ms.chatIdInt, ms.chatidString, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+		return ms.bot.apiInterface.SendPhotoContext(ctx,
+			ms.chatIdInt, ms.chatidString, media, file, ms.caption, ms.parseMode,
 			ms.replyTo, silent, ms.allowSendingWihoutReply, protectContent, ms.replyMarkup, ms.captionEntities,
 		)
 	case VIDEO:
-		return ms.bot.apiInterface.SendVideo(
-			ms.chatIdInt, ms.chatidString, "attach://"+stat.Name(),
+		return ms.bot.apiInterface.SendVideoContext(ctx,
+			ms.chatIdInt, ms.chatidString, media,
 			file, ms.caption, ms.parseMode, ms.replyTo, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.captionEntities, ms.duration, ms.supportsStreaming, ms.replyMarkup,
 		)
 	case AUDIO:
-		return ms.bot.apiInterface.SendAudio(
-			ms.chatIdInt, ms.chatidString, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+		return ms.bot.apiInterface.SendAudioContext(ctx,
+			ms.chatIdInt, ms.chatidString, media, file, ms.caption, ms.parseMode,
 			ms.replyTo, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.captionEntities, ms.duration, ms.performer, ms.title, ms.replyMarkup,
 		)
 	case ANIMATION:
-		return ms.bot.apiInterface.SendAnimation(
-			ms.chatIdInt, ms.chatidString, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+		return ms.bot.apiInterface.SendAnimationContext(ctx,
+			ms.chatIdInt, ms.chatidString, media, file, ms.caption, ms.parseMode,
 			ms.width, ms.height, ms.duration, ms.replyTo, ms.thumb, ms.thumbFile,
 			silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case DOCUMENT:
-		return ms.bot.apiInterface.SendDocument(
-			ms.chatIdInt, ms.chatidString, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+		return ms.bot.apiInterface.SendDocumentContext(ctx,
+			ms.chatIdInt, ms.chatidString, media, file, ms.caption, ms.parseMode,
 			ms.replyTo, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities,
 			ms.disableContentTypeDetection, ms.replyMarkup,
 		)
 	case VIDEONOTE:
-		return ms.bot.apiInterface.SendVideoNote(
-			ms.chatIdInt, ms.chatidString, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+		return ms.bot.apiInterface.SendVideoNoteContext(ctx,
+			ms.chatIdInt, ms.chatidString, media, file, ms.caption, ms.parseMode,
 			ms.length, ms.duration, ms.replyTo, ms.thumb, ms.thumbFile, silent,
 			ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case VOICE:
-		return ms.bot.apiInterface.SendVoice(
-			ms.chatIdInt, ms.chatidString, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+		return ms.bot.apiInterface.SendVoiceContext(ctx,
+			ms.chatIdInt, ms.chatidString, media, file, ms.caption, ms.parseMode,
 			ms.duration, ms.replyTo, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case STICKER:
-		return ms.bot.apiInterface.SendSticker(
-			ms.chatIdInt, ms.chatidString, "attach://"+stat.Name(), silent, ms.allowSendingWihoutReply, protectContent,
+		return ms.bot.apiInterface.SendStickerContext(ctx,
+			ms.chatIdInt, ms.chatidString, media, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.replyTo, ms.replyMarkup, file,
 		)
 	default:
@@ -163,3 +146,23 @@ func (ms *MediaSender) SetThumbnailFile(file *os.File) error {
 	ms.thumb = "attach://" + stat.Name()
 	return nil
 }
+
+/*SetThumbnailReader sets the thumbnail of the file. It takes a reader, the file name and the MIME type of the thumbnail (see "SendByReader").
+If this media does not support thumbnail, the thumbnail will be ignored.*/
+func (ms *MediaSender) SetThumbnailReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	ms.thumbFile = file
+	ms.thumb = "attach://" + fileName
+	return nil
+}
+
+//newInputFile returns the file that uploads the given reader.
+func newInputFile(reader io.Reader, fileName, contentType string) (*tba.InputFile, error) {
+	if reader == nil || fileName == "" {
+		return nil, errors.New("reader and file name are required")
+	}
+	return &tba.InputFile{Name: fileName, ContentType: contentType, Reader: reader}, nil
+}
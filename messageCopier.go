@@ -1,6 +1,7 @@
 package telego
 
 import (
+	"context"
 	objs "github.com/SakoDroid/telego/objects"
 )
 
@@ -17,20 +18,40 @@ type MessageCopier struct {
 
 /*CopyFromUserToUser copies the given message from a user to another user. chatId is the user that message is being copied to and fromChatId is the user that message is being copied to.*/
 func (mf *MessageCopier) CopyFromUserToUser(chatId, fromChatId int) (*objs.SendMethodsResult, error) {
-	return mf.bot.apiInterface.CopyMessage(chatId, fromChatId, "", "", mf.messageId, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
+	return mf.CopyFromUserToUserContext(context.Background(), chatId, fromChatId)
+}
+
+/*CopyFromUserToUserContext works like "CopyFromUserToUser" but sends the request with the given context.*/
+func (mf *MessageCopier) CopyFromUserToUserContext(ctx context.Context, chatId, fromChatId int) (*objs.SendMethodsResult, error) {
+	return mf.bot.apiInterface.CopyMessageContext(ctx, chatId, fromChatId, "", "", mf.messageId, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
 }
 
 /*CopyFromUserToChannel copies the given message from a user to a channel. chatId is the channel that message is being copied to and fromChatId is the user that message is being copied to.*/
 func (mf *MessageCopier) CopyFromUserToChannel(chatId string, fromChatId int) (*objs.SendMethodsResult, error) {
-	return mf.bot.apiInterface.CopyMessage(0, fromChatId, chatId, "", mf.messageId, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
+	return mf.CopyFromUserToChannelContext(context.Background(), chatId, fromChatId)
+}
+
+/*CopyFromUserToChannelContext works like "CopyFromUserToChannel" but sends the request with the given context.*/
+func (mf *MessageCopier) CopyFromUserToChannelContext(ctx context.Context, chatId string, fromChatId int) (*objs.SendMethodsResult, error) {
+	return mf.bot.apiInterface.CopyMessageContext(ctx, 0, fromChatId, chatId, "", mf.messageId, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
 }
 
 /*CopyFromChannelToUser copies the given message from a channel to a user. chatId is the user that message is being copied to and fromChatId is the channel that message is being copied to.*/
 func (mf *MessageCopier) CopyFromChannelToUser(chatId int, fromChatId string) (*objs.SendMethodsResult, error) {
-	return mf.bot.apiInterface.CopyMessage(chatId, 0, "", fromChatId, mf.messageId, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
+	return mf.CopyFromChannelToUserContext(context.Background(), chatId, fromChatId)
+}
+
+/*CopyFromChannelToUserContext works like "CopyFromChannelToUser" but sends the request with the given context.*/
+func (mf *MessageCopier) CopyFromChannelToUserContext(ctx context.Context, chatId int, fromChatId string) (*objs.SendMethodsResult, error) {
+	return mf.bot.apiInterface.CopyMessageContext(ctx, chatId, 0, "", fromChatId, mf.messageId, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
 }
 
 /*CopyFromChannelToChannel copies the given message from a channel to another channel. chatId is the channel that message is being copied to and fromChatId is the channel that message is being copied to.*/
 func (mf *MessageCopier) CopyFromChannelToChannel(chatId, fromChatId string) (*objs.SendMethodsResult, error) {
-	return mf.bot.apiInterface.CopyMessage(0, 0, chatId, fromChatId, mf.messageId, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
+	return mf.CopyFromChannelToChannelContext(context.Background(), chatId, fromChatId)
+}
+
+/*CopyFromChannelToChannelContext works like "CopyFromChannelToChannel" but sends the request with the given context.*/
+func (mf *MessageCopier) CopyFromChannelToChannelContext(ctx context.Context, chatId, fromChatId string) (*objs.SendMethodsResult, error) {
+	return mf.bot.apiInterface.CopyMessageContext(ctx, 0, 0, chatId, fromChatId, mf.messageId, mf.disableNotif, mf.caption, mf.parseMode, mf.replyTo, mf.allowSendingWihtouReply, mf.protectContent, mf.replyMarkup, mf.captionEntities)
 }
@@ -1,6 +1,8 @@
 package telego
 
 import (
+	"context"
+	"io"
 	"os"
 
 	objs "github.com/SakoDroid/telego/objects"
@@ -24,22 +26,47 @@ type PhotoEditor struct {
 
 /*EditByFileIdOrURL edits this photo by file id or url*/
 func (pi *PhotoEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.DefaultResult, error) {
-	im := &objs.InputMediaPhoto{
-		InputMediaDefault: fixTheDefault("photo", fileIdOrUrl, pi.caption, pi.parseMode, pi.captionEntities),
-	}
-	return pi.mg.editMedia(pi.messageId, pi.inlineMessageId, im, pi.replyMarkup, nil)
+	return pi.EditByFileIdOrURLContext(context.Background(), fileIdOrUrl)
+}
+
+/*EditByFileIdOrURLContext works like "EditByFileIdOrURL" but sends the request with the given context.*/
+func (pi *PhotoEditor) EditByFileIdOrURLContext(ctx context.Context, fileIdOrUrl string) (*objs.DefaultResult, error) {
+	return pi.edit(ctx, fileIdOrUrl, nil)
 }
 
 /*EditByFile edits this photo with an existing file in the device*/
 func (pi *PhotoEditor) EditByFile(file *os.File) (*objs.DefaultResult, error) {
+	return pi.EditByFileContext(context.Background(), file)
+}
+
+/*EditByFileContext works like "EditByFile" but sends the request with the given context.*/
+func (pi *PhotoEditor) EditByFileContext(ctx context.Context, file *os.File) (*objs.DefaultResult, error) {
 	stat, err := file.Stat()
 	if err != nil {
 		return nil, err
 	}
+	return pi.edit(ctx, "attach://"+stat.Name(), file)
+}
+
+/*EditByReader edits this photo with the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (pi *PhotoEditor) EditByReader(reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	return pi.EditByReaderContext(context.Background(), reader, fileName, contentType)
+}
+
+/*EditByReaderContext works like "EditByReader" but sends the request with the given context.*/
+func (pi *PhotoEditor) EditByReaderContext(ctx context.Context, reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return pi.edit(ctx, "attach://"+fileName, file)
+}
+
+func (pi *PhotoEditor) edit(ctx context.Context, media string, file io.Reader) (*objs.DefaultResult, error) {
 	im := &objs.InputMediaPhoto{
-		InputMediaDefault: fixTheDefault("photo", "attach://"+stat.Name(), pi.caption, pi.parseMode, pi.captionEntities),
+		InputMediaDefault: fixTheDefault("photo", media, pi.caption, pi.parseMode, pi.captionEntities),
 	}
-	return pi.mg.editMedia(pi.messageId, pi.inlineMessageId, im, pi.replyMarkup, file)
+	return pi.mg.editMedia(ctx, pi.messageId, pi.inlineMessageId, im, pi.replyMarkup, file)
 }
 
 //VideoEditor is a tool for editing videos.
@@ -48,7 +75,7 @@ type VideoEditor struct {
 	messageId                                  int
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  io.Reader
 	width, height, duration                    int
 	supportsStreaming                          bool
 	replyMarkup                                *objs.InlineKeyboardMarkup
@@ -56,31 +83,45 @@ type VideoEditor struct {
 
 /*EditByFileIdOrURL edits this video by file id or url*/
 func (vi *VideoEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.DefaultResult, error) {
-	im := &objs.InputMediaVideo{
-		InputMediaDefault: fixTheDefault("video", fileIdOrUrl, vi.caption, vi.parseMode, vi.captionEntities),
-		Thumb:             vi.thumb,
-		SupportsStreaming: vi.supportsStreaming,
-	}
-	if vi.width != 0 {
-		im.Width = vi.width
-	}
-	if vi.height != 0 {
-		im.Height = vi.height
-	}
-	if vi.duration != 0 {
-		im.Duration = vi.duration
-	}
-	return vi.mg.editMedia(vi.messageId, vi.inlineMessageId, im, vi.replyMarkup, nil, vi.thumbFile)
+	return vi.EditByFileIdOrURLContext(context.Background(), fileIdOrUrl)
+}
+
+/*EditByFileIdOrURLContext works like "EditByFileIdOrURL" but sends the request with the given context.*/
+func (vi *VideoEditor) EditByFileIdOrURLContext(ctx context.Context, fileIdOrUrl string) (*objs.DefaultResult, error) {
+	return vi.edit(ctx, fileIdOrUrl, nil)
 }
 
 /*EditByFile edits this video by file in the device*/
 func (vi *VideoEditor) EditByFile(file *os.File) (*objs.DefaultResult, error) {
+	return vi.EditByFileContext(context.Background(), file)
+}
+
+/*EditByFileContext works like "EditByFile" but sends the request with the given context.*/
+func (vi *VideoEditor) EditByFileContext(ctx context.Context, file *os.File) (*objs.DefaultResult, error) {
 	stat, err := file.Stat()
 	if err != nil {
 		return nil, err
 	}
+	return vi.edit(ctx, "attach://"+stat.Name(), file)
+}
+
+/*EditByReader edits this video with the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (vi *VideoEditor) EditByReader(reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	return vi.EditByReaderContext(context.Background(), reader, fileName, contentType)
+}
+
+/*EditByReaderContext works like "EditByReader" but sends the request with the given context.*/
+func (vi *VideoEditor) EditByReaderContext(ctx context.Context, reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return vi.edit(ctx, "attach://"+fileName, file)
+}
+
+func (vi *VideoEditor) edit(ctx context.Context, media string, file io.Reader) (*objs.DefaultResult, error) {
 	im := &objs.InputMediaVideo{
-		InputMediaDefault: fixTheDefault("video", "attach://"+stat.Name(), vi.caption, vi.parseMode, vi.captionEntities),
+		InputMediaDefault: fixTheDefault("video", media, vi.caption, vi.parseMode, vi.captionEntities),
 		Thumb:             vi.thumb,
 		SupportsStreaming: vi.supportsStreaming,
 	}
@@ -93,7 +134,7 @@ func (vi *VideoEditor) EditByFile(file *os.File) (*objs.DefaultResult, error) {
 	if vi.duration != 0 {
 		im.Duration = vi.duration
 	}
-	return vi.mg.editMedia(vi.messageId, vi.inlineMessageId, im, vi.replyMarkup, file, vi.thumbFile)
+	return vi.mg.editMedia(ctx, vi.messageId, vi.inlineMessageId, im, vi.replyMarkup, file, vi.thumbFile)
 }
 
 /*EditThumbnail edits the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -112,43 +153,69 @@ func (vi *VideoEditor) EditThumbnailFile(file *os.File) error {
 	return nil
 }
 
+/*EditThumbnailReader edits the thumbnail of the file. It takes a reader, the file name and the MIME type of the thumbnail.*/
+func (vi *VideoEditor) EditThumbnailReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	vi.thumbFile = file
+	vi.thumb = "attach://" + fileName
+	return nil
+}
+
 //AnimationEditor is a tool for editing animations.
 type AnimationEditor struct {
 	mg                                         *MessageEditor
 	messageId                                  int
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  io.Reader
 	width, height, duration                    int
 	replyMarkup                                *objs.InlineKeyboardMarkup
 }
 
 /*EditByFileIdOrURL edits this animation file by file id or url*/
 func (ai *AnimationEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.DefaultResult, error) {
-	im := &objs.InputMediaAnimation{
-		InputMediaDefault: fixTheDefault("animation", fileIdOrUrl, ai.caption, ai.parseMode, ai.captionEntities),
-		Thumb:             ai.thumb,
-	}
-	if ai.width != 0 {
-		im.Width = ai.width
-	}
-	if ai.height != 0 {
-		im.Height = ai.height
-	}
-	if ai.duration != 0 {
-		im.Duration = ai.duration
-	}
-	return ai.mg.editMedia(ai.messageId, ai.inlineMessageId, im, ai.replyMarkup, nil, ai.thumbFile)
+	return ai.EditByFileIdOrURLContext(context.Background(), fileIdOrUrl)
+}
+
+/*EditByFileIdOrURLContext works like "EditByFileIdOrURL" but sends the request with the given context.*/
+func (ai *AnimationEditor) EditByFileIdOrURLContext(ctx context.Context, fileIdOrUrl string) (*objs.DefaultResult, error) {
+	return ai.edit(ctx, fileIdOrUrl, nil)
 }
 
 /*EditByFile edits this animation by file in the device*/
 func (ai *AnimationEditor) EditByFile(file *os.File) (*objs.DefaultResult, error) {
+	return ai.EditByFileContext(context.Background(), file)
+}
+
+/*EditByFileContext works like "EditByFile" but sends the request with the given context.*/
+func (ai *AnimationEditor) EditByFileContext(ctx context.Context, file *os.File) (*objs.DefaultResult, error) {
 	stat, err := file.Stat()
 	if err != nil {
 		return nil, err
 	}
+	return ai.edit(ctx, "attach://"+stat.Name(), file)
+}
+
+/*EditByReader edits this animation with the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (ai *AnimationEditor) EditByReader(reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	return ai.EditByReaderContext(context.Background(), reader, fileName, contentType)
+}
+
+/*EditByReaderContext works like "EditByReader" but sends the request with the given context.*/
+func (ai *AnimationEditor) EditByReaderContext(ctx context.Context, reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return ai.edit(ctx, "attach://"+fileName, file)
+}
+
+func (ai *AnimationEditor) edit(ctx context.Context, media string, file io.Reader) (*objs.DefaultResult, error) {
 	im := &objs.InputMediaAnimation{
-		InputMediaDefault: fixTheDefault("animation", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("animation", media, ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 	}
 	if ai.width != 0 {
@@ -160,7 +227,7 @@ func (ai *AnimationEditor) EditByFile(file *os.File) (*objs.DefaultResult, error
 	if ai.duration != 0 {
 		im.Duration = ai.duration
 	}
-	return ai.mg.editMedia(ai.messageId, ai.inlineMessageId, im, ai.replyMarkup, file, ai.thumbFile)
+	return ai.mg.editMedia(ctx, ai.messageId, ai.inlineMessageId, im, ai.replyMarkup, file, ai.thumbFile)
 }
 
 /*EditThumbnail edits the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -185,33 +252,52 @@ type AudioEditor struct {
 	messageId                                                    int
 	inlineMessageId, caption, parseMode, thumb, performer, title string
 	captionEntities                                              []objs.MessageEntity
-	thumbFile                                                    *os.File
+	thumbFile                                                    io.Reader
 	duration                                                     int
 	replyMarkup                                                  *objs.InlineKeyboardMarkup
 }
 
 /*EditByFileIdOrURL edits this file by file id or url*/
 func (ai *AudioEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.DefaultResult, error) {
-	im := &objs.InputMediaAudio{
-		InputMediaDefault: fixTheDefault("audio", fileIdOrUrl, ai.caption, ai.parseMode, ai.captionEntities),
-		Thumb:             ai.thumb,
-		Performer:         ai.performer,
-		Title:             ai.title,
-	}
-	if ai.duration != 0 {
-		im.Duration = ai.duration
-	}
-	return ai.mg.editMedia(ai.messageId, ai.inlineMessageId, im, ai.replyMarkup, nil, ai.thumbFile)
+	return ai.EditByFileIdOrURLContext(context.Background(), fileIdOrUrl)
+}
+
+/*EditByFileIdOrURLContext works like "EditByFileIdOrURL" but sends the request with the given context.*/
+func (ai *AudioEditor) EditByFileIdOrURLContext(ctx context.Context, fileIdOrUrl string) (*objs.DefaultResult, error) {
+	return ai.edit(ctx, fileIdOrUrl, nil)
 }
 
 /*EditByFile edits this audio by file in the device*/
 func (ai *AudioEditor) EditByFile(file *os.File) (*objs.DefaultResult, error) {
+	return ai.EditByFileContext(context.Background(), file)
+}
+
+/*EditByFileContext works like "EditByFile" but sends the request with the given context.*/
+func (ai *AudioEditor) EditByFileContext(ctx context.Context, file *os.File) (*objs.DefaultResult, error) {
 	stat, err := file.Stat()
 	if err != nil {
 		return nil, err
 	}
+	return ai.edit(ctx, "attach://"+stat.Name(), file)
+}
+
+/*EditByReader edits this audio with the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (ai *AudioEditor) EditByReader(reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	return ai.EditByReaderContext(context.Background(), reader, fileName, contentType)
+}
+
+/*EditByReaderContext works like "EditByReader" but sends the request with the given context.*/
+func (ai *AudioEditor) EditByReaderContext(ctx context.Context, reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return ai.edit(ctx, "attach://"+fileName, file)
+}
+
+func (ai *AudioEditor) edit(ctx context.Context, media string, file io.Reader) (*objs.DefaultResult, error) {
 	im := &objs.InputMediaAudio{
-		InputMediaDefault: fixTheDefault("audio", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("audio", media, ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 		Performer:         ai.performer,
 		Title:             ai.title,
@@ -219,7 +305,7 @@ func (ai *AudioEditor) EditByFile(file *os.File) (*objs.DefaultResult, error) {
 	if ai.duration != 0 {
 		im.Duration = ai.duration
 	}
-	return ai.mg.editMedia(ai.messageId, ai.inlineMessageId, im, ai.replyMarkup, file, ai.thumbFile)
+	return ai.mg.editMedia(ctx, ai.messageId, ai.inlineMessageId, im, ai.replyMarkup, file, ai.thumbFile)
 }
 
 /*EditThumbnail edits the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -238,39 +324,73 @@ func (ai *AudioEditor) EditThumbnailFile(file *os.File) error {
 	return nil
 }
 
+/*EditThumbnailReader edits the thumbnail of the file. It takes a reader, the file name and the MIME type of the thumbnail.*/
+func (ai *AudioEditor) EditThumbnailReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	ai.thumbFile = file
+	ai.thumb = "attach://" + fileName
+	return nil
+}
+
 //DocumentEditor is a tool for editing documents.
 type DocumentEditor struct {
 	mg                                         *MessageEditor
 	messageId                                  int
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  io.Reader
 	disableContentTypeDetection                bool
 	replyMarkup                                *objs.InlineKeyboardMarkup
 }
 
 /*EditByFileIdOrURL edits this file by file id or url*/
 func (di *DocumentEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.DefaultResult, error) {
-	im := &objs.InputMediaDocument{
-		InputMediaDefault:           fixTheDefault("document", fileIdOrUrl, di.caption, di.parseMode, di.captionEntities),
-		Thumb:                       di.thumb,
-		DisableContentTypeDetection: di.disableContentTypeDetection,
-	}
-	return di.mg.editMedia(di.messageId, di.inlineMessageId, im, di.replyMarkup, nil, di.thumbFile)
+	return di.EditByFileIdOrURLContext(context.Background(), fileIdOrUrl)
+}
+
+/*EditByFileIdOrURLContext works like "EditByFileIdOrURL" but sends the request with the given context.*/
+func (di *DocumentEditor) EditByFileIdOrURLContext(ctx context.Context, fileIdOrUrl string) (*objs.DefaultResult, error) {
+	return di.edit(ctx, fileIdOrUrl, nil)
 }
 
 /*EditByFile edits this document by file in the device*/
 func (di *DocumentEditor) EditByFile(file *os.File) (*objs.DefaultResult, error) {
+	return di.EditByFileContext(context.Background(), file)
+}
+
+/*EditByFileContext works like "EditByFile" but sends the request with the given context.*/
+func (di *DocumentEditor) EditByFileContext(ctx context.Context, file *os.File) (*objs.DefaultResult, error) {
 	stat, err := file.Stat()
 	if err != nil {
 		return nil, err
 	}
+	return di.edit(ctx, "attach://"+stat.Name(), file)
+}
+
+/*EditByReader edits this document with the file read from the given reader. "fileName" is the name of the file sent to telegram and "contentType" is its MIME type (can be empty).*/
+func (di *DocumentEditor) EditByReader(reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	return di.EditByReaderContext(context.Background(), reader, fileName, contentType)
+}
+
+/*EditByReaderContext works like "EditByReader" but sends the request with the given context.*/
+func (di *DocumentEditor) EditByReaderContext(ctx context.Context, reader io.Reader, fileName, contentType string) (*objs.DefaultResult, error) {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return di.edit(ctx, "attach://"+fileName, file)
+}
+
+func (di *DocumentEditor) edit(ctx context.Context, media string, file io.Reader) (*objs.DefaultResult, error) {
 	im := &objs.InputMediaDocument{
-		InputMediaDefault:           fixTheDefault("document", "attach://"+stat.Name(), di.caption, di.parseMode, di.captionEntities),
+		InputMediaDefault:           fixTheDefault("document", media, di.caption, di.parseMode, di.captionEntities),
 		Thumb:                       di.thumb,
 		DisableContentTypeDetection: di.disableContentTypeDetection,
 	}
-	return di.mg.editMedia(di.messageId, di.inlineMessageId, im, di.replyMarkup, file, di.thumbFile)
+	return di.mg.editMedia(ctx, di.messageId, di.inlineMessageId, im, di.replyMarkup, file, di.thumbFile)
 }
 
 /*EditThumbnail edits the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
@@ -289,13 +409,29 @@ func (di *DocumentEditor) EditThumbnailFile(file *os.File) error {
 	return nil
 }
 
+/*EditThumbnailReader edits the thumbnail of the file. It takes a reader, the file name and the MIME type of the thumbnail.*/
+func (di *DocumentEditor) EditThumbnailReader(reader io.Reader, fileName, contentType string) error {
+	file, err := newInputFile(reader, fileName, contentType)
+	if err != nil {
+		return err
+	}
+	di.thumbFile = file
+	di.thumb = "attach://" + fileName
+	return nil
+}
+
 /*EditText can be used to edit text and game messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.*/
 func (me *MessageEditor) EditText(messageId int, text, inlineMessageId, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, keyboard *inlineKeyboard) (*objs.DefaultResult, error) {
+	return me.EditTextContext(context.Background(), messageId, text, inlineMessageId, parseMode, entities, disableWebPagePreview, keyboard)
+}
+
+/*EditTextContext works like "EditText" but sends the request with the given context.*/
+func (me *MessageEditor) EditTextContext(ctx context.Context, messageId int, text, inlineMessageId, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, keyboard *inlineKeyboard) (*objs.DefaultResult, error) {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
 	}
-	return me.bot.apiInterface.EditMessageText(
+	return me.bot.apiInterface.EditMessageTextContext(ctx,
 		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, text,
 		parseMode, entities, disableWebPagePreview, &replyMarkup,
 	)
@@ -303,11 +439,16 @@ func (me *MessageEditor) EditText(messageId int, text, inlineMessageId, parseMod
 
 /*EditCaption can be used to edit captions of messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.*/
 func (me *MessageEditor) EditCaption(messageId int, caption, inlineMessageId, parseMode string, captionEntities []objs.MessageEntity, keyboard *inlineKeyboard) (*objs.DefaultResult, error) {
+	return me.EditCaptionContext(context.Background(), messageId, caption, inlineMessageId, parseMode, captionEntities, keyboard)
+}
+
+/*EditCaptionContext works like "EditCaption" but sends the request with the given context.*/
+func (me *MessageEditor) EditCaptionContext(ctx context.Context, messageId int, caption, inlineMessageId, parseMode string, captionEntities []objs.MessageEntity, keyboard *inlineKeyboard) (*objs.DefaultResult, error) {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
 	}
-	return me.bot.apiInterface.EditMessageCaption(
+	return me.bot.apiInterface.EditMessageCaptionContext(ctx,
 		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, caption,
 		parseMode, captionEntities, &replyMarkup,
 	)
@@ -360,11 +501,16 @@ func (mg *MessageEditor) EditMediaDocument(messageId int, caption, parseMode str
 
 /*EditReplyMarkup can be used to edit only the reply markup of messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.*/
 func (me *MessageEditor) EditReplyMarkup(messageId int, inlineMessageId string, keyboard *inlineKeyboard) (*objs.DefaultResult, error) {
+	return me.EditReplyMarkupContext(context.Background(), messageId, inlineMessageId, keyboard)
+}
+
+/*EditReplyMarkupContext works like "EditReplyMarkup" but sends the request with the given context.*/
+func (me *MessageEditor) EditReplyMarkupContext(ctx context.Context, messageId int, inlineMessageId string, keyboard *inlineKeyboard) (*objs.DefaultResult, error) {
 	var replyMarkup objs.InlineKeyboardMarkup
 	if keyboard != nil {
 		replyMarkup = keyboard.toInlineKeyboardMarkup()
 	}
-	return me.bot.apiInterface.EditMessagereplyMarkup(
+	return me.bot.apiInterface.EditMessagereplyMarkupContext(ctx,
 		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, &replyMarkup,
 	)
 }
@@ -387,11 +533,16 @@ func (me *MessageEditor) EditReplyMarkup(messageId int, inlineMessageId string,
 
 Returns True on success.*/
 func (me *MessageEditor) DeleteMessage(messageId int) (*objs.LogicalResult, error) {
-	return me.bot.apiInterface.DeleteMessage(me.chatIdInt, me.chatIdString, messageId)
+	return me.DeleteMessageContext(context.Background(), messageId)
+}
+
+/*DeleteMessageContext works like "DeleteMessage" but sends the request with the given context.*/
+func (me *MessageEditor) DeleteMessageContext(ctx context.Context, messageId int) (*objs.LogicalResult, error) {
+	return me.bot.apiInterface.DeleteMessageContext(ctx, me.chatIdInt, me.chatIdString, messageId)
 }
 
-func (me *MessageEditor) editMedia(messageId int, inlineMessageId string, media objs.InputMedia, replyMarkup *objs.InlineKeyboardMarkup, file ...*os.File) (*objs.DefaultResult, error) {
-	return me.bot.apiInterface.EditMessageMedia(
+func (me *MessageEditor) editMedia(ctx context.Context, messageId int, inlineMessageId string, media objs.InputMedia, replyMarkup *objs.InlineKeyboardMarkup, file ...io.Reader) (*objs.DefaultResult, error) {
+	return me.bot.apiInterface.EditMessageMediaContext(ctx,
 		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, media,
 		replyMarkup, file...,
 	)
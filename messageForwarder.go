@@ -1,6 +1,7 @@
 package telego
 
 import (
+	"context"
 	objs "github.com/SakoDroid/telego/objects"
 )
 
@@ -13,20 +14,40 @@ type MessageForwarder struct {
 
 /*ForwardFromUserToUser forwards the given message from a user to another user. chatId is the user that message is being forwarded to and fromChatId is the user that message is being forwarded to.*/
 func (mf *MessageForwarder) ForwardFromUserToUser(chatId, fromChatId int) (*objs.SendMethodsResult, error) {
-	return mf.bot.apiInterface.ForwardMessage(chatId, fromChatId, "", "", mf.disableNotif, mf.protectContent, mf.messageId)
+	return mf.ForwardFromUserToUserContext(context.Background(), chatId, fromChatId)
+}
+
+/*ForwardFromUserToUserContext works like "ForwardFromUserToUser" but sends the request with the given context.*/
+func (mf *MessageForwarder) ForwardFromUserToUserContext(ctx context.Context, chatId, fromChatId int) (*objs.SendMethodsResult, error) {
+	return mf.bot.apiInterface.ForwardMessageContext(ctx, chatId, fromChatId, "", "", mf.disableNotif, mf.protectContent, mf.messageId)
 }
 
 /*ForwardFromUserToChannel forwards the given message from a user to a channel. chatId is the channel that message is being forwarded to and fromChatId is the user that message is being forwarded to.*/
 func (mf *MessageForwarder) ForwardFromUserToChannel(chatId string, fromChatId int) (*objs.SendMethodsResult, error) {
-	return mf.bot.apiInterface.ForwardMessage(0, fromChatId, chatId, "", mf.disableNotif, mf.protectContent, mf.messageId)
+	return mf.ForwardFromUserToChannelContext(context.Background(), chatId, fromChatId)
+}
+
+/*ForwardFromUserToChannelContext works like "ForwardFromUserToChannel" but sends the request with the given context.*/
+func (mf *MessageForwarder) ForwardFromUserToChannelContext(ctx context.Context, chatId string, fromChatId int) (*objs.SendMethodsResult, error) {
+	return mf.bot.apiInterface.ForwardMessageContext(ctx, 0, fromChatId, chatId, "", mf.disableNotif, mf.protectContent, mf.messageId)
 }
 
 /*ForwardFromChannelToUser forwards the given message from a channel to a user. chatId is the user that message is being forwarded to and fromChatId is the channel that message is being forwarded to.*/
 func (mf *MessageForwarder) ForwardFromChannelToUser(chatId int, fromChatId string) (*objs.SendMethodsResult, error) {
-	return mf.bot.apiInterface.ForwardMessage(chatId, 0, "", fromChatId, mf.disableNotif, mf.protectContent, mf.messageId)
+	return mf.ForwardFromChannelToUserContext(context.Background(), chatId, fromChatId)
+}
+
+/*ForwardFromChannelToUserContext works like "ForwardFromChannelToUser" but sends the request with the given context.*/
+func (mf *MessageForwarder) ForwardFromChannelToUserContext(ctx context.Context, chatId int, fromChatId string) (*objs.SendMethodsResult, error) {
+	return mf.bot.apiInterface.ForwardMessageContext(ctx, chatId, 0, "", fromChatId, mf.disableNotif, mf.protectContent, mf.messageId)
 }
 
 /*ForwardFromChannelToChannel forwards the given message from a channel to another channel. chatId is the channel that message is being forwarded to and fromChatId is the channel that message is being forwarded to.*/
 func (mf *MessageForwarder) ForwardFromChannelToChannel(chatId, fromChatId string) (*objs.SendMethodsResult, error) {
-	return mf.bot.apiInterface.ForwardMessage(0, 0, chatId, fromChatId, mf.disableNotif, mf.protectContent, mf.messageId)
+	return mf.ForwardFromChannelToChannelContext(context.Background(), chatId, fromChatId)
+}
+
+/*ForwardFromChannelToChannelContext works like "ForwardFromChannelToChannel" but sends the request with the given context.*/
+func (mf *MessageForwarder) ForwardFromChannelToChannelContext(ctx context.Context, chatId, fromChatId string) (*objs.SendMethodsResult, error) {
+	return mf.bot.apiInterface.ForwardMessageContext(ctx, 0, 0, chatId, fromChatId, mf.disableNotif, mf.protectContent, mf.messageId)
 }
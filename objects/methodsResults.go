@@ -10,9 +10,10 @@ type UpdateResult struct {
 
 //FailureResult represents a failure response that has "ok : false" field.
 type FailureResult struct {
-	Ok          bool   `json:"ok"`
-	ErrorCode   int    `json:"error_code"`
-	Description string `json:"description"`
+	Ok          bool                `json:"ok"`
+	ErrorCode   int                 `json:"error_code"`
+	Description string              `json:"description"`
+	Parameters  *ResponseParameters `json:"parameters,omitempty"`
 }
 
 /*SendMethodsResult represents the response of the methods which send a message. (sendMessage,sendPhoto,...)*/
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//MaxCallbackDataLength is the maximum length of the callback data of a button in bytes.
+const MaxCallbackDataLength = 64
+
+var callbackEscaper = strings.NewReplacer("%", "%25", ":", "%3A")
+var callbackUnescaper = strings.NewReplacer("%3A", ":", "%25", "%")
+
+/*CallbackCodec encodes typed fields into the callback data of a button and decodes them when the callback query is received. Encoded data is like "prefix:field1:field2". Ints are encoded in base 36 and bools as 1 or 0 to save space, since the callback data can't be longer than 64 bytes.*/
+type CallbackCodec struct {
+	prefix string
+	fields []commandArg
+}
+
+/*NewCallbackCodec creates a codec with the given prefix and fields. Prefix is used for routing the callback queries to the handler of the codec so it should be unique and can't contain ":". Each field is like "name:type" where type can be "string", "int", "float" or "bool". If no type is given, the field is a string.*/
+func NewCallbackCodec(prefix string, fields ...string) (*CallbackCodec, error) {
+	if prefix == "" || strings.Contains(prefix, ":") {
+		return nil, errors.New("callback codec prefix should not be empty or contain \":\"")
+	}
+	cc := &CallbackCodec{prefix: prefix}
+	names := make(map[string]bool)
+	for _, field := range fields {
+		arg, err := parseCommandArg("<" + field + ">")
+		if err != nil {
+			return nil, err
+		}
+		if names[arg.name] {
+			return nil, errors.New("duplicate field : " + arg.name)
+		}
+		names[arg.name] = true
+		cc.fields = append(cc.fields, arg)
+	}
+	return cc, nil
+}
+
+//Prefix returns the prefix of the codec followed by the separator. All the data encoded by this codec starts with it.
+func (cc *CallbackCodec) Prefix() string {
+	return cc.prefix + ":"
+}
+
+/*Encode encodes the given values into callback data. Values should be given in the order of the fields and their types should match the types of the fields (int, float64, bool or string). Returns an error if the encoded data is longer than 64 bytes.*/
+func (cc *CallbackCodec) Encode(values ...interface{}) (string, error) {
+	if len(values) != len(cc.fields) {
+		return "", errors.New("callback codec " + cc.prefix + " needs " + strconv.Itoa(len(cc.fields)) + " values")
+	}
+	parts := make([]string, len(values)+1)
+	parts[0] = cc.prefix
+	for i, field := range cc.fields {
+		part, ok := "", false
+		switch field.argType {
+		case "int":
+			var val int
+			val, ok = values[i].(int)
+			part = strconv.FormatInt(int64(val), 36)
+		case "float":
+			var val float64
+			val, ok = values[i].(float64)
+			part = strconv.FormatFloat(val, 'g', -1, 64)
+		case "bool":
+			var val bool
+			val, ok = values[i].(bool)
+			part = "0"
+			if val {
+				part = "1"
+			}
+		default:
+			part, ok = values[i].(string)
+			part = callbackEscaper.Replace(part)
+		}
+		if !ok {
+			return "", errors.New("value of " + field.name + " should be " + field.argType)
+		}
+		parts[i+1] = part
+	}
+	out := strings.Join(parts, ":")
+	if len(cc.fields) == 0 {
+		out += ":"
+	}
+	if len(out) > MaxCallbackDataLength {
+		return "", errors.New("encoded callback data is longer than 64 bytes : " + out)
+	}
+	return out, nil
+}
+
+/*Decode decodes the given callback data. Values in the returned map are int, float64, bool or string according to the type of the fields.*/
+func (cc *CallbackCodec) Decode(data string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(data, cc.Prefix()) {
+		return nil, errors.New("callback data does not belong to codec " + cc.prefix + " : " + data)
+	}
+	out := make(map[string]interface{})
+	if len(cc.fields) == 0 {
+		return out, nil
+	}
+	parts := strings.Split(data[len(cc.Prefix()):], ":")
+	if len(parts) != len(cc.fields) {
+		return nil, errors.New("wrong number of fields in callback data : " + data)
+	}
+	for i, field := range cc.fields {
+		var val interface{}
+		var err error
+		switch field.argType {
+		case "int":
+			var num int64
+			num, err = strconv.ParseInt(parts[i], 36, 0)
+			val = int(num)
+		case "bool":
+			val = parts[i] == "1"
+			if parts[i] != "0" && parts[i] != "1" {
+				err = errors.New("invalid bool")
+			}
+		case "float":
+			val, err = parseArgValue(parts[i], field.argType)
+		default:
+			val = callbackUnescaper.Replace(parts[i])
+		}
+		if err != nil {
+			return nil, errors.New("invalid value for " + field.name + " in callback data : " + data)
+		}
+		out[field.name] = val
+	}
+	return out, nil
+}
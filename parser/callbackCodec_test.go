@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCallbackCodec(t *testing.T) {
+	cc, err := NewCallbackCodec("item", "id:int", "price:float", "sold:bool", "note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := cc.Encode(123456, 9.5, true, "a:b%3A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(data, cc.Prefix()) || strings.Count(data, ":") != 4 {
+		t.Error("wrong encoded data :", data)
+	}
+	vals, err := cc.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals["id"] != 123456 || vals["price"] != 9.5 || vals["sold"] != true || vals["note"] != "a:b%3A" {
+		t.Error("wrong decoded values :", vals)
+	}
+	if _, err = cc.Encode("123456", 9.5, true, ""); err == nil {
+		t.Error("value with wrong type was encoded")
+	}
+	if _, err = cc.Encode(1, 1.0, false, strings.Repeat("x", 60)); err == nil {
+		t.Error("data longer than 64 bytes was encoded")
+	}
+	for _, data := range []string{"items:1:1:1:x", "item:1:1:1", "item:zz:1:2:x"} {
+		if _, err = cc.Decode(data); err == nil {
+			t.Error("invalid data was decoded :", data)
+		}
+	}
+	if _, err = NewCallbackCodec("a:b"); err == nil {
+		t.Error("prefix containing separator was accepted")
+	}
+}
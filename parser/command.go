@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	errs "github.com/SakoDroid/telego/errors"
+)
+
+var commandNameRegex = regexp.MustCompile(`^/[A-Za-z0-9_]{1,32}$`)
+var argNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+/*Command is a parsed command syntax like "/ban <user:int> [reason]". Arguments in <> are required and arguments in [] are optional. Type of an argument comes after its name and can be "string", "int", "float" or "bool". If no type is given, the argument is a string.
+
+The last argument, if it's a string, takes the rest of the text, so it can contain spaces.*/
+type Command struct {
+	name  string
+	usage string
+	args  []commandArg
+}
+
+type commandArg struct {
+	name, argType string
+	optional      bool
+}
+
+//ParseCommand parses the given command syntax.
+func ParseCommand(syntax string) (*Command, error) {
+	fields := strings.Fields(syntax)
+	if len(fields) == 0 || !commandNameRegex.MatchString(fields[0]) {
+		return nil, errors.New("command syntax should start with a valid command like /start")
+	}
+	cmd := &Command{name: fields[0], usage: strings.Join(fields, " ")}
+	names := make(map[string]bool)
+	for _, field := range fields[1:] {
+		arg, err := parseCommandArg(field)
+		if err != nil {
+			return nil, err
+		}
+		if names[arg.name] {
+			return nil, errors.New("duplicate argument : " + arg.name)
+		}
+		if !arg.optional && len(cmd.args) > 0 && cmd.args[len(cmd.args)-1].optional {
+			return nil, errors.New("required argument " + arg.name + " comes after an optional argument")
+		}
+		names[arg.name] = true
+		cmd.args = append(cmd.args, arg)
+	}
+	return cmd, nil
+}
+
+func parseCommandArg(field string) (commandArg, error) {
+	arg := commandArg{argType: "string"}
+	switch {
+	case strings.HasPrefix(field, "<") && strings.HasSuffix(field, ">"):
+	case strings.HasPrefix(field, "[") && strings.HasSuffix(field, "]"):
+		arg.optional = true
+	default:
+		return arg, errors.New("arguments should be like <name:type> or [name:type] : " + field)
+	}
+	parts := strings.SplitN(field[1:len(field)-1], ":", 2)
+	arg.name = parts[0]
+	if len(parts) == 2 {
+		arg.argType = parts[1]
+	}
+	if !argNameRegex.MatchString(arg.name) {
+		return arg, errors.New("invalid argument name : " + arg.name)
+	}
+	switch arg.argType {
+	case "string", "int", "float", "bool":
+	default:
+		return arg, errors.New("unknown argument type : " + arg.argType)
+	}
+	return arg, nil
+}
+
+//Name returns the name of the command including the leading "/".
+func (cmd *Command) Name() string {
+	return cmd.name
+}
+
+//Usage returns the syntax of the command which can be shown to the users.
+func (cmd *Command) Usage() string {
+	return cmd.usage
+}
+
+/*Pattern returns a regex pattern that matches the command with any arguments (or none) and an optional bot username like /ban@my_bot. Arguments are not validated by the pattern so the wrong ones can be reported to the user.*/
+func (cmd *Command) Pattern() string {
+	return `^` + regexp.QuoteMeta(cmd.name) + `(@\w+)?(\s[\s\S]*)?$`
+}
+
+/*ParseArgs parses the arguments of the command in the given text. Values are int, float64, bool or string according to the type of the argument. Optional arguments that are not given are not present in the returned map. If the arguments don't match the syntax, an *errors.InvalidCommandArguments is returned.*/
+func (cmd *Command) ParseArgs(text string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	rest := strings.TrimLeftFunc(text, unicode.IsSpace)
+	_, rest = nextToken(rest)
+	for i, arg := range cmd.args {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			if !arg.optional {
+				return nil, cmd.usageError("missing argument " + arg.name + ".")
+			}
+			break
+		}
+		var token string
+		if i == len(cmd.args)-1 && arg.argType == "string" {
+			token, rest = strings.TrimRightFunc(rest, unicode.IsSpace), ""
+		} else {
+			token, rest = nextToken(rest)
+		}
+		val, err := parseArgValue(token, arg.argType)
+		if err != nil {
+			return nil, cmd.usageError(arg.name + " should be " + arg.argType + ".")
+		}
+		out[arg.name] = val
+	}
+	if strings.TrimSpace(rest) != "" {
+		return nil, cmd.usageError("too many arguments.")
+	}
+	return out, nil
+}
+
+func (cmd *Command) usageError(reason string) error {
+	return &errs.InvalidCommandArguments{Usage: cmd.usage, Reason: reason}
+}
+
+//nextToken splits the first word of the text from the rest of it.
+func nextToken(text string) (string, string) {
+	index := strings.IndexFunc(text, unicode.IsSpace)
+	if index < 0 {
+		return text, ""
+	}
+	return text[:index], text[index:]
+}
+
+func parseArgValue(token, argType string) (interface{}, error) {
+	switch argType {
+	case "int":
+		return strconv.Atoi(token)
+	case "float":
+		return strconv.ParseFloat(token, 64)
+	case "bool":
+		return strconv.ParseBool(token)
+	}
+	return token, nil
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	errs "github.com/SakoDroid/telego/errors"
+)
+
+func TestCommandParseArgs(t *testing.T) {
+	cmd, err := ParseCommand("/ban <user:int> [days:int] [reason]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rgx := regexp.MustCompile(cmd.Pattern())
+	for _, text := range []string{"/ban", "/ban@my_bot 12", "/ban 12 3 spamming  a lot"} {
+		if !rgx.MatchString(text) {
+			t.Error("pattern does not match :", text)
+		}
+	}
+	if rgx.MatchString("/banned 12") {
+		t.Error("pattern matches another command")
+	}
+	args, err := cmd.ParseArgs("/ban@my_bot 12 3 spamming  a lot ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args["user"] != 12 || args["days"] != 3 || args["reason"] != "spamming  a lot" {
+		t.Error("wrong args :", args)
+	}
+	args, err = cmd.ParseArgs("/ban 12")
+	if _, ok := args["days"]; err != nil || ok {
+		t.Error("optional argument was not ignored :", args, err)
+	}
+	var usageErr *errs.InvalidCommandArguments
+	for _, text := range []string{"/ban", "/ban john", "/ban 12 three"} {
+		if _, err = cmd.ParseArgs(text); !errors.As(err, &usageErr) || usageErr.Usage != "/ban <user:int> [days:int] [reason]" {
+			t.Error("expected usage error for", text, ":", err)
+		}
+	}
+}
+
+func TestParseCommandSyntaxErrors(t *testing.T) {
+	for _, syntax := range []string{"ban <user>", "/ban [user] <reason>", "/ban <user:date>", "/ban user", "/ban <user> <user>"} {
+		if _, err := ParseCommand(syntax); err == nil {
+			t.Error("syntax was accepted :", syntax)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package parser
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/SakoDroid/telego/configs"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+/*job is a handler waiting in the queue of a worker. It is called with true to execute the handler and with false when it is dropped from the queue.*/
+type job func(run bool)
+
+/*workerPool executes the handlers using a fixed number of workers. Each worker has its own queue and updates are assigned to the workers by their chat, so the handlers of a chat are executed in order.*/
+type workerPool struct {
+	parser *Parser
+	queues []chan job
+	policy string
+	next   uint32 //Used for assigning the updates that have no chat and no sender.
+	closed bool
+	lock   sync.RWMutex
+}
+
+/*chatSequencer executes the handlers of each chat one after another while different chats are processed concurrently. A chat is present in the queues map while its handlers are being executed, its value is the handlers waiting for their turn.*/
+type chatSequencer struct {
+	parser *Parser
+	queues map[int][]job
+	size   int //The number of handlers that can wait in the queue of a chat. Negative means no limit.
+	policy string
+	lock   sync.Mutex
+	cond   *sync.Cond //Signaled when a job is taken from a queue.
+}
+
+/*StartWorkers starts the workers of the dispatcher according to the given configs. If the configs are nil or the number of workers is zero, no worker is started and each handler is executed in a new goroutine (in the order of their chat if "SequentialChats" is true). Workers that have been started before are stopped.*/
+func (p *Parser) StartWorkers(cfg *configs.DispatcherConfigs) {
+	var wp *workerPool
+	var cs *chatSequencer
+	if cfg != nil && cfg.Workers > 0 {
+		wp = &workerPool{parser: p, queues: make([]chan job, cfg.Workers), policy: cfg.Policy()}
+		for i := range wp.queues {
+			wp.queues[i] = make(chan job, cfg.QueueSize)
+			go work(wp.queues[i])
+		}
+	} else if cfg != nil && cfg.SequentialChats {
+		size := cfg.QueueSize
+		if size == 0 {
+			//Waiting for room in an unbuffered queue would block the updates of the other chats too.
+			size = -1
+		}
+		cs = newChatSequencer(p, size, cfg.Policy())
+	}
+	p.poolLock.Lock()
+	old := p.pool
+	p.pool = wp
+	p.sequencer = cs
+	p.poolLock.Unlock()
+	if old != nil {
+		old.stop()
+	}
+}
+
+/*StopWorkers stops the workers of the dispatcher after they have executed the handlers in their queues. Handlers added after this call are executed in new goroutines.*/
+func (p *Parser) StopWorkers() {
+	p.poolLock.Lock()
+	old := p.pool
+	p.pool = nil
+	p.sequencer = nil
+	p.poolLock.Unlock()
+	if old != nil {
+		old.stop()
+	}
+}
+
+func newChatSequencer(p *Parser, size int, policy string) *chatSequencer {
+	cs := &chatSequencer{parser: p, queues: make(map[int][]job), size: size, policy: policy}
+	cs.cond = sync.NewCond(&cs.lock)
+	return cs
+}
+
+/*SetContext sets the context of the parser. Sending the updates into the channels with BlockPolicy stops waiting and drops the update when the context is done, so an update channel that no one receives from does not block the parser forever. Pass nil to wait without a context.*/
+func (p *Parser) SetContext(ctx context.Context) {
+	p.poolLock.Lock()
+	defer p.poolLock.Unlock()
+	p.runCtx = ctx
+}
+
+//runContext returns the context set by "SetContext" or context.Background if it's not set.
+func (p *Parser) runContext() context.Context {
+	p.poolLock.Lock()
+	defer p.poolLock.Unlock()
+	if p.runCtx == nil {
+		return context.Background()
+	}
+	return p.runCtx
+}
+
+//DroppedUpdates returns the number of updates that have been dropped because of full queues.
+func (p *Parser) DroppedUpdates() int64 {
+	return atomic.LoadInt64(&p.droppedUpdates)
+}
+
+/*SendUpdate sends the update into the given channel according to the given full queue policy (see configs.DispatcherConfigs). With BlockPolicy it waits until the update is sent or the context is done. Returns false if the update has been dropped.*/
+func (p *Parser) SendUpdate(ctx context.Context, ch chan *objs.Update, up *objs.Update, policy string) bool {
+	switch policy {
+	case configs.DropOldestPolicy:
+		//Unbuffered channels have no old update to drop.
+		for cap(ch) > 0 {
+			select {
+			case ch <- up:
+				return true
+			default:
+			}
+			select {
+			case <-ch:
+				p.recordDrop()
+			default:
+			}
+		}
+		fallthrough
+	case configs.DropPolicy:
+		select {
+		case ch <- up:
+			return true
+		default:
+			p.recordDrop()
+			return false
+		}
+	default:
+		select {
+		case ch <- up:
+			return true
+		case <-ctx.Done():
+			p.recordDrop()
+			return false
+		}
+	}
+}
+
+func (p *Parser) sendChatUpdate(ctx context.Context, ch chan *objs.ChatUpdate, up *objs.ChatUpdate, policy string) bool {
+	switch policy {
+	case configs.DropOldestPolicy:
+		for cap(ch) > 0 {
+			select {
+			case ch <- up:
+				return true
+			default:
+			}
+			select {
+			case <-ch:
+				p.recordDrop()
+			default:
+			}
+		}
+		fallthrough
+	case configs.DropPolicy:
+		select {
+		case ch <- up:
+			return true
+		default:
+			p.recordDrop()
+			return false
+		}
+	default:
+		select {
+		case ch <- up:
+			return true
+		case <-ctx.Done():
+			p.recordDrop()
+			return false
+		}
+	}
+}
+
+func (p *Parser) recordDrop() {
+	atomic.AddInt64(&p.droppedUpdates, 1)
+}
+
+func work(queue chan job) {
+	for jb := range queue {
+		jb(true)
+	}
+}
+
+/*dispatch executes the given job in a worker if the workers are started. Otherwise it is executed in a new goroutine, after the previous jobs of its chat if sequential chats are enabled or "ordered" is true.*/
+func (p *Parser) dispatch(up *objs.Update, jb job, ordered bool) {
+	p.poolLock.Lock()
+	wp, cs := p.pool, p.sequencer
+	if cs == nil && ordered {
+		//Workers also keep the order of the chats, but they may have been stopped before the job is submitted.
+		if p.orderedSequencer == nil {
+			p.orderedSequencer = newChatSequencer(p, -1, "")
+		}
+		cs = p.orderedSequencer
+	}
+	p.poolLock.Unlock()
+	if wp != nil && wp.submit(up, jb) {
+		return
+	}
+	if cs != nil {
+		if chat := GetChat(up); chat != nil {
+			cs.add(chat.Id, jb)
+			return
+		}
+	}
+	go jb(true)
+}
+
+//add executes the job after the jobs of the given chat that have been added before.
+func (cs *chatSequencer) add(chatId int, jb job) {
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+	for {
+		queue, running := cs.queues[chatId]
+		switch {
+		case !running:
+			cs.queues[chatId] = nil
+			go cs.run(chatId, jb)
+			return
+		case cs.size < 0 || len(queue) < cs.size:
+			cs.queues[chatId] = append(queue, jb)
+			return
+		case cs.policy == configs.DropOldestPolicy && len(queue) > 0:
+			cs.parser.recordDrop()
+			queue[0](false)
+			cs.queues[chatId] = append(queue[1:], jb)
+			return
+		case cs.policy == configs.DropPolicy || cs.policy == configs.DropOldestPolicy:
+			cs.parser.recordDrop()
+			jb(false)
+			return
+		default:
+			cs.cond.Wait()
+		}
+	}
+}
+
+//run executes the given job and then the queued jobs of the chat until its queue is empty.
+func (cs *chatSequencer) run(chatId int, jb job) {
+	for jb != nil {
+		jb(true)
+		cs.lock.Lock()
+		queue := cs.queues[chatId]
+		if len(queue) == 0 {
+			delete(cs.queues, chatId)
+			jb = nil
+		} else {
+			jb = queue[0]
+			cs.queues[chatId] = queue[1:]
+		}
+		cs.cond.Broadcast()
+		cs.lock.Unlock()
+	}
+}
+
+//submit puts the job into the queue of the worker assigned to the update. Returns false if the pool is stopped.
+func (wp *workerPool) submit(up *objs.Update, jb job) bool {
+	wp.lock.RLock()
+	defer wp.lock.RUnlock()
+	if wp.closed {
+		return false
+	}
+	queue := wp.queues[wp.index(up)]
+	switch wp.policy {
+	case configs.DropOldestPolicy:
+		for cap(queue) > 0 {
+			select {
+			case queue <- jb:
+				return true
+			default:
+			}
+			select {
+			case old := <-queue:
+				wp.parser.recordDrop()
+				old(false)
+			default:
+			}
+		}
+		fallthrough
+	case configs.DropPolicy:
+		select {
+		case queue <- jb:
+		default:
+			wp.parser.recordDrop()
+			jb(false)
+		}
+	default:
+		queue <- jb
+	}
+	return true
+}
+
+//index returns the index of the worker that processes the given update.
+func (wp *workerPool) index(up *objs.Update) int {
+	n := len(wp.queues)
+	key := 0
+	if chat := GetChat(up); chat != nil {
+		key = chat.Id
+	} else if user := GetSender(up); user != nil {
+		key = user.Id
+	} else {
+		return int(atomic.AddUint32(&wp.next, 1) % uint32(n))
+	}
+	idx := key % n
+	if idx < 0 {
+		idx += n
+	}
+	return idx
+}
+
+func (wp *workerPool) stop() {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+	if !wp.closed {
+		wp.closed = true
+		for _, queue := range wp.queues {
+			close(queue)
+		}
+	}
+}
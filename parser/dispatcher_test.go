@@ -0,0 +1,197 @@
+package parser
+
+import (
+	"context"
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SakoDroid/telego/configs"
+	"github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestSendUpdatePolicies(t *testing.T) {
+	p := NewParser()
+	first, second := &objs.Update{Update_id: 1}, &objs.Update{Update_id: 2}
+	ch := make(chan *objs.Update, 1)
+	dropped := p.DroppedUpdates()
+	p.SendUpdate(context.Background(), ch, first, configs.DropPolicy)
+	if p.SendUpdate(context.Background(), ch, second, configs.DropPolicy) {
+		t.Error("update was sent into a full channel")
+	}
+	if up := <-ch; up != first {
+		t.Error("wrong update with drop policy :", up.Update_id)
+	}
+	p.SendUpdate(context.Background(), ch, first, configs.DropOldestPolicy)
+	if !p.SendUpdate(context.Background(), ch, second, configs.DropOldestPolicy) {
+		t.Error("update was not sent with drop oldest policy")
+	}
+	if up := <-ch; up != second {
+		t.Error("wrong update with drop oldest policy :", up.Update_id)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.SendUpdate(context.Background(), ch, first, configs.BlockPolicy)
+	if p.SendUpdate(ctx, ch, second, configs.BlockPolicy) {
+		t.Error("update was sent into a full channel after the context was done")
+	}
+	if p.DroppedUpdates()-dropped != 3 {
+		t.Error("wrong number of dropped updates :", p.DroppedUpdates()-dropped)
+	}
+}
+
+func TestParserContext(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	p := NewParser()
+	ctx, cancel := context.WithCancel(context.Background())
+	p.SetContext(ctx)
+	//No one receives from these channels.
+	uc, cu := make(chan *objs.Update), make(chan *objs.ChatUpdate)
+	cfg := configs.Default("1:token")
+	done := make(chan bool)
+	go func() {
+		p.ParseSingleUpdate(&objs.Update{Message: &objs.Message{Text: "hi", Chat: &objs.Chat{Id: 1, Type: "private"}}}, &uc, &cu, cfg)
+		p.ParseSingleUpdate(&objs.Update{Poll: &objs.Poll{Id: "1"}}, &uc, &cu, cfg)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("parser was blocked after its context was done")
+	}
+	if p.DroppedUpdates() != 2 {
+		t.Error("wrong number of dropped updates :", p.DroppedUpdates())
+	}
+}
+
+func TestWorkerPool(t *testing.T) {
+	p := NewParser()
+	p.StartWorkers(&configs.DispatcherConfigs{Workers: 2, QueueSize: 10})
+	defer p.StopWorkers()
+	var lock sync.Mutex
+	running, maxRunning := 0, 0
+	order := make(map[int][]int)
+	for i := 0; i < 20; i++ {
+		chatId := i % 4
+		seq := i
+		up := &objs.Update{Message: &objs.Message{Chat: &objs.Chat{Id: chatId}}}
+		p.runHandler(func(up *objs.Update) {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+			time.Sleep(time.Millisecond)
+			lock.Lock()
+			running--
+			order[chatId] = append(order[chatId], seq)
+			lock.Unlock()
+		}, up, false)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.WaitForHandlers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if maxRunning > 2 {
+		t.Error("more handlers than workers were running :", maxRunning)
+	}
+	for chatId, seqs := range order {
+		for i := 1; i < len(seqs); i++ {
+			if seqs[i] < seqs[i-1] {
+				t.Error("updates of chat", chatId, "were processed out of order :", seqs)
+				break
+			}
+		}
+	}
+}
+
+func TestSequentialChats(t *testing.T) {
+	p := NewParser()
+	p.StartWorkers(&configs.DispatcherConfigs{QueueSize: 10, SequentialChats: true})
+	defer p.StopWorkers()
+	release := make(chan bool)
+	var lock sync.Mutex
+	order := make([]string, 0)
+	record := func(name string) {
+		lock.Lock()
+		order = append(order, name)
+		lock.Unlock()
+	}
+	chat1 := &objs.Update{Message: &objs.Message{Chat: &objs.Chat{Id: 1}}}
+	chat2 := &objs.Update{Message: &objs.Message{Chat: &objs.Chat{Id: 2}}}
+	p.runHandler(func(up *objs.Update) {
+		<-release
+		record("1a")
+	}, chat1, false)
+	p.runHandler(func(up *objs.Update) {
+		record("1b")
+	}, chat1, false)
+	done := make(chan bool)
+	p.runHandler(func(up *objs.Update) {
+		record("2a")
+		close(done)
+	}, chat2, false)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("chat 2 was blocked by chat 1")
+	}
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.WaitForHandlers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != 3 || order[0] != "2a" || order[1] != "1a" || order[2] != "1b" {
+		t.Error("wrong execution order :", order)
+	}
+}
+
+func TestSequentialChatsUnbuffered(t *testing.T) {
+	p := NewParser()
+	p.StartWorkers(&configs.DispatcherConfigs{SequentialChats: true})
+	defer p.StopWorkers()
+	release := make(chan bool)
+	chat1 := &objs.Update{Message: &objs.Message{Chat: &objs.Chat{Id: 1}}}
+	chat2 := &objs.Update{Message: &objs.Message{Chat: &objs.Chat{Id: 2}}}
+	var executed int32
+	p.runHandler(func(up *objs.Update) {
+		<-release
+		atomic.AddInt32(&executed, 1)
+	}, chat1, false)
+	done := make(chan bool)
+	go func() {
+		//These updates wait for the blocked handler of chat 1, they should not block the dispatching.
+		for i := 0; i < 3; i++ {
+			p.runHandler(func(up *objs.Update) {
+				atomic.AddInt32(&executed, 1)
+			}, chat1, false)
+		}
+		p.runHandler(func(up *objs.Update) {
+			close(done)
+		}, chat2, false)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("chat 2 was blocked by chat 1")
+	}
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.WaitForHandlers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&executed) != 4 {
+		t.Error("handlers of chat 1 were not executed :", executed)
+	}
+}
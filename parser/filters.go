@@ -0,0 +1,221 @@
+package parser
+
+import (
+	"regexp"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+/*Filter decides whether an update should be processed by a handler (or passed into a channel) or not. Filters can be combined using And, Or and Not. Filters may be evaluated more than once for an update, so they should not have side effects.*/
+type Filter func(*objs.Update) bool
+
+//And returns a filter that accepts the updates that all the given filters accept.
+func And(filters ...Filter) Filter {
+	return func(up *objs.Update) bool {
+		for _, filter := range filters {
+			if !filter(up) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+//Or returns a filter that accepts the updates that at least one of the given filters accepts.
+func Or(filters ...Filter) Filter {
+	return func(up *objs.Update) bool {
+		for _, filter := range filters {
+			if filter(up) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//Not returns a filter that accepts the updates that the given filter rejects.
+func Not(filter Filter) Filter {
+	return func(up *objs.Update) bool {
+		return !filter(up)
+	}
+}
+
+//UpdateTypes returns a filter that accepts the updates of the given types. Update types are the ones returned by the "GetType" method of the update.
+func UpdateTypes(types ...string) Filter {
+	return func(up *objs.Update) bool {
+		upType := up.GetType()
+		for _, tp := range types {
+			if tp == upType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//ChatTypes returns a filter that accepts the updates that belong to a chat of one of the given types. Chat types are "private","group","supergroup" and "channel".
+func ChatTypes(types ...string) Filter {
+	return func(up *objs.Update) bool {
+		chat := GetChat(up)
+		if chat == nil {
+			return false
+		}
+		for _, tp := range types {
+			if tp == chat.Type || tp == "all" {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//ChatIds returns a filter that accepts the updates that belong to one of the given chats.
+func ChatIds(ids ...int) Filter {
+	return func(up *objs.Update) bool {
+		chat := GetChat(up)
+		return chat != nil && containsInt(ids, chat.Id)
+	}
+}
+
+//Senders returns a filter that accepts the updates that have been sent by one of the given users.
+func Senders(ids ...int) Filter {
+	return func(up *objs.Update) bool {
+		user := GetSender(up)
+		return user != nil && containsInt(ids, user.Id)
+	}
+}
+
+/*MediaTypes returns a filter that accepts the messages (including edited messages and channel posts) that contain one of the given media types. Media types are the ones returned by "GetMediaType".*/
+func MediaTypes(types ...string) Filter {
+	return func(up *objs.Update) bool {
+		mediaType := GetMediaType(getMessage(up))
+		for _, tp := range types {
+			if tp == mediaType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//HasPhoto returns a filter that accepts the messages that contain a photo.
+func HasPhoto() Filter {
+	return MediaTypes("photo")
+}
+
+//HasDocument returns a filter that accepts the messages that contain a document.
+func HasDocument() Filter {
+	return MediaTypes("document")
+}
+
+//HasLocation returns a filter that accepts the messages that contain a location (venues included).
+func HasLocation() Filter {
+	return MediaTypes("location", "venue")
+}
+
+/*TextMatches returns a filter that accepts the updates whose text matches the given regex pattern. Text is the text or the caption for messages, the data for callback queries, the query for inline queries and the invoice payload for payment queries. It panics if the pattern is invalid, like regexp.MustCompile.*/
+func TextMatches(pattern string) Filter {
+	rgx := regexp.MustCompile(pattern)
+	return func(up *objs.Update) bool {
+		if up.CallbackQuery != nil {
+			return rgx.MatchString(up.CallbackQuery.Data)
+		}
+		return rgx.MatchString(getText(up))
+	}
+}
+
+//IsReply returns a filter that accepts the messages that are a reply to another message.
+func IsReply() Filter {
+	return func(up *objs.Update) bool {
+		msg := getMessage(up)
+		return msg != nil && msg.ReplyToMessage != nil
+	}
+}
+
+//IsForwarded returns a filter that accepts the messages that have been forwarded.
+func IsForwarded() Filter {
+	return func(up *objs.Update) bool {
+		msg := getMessage(up)
+		return msg != nil && (msg.ForwardDate != 0 || msg.ForwardFrom != nil || msg.ForwardFromChat != nil || msg.ForwardSenderName != "")
+	}
+}
+
+//LanguageCodes returns a filter that accepts the updates whose sender's language is one of the given IETF language tags, like "en" or "fa".
+func LanguageCodes(codes ...string) Filter {
+	return func(up *objs.Update) bool {
+		user := GetSender(up)
+		if user == nil {
+			return false
+		}
+		for _, code := range codes {
+			if code == user.LanguageCode {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+/*GetMediaType returns the type of the content of the given message. It is one of "text", "photo", "video", "audio", "voice", "animation", "document", "sticker", "video_note", "contact", "location", "venue", "poll", "dice", "game" or "other". Returns an empty string for nil.*/
+func GetMediaType(msg *objs.Message) string {
+	switch {
+	case msg == nil:
+		return ""
+	case msg.Text != "":
+		return "text"
+	case len(msg.Photo) != 0:
+		return "photo"
+	case msg.Video != nil:
+		return "video"
+	case msg.Audio != nil:
+		return "audio"
+	case msg.Vocie != nil:
+		return "voice"
+	//Animations have the document field too, so they should be checked first.
+	case msg.Animation != nil:
+		return "animation"
+	case msg.Document != nil:
+		return "document"
+	case msg.Sticker != nil:
+		return "sticker"
+	case msg.VideoNote != nil:
+		return "video_note"
+	case msg.Contact != nil:
+		return "contact"
+	//Venues have the location field too.
+	case msg.Venue != nil:
+		return "venue"
+	case msg.Location != nil:
+		return "location"
+	case msg.Poll != nil:
+		return "poll"
+	case msg.Dice != nil:
+		return "dice"
+	case msg.Game != nil:
+		return "game"
+	}
+	return "other"
+}
+
+func getMessage(up *objs.Update) *objs.Message {
+	switch {
+	case up.Message != nil:
+		return up.Message
+	case up.EditedMessage != nil:
+		return up.EditedMessage
+	case up.ChannelPost != nil:
+		return up.ChannelPost
+	case up.EditedChannelPost != nil:
+		return up.EditedChannelPost
+	}
+	return nil
+}
+
+func containsInt(list []int, val int) bool {
+	for _, item := range list {
+		if item == val {
+			return true
+		}
+	}
+	return false
+}
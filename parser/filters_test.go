@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"testing"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestFilters(t *testing.T) {
+	photo := &objs.Update{Message: &objs.Message{
+		Chat: &objs.Chat{Id: 10, Type: "group"}, From: &objs.User{Id: 1, LanguageCode: "en"},
+		Photo: []objs.PhotoSize{{}}, Caption: "look at this", ReplyToMessage: &objs.Message{},
+	}}
+	forwarded := &objs.Update{Message: &objs.Message{
+		Chat: &objs.Chat{Id: 11, Type: "private"}, From: &objs.User{Id: 2, LanguageCode: "fa"},
+		Text: "hello", ForwardDate: 1,
+	}}
+	tests := []struct {
+		name             string
+		filter           Filter
+		photo, forwarded bool
+	}{
+		{"chat type", ChatTypes("group"), true, false},
+		{"sender", Senders(2), false, true},
+		{"has photo", HasPhoto(), true, false},
+		{"text", TextMatches("^look"), true, false},
+		{"reply", IsReply(), true, false},
+		{"forwarded", IsForwarded(), false, true},
+		{"language", LanguageCodes("fa", "de"), false, true},
+		{"and", And(ChatTypes("group"), HasPhoto(), Senders(1)), true, false},
+		{"or", Or(HasDocument(), HasLocation(), ChatIds(11)), false, true},
+		{"not", Not(Or(IsReply(), IsForwarded())), false, false},
+		{"update type", UpdateTypes("message"), true, true},
+	}
+	for _, test := range tests {
+		if test.filter(photo) != test.photo || test.filter(forwarded) != test.forwarded {
+			t.Error("wrong result for filter :", test.name)
+		}
+	}
+}
@@ -1,19 +1,76 @@
 package parser
 
 import (
+	"context"
 	"regexp"
-	"strings"
+	"runtime/debug"
+	"sync"
 
+	errs "github.com/SakoDroid/telego/errors"
+	"github.com/SakoDroid/telego/logger"
 	objs "github.com/SakoDroid/telego/objects"
 )
 
-var handlers = HandlerTree{}
-var callbackHandlers = make(map[string]*callbackHandler)
+/*Parser parses the received updates and dispatches them to the handlers and the update channels. Each bot has its own parser, so several bots can run in one process without sharing their handlers.*/
+type Parser struct {
+	droppedUpdates          int64 //The number of updates dropped because of full queues. It should be accessed atomically. (First field for 64 bit alignment)
+	handlers                Router
+	callbackHandlers        map[string]*callbackHandler
+	callbackPatternHandlers []*callbackPatternHandler
+	updateHandlers          []*updateHandler
+	middlewares             []Middleware
+	interceptors            []Interceptor
+	errorHandler            ErrorHandler
+	/*registryLock guards callbackHandlers, callbackPatternHandlers, updateHandlers, middlewares, interceptors and errorHandler so handlers can be added while the updates are being dispatched. Slices are only appended to while the lock is held, so readers take a snapshot of them and release the lock before calling any function.*/
+	registryLock     sync.RWMutex
+	runningHandlers  sync.WaitGroup //Keeps track of the handlers that are being executed.
+	pool             *workerPool
+	sequencer        *chatSequencer
+	orderedSequencer *chatSequencer //Orders the functions returned by the interceptors when sequential chats are not enabled. It is created when it is needed.
+	runCtx           context.Context
+	poolLock         sync.Mutex //Guards pool, sequencer, orderedSequencer and runCtx.
+	trackers         sync.Map       //The wait groups of the updates parsed by "ParseAndTrack".
+}
+
+//NewParser creates a new parser with no handlers.
+func NewParser() *Parser {
+	return &Parser{callbackHandlers: make(map[string]*callbackHandler)}
+}
+
+//defaultParser is the parser used by the deprecated package level functions.
+var defaultParser = NewParser()
+
+//DefaultParser returns the parser used by the deprecated package level functions of this package.
+func DefaultParser() *Parser {
+	return defaultParser
+}
+
+//HandlerFunc is a function that processes an update.
+type HandlerFunc func(*objs.Update)
+
+/*ErrorHandler processes the panics recovered from the handlers. Panics are passed as *errors.HandlerPanic which contains the stack trace of the handler.*/
+type ErrorHandler func(up *objs.Update, err error)
+
+/*Middleware wraps a HandlerFunc and returns a new one. The returned function can run code before and after calling "next" or stop the update from going further by not calling "next" at all.*/
+type Middleware func(next HandlerFunc) HandlerFunc
+
+//Match holds the submatches of a handler's regex.
+type Match struct {
+	//Groups holds the whole matched text in Groups[0] followed by the captured groups.
+	Groups []string
+	//Named holds the captured groups which have a name, like (?P<id>\d+).
+	Named map[string]string
+}
+
+//MatchHandlerFunc is a text handler that also receives the submatches of its regex.
+type MatchHandlerFunc func(up *objs.Update, match *Match)
 
 type handler struct {
-	regex    *regexp.Regexp      //The compiled regex.
-	chatType string              //The ChatType this handler will act on
-	function *func(*objs.Update) //The function to be executed
+	regex     *regexp.Regexp    //The compiled regex.
+	chatTypes []string          //The ChatTypes this handler will act on
+	function  *MatchHandlerFunc //The function to be executed
+	options   HandlerOptions
+	seq       int //The order this handler has been added in
 }
 
 type callbackHandler struct {
@@ -21,46 +78,242 @@ type callbackHandler struct {
 	function     *func(*objs.Update)
 }
 
+type callbackPatternHandler struct {
+	regex    *regexp.Regexp
+	function *MatchHandlerFunc
+}
+
+func (p *Parser) AddHandler(patern string, handlerFunc func(*objs.Update), chatType ...string) error {
+	return p.AddMatchHandler(patern, func(up *objs.Update, match *Match) {
+		handlerFunc(up)
+	}, chatType...)
+}
+
+/*AddHandler adds a handler to the default parser. See "AddHandler" method of the Parser.
+
+Deprecated: Use "AddHandler" method of a Parser (or the bot).*/
 func AddHandler(patern string, handlerFunc func(*objs.Update), chatType ...string) error {
-	hl := handler{chatType: strings.Join(chatType, ","), function: &handlerFunc}
+	return defaultParser.AddHandler(patern, handlerFunc, chatType...)
+}
+
+//AddMatchHandler adds a text handler which receives the submatches of the given regex pattern.
+func (p *Parser) AddMatchHandler(patern string, handlerFunc MatchHandlerFunc, chatType ...string) error {
+	return p.AddMatchHandlerWithOptions(patern, handlerFunc, HandlerOptions{}, chatType...)
+}
+
+//AddMatchHandlerWithOptions adds a text handler with the given options.
+func (p *Parser) AddMatchHandlerWithOptions(patern string, handlerFunc MatchHandlerFunc, options HandlerOptions, chatType ...string) error {
+	hl := handler{chatTypes: chatType, function: &handlerFunc, options: options}
 	rgxp, err := regexp.Compile(patern)
 	if err != nil {
 		return err
 	}
 	hl.regex = rgxp
-	handlers.AddHandler(&hl)
+	p.handlers.AddHandler(&hl)
 	return nil
 }
 
-func AddCallbackHandler(data string, handlerFun func(*objs.Update)) {
+func (p *Parser) AddCallbackHandler(data string, handlerFun func(*objs.Update)) {
 	hl := callbackHandler{callbackData: data, function: &handlerFun}
-	callbackHandlers[data] = &hl
+	p.registryLock.Lock()
+	defer p.registryLock.Unlock()
+	p.callbackHandlers[data] = &hl
+}
+
+/*AddCallbackHandler adds a callback handler to the default parser. See "AddCallbackHandler" method of the Parser.
+
+Deprecated: Use "AddCallbackHandler" method of a Parser (or the bot).*/
+func AddCallbackHandler(data string, handlerFun func(*objs.Update)) {
+	defaultParser.AddCallbackHandler(data, handlerFun)
+}
+
+/*AddCallbackPrefixHandler adds a handler for the callback queries whose data starts with the given prefix. Groups[1] of the match passed to the handler holds the data after the prefix.*/
+func (p *Parser) AddCallbackPrefixHandler(prefix string, handlerFunc MatchHandlerFunc) {
+	hl := callbackPatternHandler{regex: regexp.MustCompile(`^` + regexp.QuoteMeta(prefix) + `([\s\S]*)$`), function: &handlerFunc}
+	p.registryLock.Lock()
+	defer p.registryLock.Unlock()
+	p.callbackPatternHandlers = append(p.callbackPatternHandlers, &hl)
+}
+
+//AddCallbackRegexHandler adds a handler for the callback queries whose data matches the given regex pattern.
+func (p *Parser) AddCallbackRegexHandler(pattern string, handlerFunc MatchHandlerFunc) error {
+	rgxp, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	hl := callbackPatternHandler{regex: rgxp, function: &handlerFunc}
+	p.registryLock.Lock()
+	defer p.registryLock.Unlock()
+	p.callbackPatternHandlers = append(p.callbackPatternHandlers, &hl)
+	return nil
+}
+
+/*Interceptor is called for every update before the handlers are checked. If it returns a non nil function, the update is considered handled and the returned function is executed instead of the handlers. The functions returned for the updates of a chat are executed one after another, in the order the updates have been received.*/
+type Interceptor func(*objs.Update) HandlerFunc
+
+//AddInterceptor adds an interceptor. Interceptors are checked in the order they have been added.
+func (p *Parser) AddInterceptor(interceptor Interceptor) {
+	p.registryLock.Lock()
+	defer p.registryLock.Unlock()
+	p.interceptors = append(p.interceptors, interceptor)
 }
 
-func checkHandlers(up *objs.Update) bool {
+/*Use adds the given middlewares to the middleware chain. Middlewares are executed in the order they have been added, meaning the first added middleware is the outermost one.*/
+func (p *Parser) Use(mws ...Middleware) {
+	p.registryLock.Lock()
+	defer p.registryLock.Unlock()
+	p.middlewares = append(p.middlewares, mws...)
+}
+
+//Wrap applies the middleware chain to the given function.
+func (p *Parser) Wrap(function HandlerFunc) HandlerFunc {
+	p.registryLock.RLock()
+	mws := p.middlewares
+	p.registryLock.RUnlock()
+	for i := len(mws) - 1; i >= 0; i-- {
+		function = mws[i](function)
+	}
+	return function
+}
+
+//WaitForHandlers blocks until all the handlers that are being executed return or the given context is done. In the latter case the context's error is returned.
+func (p *Parser) WaitForHandlers(ctx context.Context) error {
+	done := make(chan bool)
+	go func() {
+		p.runningHandlers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+/*SetErrorHandler sets the function that is called when a handler panics. If no error handler is set, panics are logged. Either way the panic does not stop the bot.*/
+func (p *Parser) SetErrorHandler(eh ErrorHandler) {
+	p.registryLock.Lock()
+	defer p.registryLock.Unlock()
+	p.errorHandler = eh
+}
+
+/*runHandler executes the handler with the middlewares. If "ordered" is true, the handler is executed after the previous ordered handlers of its chat whatever the dispatcher configs are.*/
+func (p *Parser) runHandler(function func(*objs.Update), up *objs.Update, ordered bool) {
+	wrapped := p.Wrap(function)
+	p.runningHandlers.Add(1)
+	var tracker *sync.WaitGroup
+	if tr, ok := p.trackers.Load(up); ok {
+		tracker = tr.(*sync.WaitGroup)
+		tracker.Add(1)
+	}
+	p.dispatch(up, func(run bool) {
+		defer p.runningHandlers.Done()
+		if tracker != nil {
+			defer tracker.Done()
+		}
+		if run {
+			defer p.Recover(up)
+			wrapped(up)
+		}
+	}, ordered)
+}
+
+/*Recover recovers the panic of a handler and passes it to the error handler as *errors.HandlerPanic. It must be deferred directly by the function that may panic, like "defer parser.Recover(update)".*/
+func (p *Parser) Recover(up *objs.Update) {
+	rc := recover()
+	if rc == nil {
+		return
+	}
+	err := &errs.HandlerPanic{Value: rc, Stack: debug.Stack()}
+	p.registryLock.RLock()
+	eh := p.errorHandler
+	p.registryLock.RUnlock()
+	if eh != nil {
+		eh(up, err)
+	} else {
+		logger.Log("Error", "\t\t\t", err.Error(), "", logger.BOLD+logger.FAIL, logger.WARNING, "")
+		logger.Logger.Println(string(err.Stack))
+	}
+}
+
+func (p *Parser) checkHandlers(up *objs.Update) bool {
+	p.registryLock.RLock()
+	icps := p.interceptors
+	p.registryLock.RUnlock()
+	for _, interceptor := range icps {
+		if function := interceptor(up); function != nil {
+			p.runHandler(function, up, true)
+			return true
+		}
+	}
 	if up.CallbackQuery != nil {
-		return checkCallbackHanlders(up)
+		return p.checkCallbackHanlders(up)
 	} else {
-		return checkTextMsgHandlers(up)
+		return p.checkTextMsgHandlers(up) || p.checkUpdateHandlers(up)
 	}
 }
 
-func checkCallbackHanlders(up *objs.Update) bool {
-	hdl := callbackHandlers[up.CallbackQuery.Data]
+func (p *Parser) checkCallbackHanlders(up *objs.Update) bool {
+	data := up.CallbackQuery.Data
+	p.registryLock.RLock()
+	hdl := p.callbackHandlers[data]
+	phdls := p.callbackPatternHandlers
+	p.registryLock.RUnlock()
 	if hdl != nil {
-		go (*hdl.function)(up)
+		p.runHandler(*hdl.function, up, false)
 		return true
 	}
+	for _, phdl := range phdls {
+		if phdl.regex.MatchString(data) {
+			function := *phdl.function
+			match := createMatch(phdl.regex, data)
+			p.runHandler(func(up *objs.Update) {
+				function(up, match)
+			}, up, false)
+			return true
+		}
+	}
 	return false
 }
 
-func checkTextMsgHandlers(up *objs.Update) bool {
+func (p *Parser) checkTextMsgHandlers(up *objs.Update) bool {
 	if up.Message != nil && up.Message.Text != "" {
-		hndl := handlers.GetHandler(up.Message)
-		if hndl != nil {
-			go (*hndl.function)(up)
+		hndls := p.handlers.GetHandlers(up.Message)
+		if len(hndls) != 0 {
+			p.runHandler(func(up *objs.Update) {
+				for _, hndl := range hndls {
+					p.callTextHandler(hndl, up)
+				}
+			}, up, false)
 			return true
 		}
 	}
 	return false
 }
+
+//callTextHandler executes the text handler and recovers its panic, so a panicking handler does not stop the next matching handlers.
+func (p *Parser) callTextHandler(hndl *handler, up *objs.Update) {
+	defer p.Recover(up)
+	(*hndl.function)(up, createMatch(hndl.regex, up.Message.Text))
+}
+
+//SetDispatchMode sets how many of the matching text handlers are executed for a message.
+func (p *Parser) SetDispatchMode(mode DispatchMode) {
+	p.handlers.SetMode(mode)
+}
+
+//Explain returns a human readable report of how a text message with the given text in the given chat type is dispatched to the text handlers.
+func (p *Parser) Explain(text, chatType string) string {
+	return p.handlers.Explain(text, chatType)
+}
+
+func createMatch(rgx *regexp.Regexp, text string) *Match {
+	match := &Match{Groups: rgx.FindStringSubmatch(text), Named: make(map[string]string)}
+	for i, name := range rgx.SubexpNames() {
+		if name != "" && i < len(match.Groups) {
+			match.Named[name] = match.Groups[i]
+		}
+	}
+	return match
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	errs "github.com/SakoDroid/telego/errors"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	p := NewParser()
+	order := make([]string, 0)
+	record := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(up *objs.Update) {
+				order = append(order, name)
+				next(up)
+			}
+		}
+	}
+	p.Use(record("first"), record("second"))
+	p.Wrap(func(up *objs.Update) {
+		order = append(order, "handler")
+	})(&objs.Update{})
+	if len(order) != 3 || order[0] != "first" || order[1] != "second" || order[2] != "handler" {
+		t.Error("wrong execution order :", order)
+	}
+}
+
+func TestMiddlewareStopsUpdate(t *testing.T) {
+	p := NewParser()
+	p.Use(func(next HandlerFunc) HandlerFunc {
+		return func(up *objs.Update) {
+			if up.Update_id != 0 {
+				next(up)
+			}
+		}
+	})
+	called := false
+	p.Wrap(func(up *objs.Update) {
+		called = true
+	})(&objs.Update{})
+	if called {
+		t.Error("handler was called although the middleware did not call next")
+	}
+}
+
+func TestHandlerPanicRecovery(t *testing.T) {
+	p := NewParser()
+	received := make(chan error, 1)
+	p.SetErrorHandler(func(up *objs.Update, err error) {
+		received <- err
+	})
+	p.runHandler(func(up *objs.Update) {
+		panic("boom")
+	}, &objs.Update{}, false)
+	select {
+	case err := <-received:
+		hp, ok := err.(*errs.HandlerPanic)
+		if !ok || hp.Value != "boom" || len(hp.Stack) == 0 {
+			t.Error("wrong error :", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("panic was not passed to the error handler")
+	}
+}
+
+func TestPanicOfMatchingHandler(t *testing.T) {
+	p := NewParser()
+	p.SetDispatchMode(AllMatches)
+	failed := make(chan error, 1)
+	p.SetErrorHandler(func(up *objs.Update, err error) {
+		failed <- err
+	})
+	called := make(chan bool, 1)
+	_ = p.AddMatchHandlerWithOptions("^/start$", func(up *objs.Update, match *Match) {
+		panic("boom")
+	}, HandlerOptions{Priority: 1}, "all")
+	_ = p.AddHandler("^/", func(up *objs.Update) {
+		called <- true
+	}, "all")
+	if !p.checkTextMsgHandlers(&objs.Update{Message: &objs.Message{Text: "/start", Chat: &objs.Chat{Id: 1, Type: "private"}}}) {
+		t.Fatal("message was not handled")
+	}
+	select {
+	case err := <-failed:
+		if hp, ok := err.(*errs.HandlerPanic); !ok || hp.Value != "boom" {
+			t.Error("wrong error :", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("panic was not passed to the error handler")
+	}
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Fatal("next matching handler was not called after the panic")
+	}
+}
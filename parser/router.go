@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+//DispatchMode specifies how many of the matching handlers are executed for a message.
+type DispatchMode int
+
+const (
+	//FirstMatch executes the first matching handler. Handlers with "Fallthrough" option pass the message to the next matching handler too.
+	FirstMatch DispatchMode = iota
+	//AllMatches executes all the matching handlers in order.
+	AllMatches
+)
+
+/*HandlerOptions are the options of a text handler.
+
+"Priority" : Handlers with higher priority are checked first. Default is 0.
+
+"Fallthrough" : In FirstMatch mode, after this handler is executed, the next matching handler is executed too.
+
+"Filters" : The handler matches a message only if all the filters accept it.*/
+type HandlerOptions struct {
+	Priority    int
+	Fallthrough bool
+	Filters     []Filter
+}
+
+/*Router stores the text handlers in a deterministic order. Handlers are sorted by their priority (higher first), then by the length of their pattern (longer patterns are considered more specific and come first) and then by the order they have been added.
+
+Router is safe for concurrent use. Adding a handler creates a new sorted list, so the messages that are being dispatched keep using the list they have started with.*/
+type Router struct {
+	handlers []*handler
+	mode     DispatchMode
+	seq      int
+	lock     sync.RWMutex
+}
+
+//AddHandler adds a new handler to the router.
+func (rt *Router) AddHandler(hdl *handler) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	rt.seq++
+	hdl.seq = rt.seq
+	hdls := make([]*handler, len(rt.handlers), len(rt.handlers)+1)
+	copy(hdls, rt.handlers)
+	hdls = append(hdls, hdl)
+	sort.SliceStable(hdls, func(i, j int) bool {
+		return hdls[i].before(hdls[j])
+	})
+	rt.handlers = hdls
+}
+
+//SetMode sets the dispatch mode of the router.
+func (rt *Router) SetMode(mode DispatchMode) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	rt.mode = mode
+}
+
+func (rt *Router) snapshot() ([]*handler, DispatchMode) {
+	rt.lock.RLock()
+	defer rt.lock.RUnlock()
+	return rt.handlers, rt.mode
+}
+
+//GetHandler returns the first handler that matches the given message. Returns nil if no handler matches.
+func (rt *Router) GetHandler(msg *objs.Message) *handler {
+	up := &objs.Update{Message: msg}
+	hdls, _ := rt.snapshot()
+	for _, hdl := range hdls {
+		if hdl.matches(up) {
+			return hdl
+		}
+	}
+	return nil
+}
+
+//GetHandlers returns the handlers that should be executed for the given message according to the dispatch mode, in the order they should be executed.
+func (rt *Router) GetHandlers(msg *objs.Message) []*handler {
+	var out []*handler
+	up := &objs.Update{Message: msg}
+	hdls, mode := rt.snapshot()
+	for _, hdl := range hdls {
+		if !hdl.matches(up) {
+			continue
+		}
+		out = append(out, hdl)
+		if mode == FirstMatch && !hdl.options.Fallthrough {
+			break
+		}
+	}
+	return out
+}
+
+/*Explain returns a human readable report of how a message with the given text in the given chat type is dispatched. Every handler is listed in the order it's checked along with the reason it's selected or skipped. Filters are evaluated on a message that only has the text and the chat type.*/
+func (rt *Router) Explain(text, chatType string) string {
+	up := &objs.Update{Message: &objs.Message{Text: text, Chat: &objs.Chat{Type: chatType}}}
+	hdls, mode := rt.snapshot()
+	var sb strings.Builder
+	sb.WriteString("Dispatching \"" + text + "\" in a " + chatType + " chat (mode : " + mode.String() + ")\n")
+	done, selected := false, false
+	for i, hdl := range hdls {
+		sb.WriteString(strconv.Itoa(i+1) + ". `" + hdl.regex.String() + "` (priority " + strconv.Itoa(hdl.options.Priority) + ", chat types : " + strings.Join(hdl.chatTypes, ",") + ") : ")
+		switch {
+		case !hdl.regex.MatchString(text):
+			sb.WriteString("regex does not match")
+		case !hdl.acceptsChat(chatType):
+			sb.WriteString("chat type is not accepted")
+		case !hdl.acceptsFilters(up):
+			sb.WriteString("rejected by the filters")
+		case done:
+			sb.WriteString("matches but skipped, an earlier handler has been selected")
+		default:
+			selected = true
+			sb.WriteString("selected")
+			if mode == FirstMatch {
+				if hdl.options.Fallthrough {
+					sb.WriteString(", falls through")
+				} else {
+					done = true
+				}
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if !selected {
+		sb.WriteString("No handler matches. The update is passed to the channels.\n")
+	}
+	return sb.String()
+}
+
+func (mode DispatchMode) String() string {
+	if mode == AllMatches {
+		return "all matches"
+	}
+	return "first match"
+}
+
+//before returns true if this handler should be checked before the other handler.
+func (hdl *handler) before(other *handler) bool {
+	if hdl.options.Priority != other.options.Priority {
+		return hdl.options.Priority > other.options.Priority
+	}
+	l1, l2 := len(hdl.regex.String()), len(other.regex.String())
+	if l1 != l2 {
+		return l1 > l2
+	}
+	return hdl.seq < other.seq
+}
+
+func (hdl *handler) matches(up *objs.Update) bool {
+	return hdl.acceptsChat(up.Message.Chat.Type) && hdl.regex.MatchString(up.Message.Text) && hdl.acceptsFilters(up)
+}
+
+func (hdl *handler) acceptsFilters(up *objs.Update) bool {
+	for _, filter := range hdl.options.Filters {
+		if !filter(up) {
+			return false
+		}
+	}
+	return true
+}
+
+func (hdl *handler) acceptsChat(chatType string) bool {
+	for _, ct := range hdl.chatTypes {
+		if ct == chatType || ct == "all" {
+			return true
+		}
+	}
+	return false
+}
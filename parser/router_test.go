@@ -2,12 +2,13 @@ package parser
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/SakoDroid/telego/objects"
 )
 
-var tree = &HandlerTree{}
+var tree = &Router{}
 var testTable []handlerTest
 
 type handlerTest struct {
@@ -15,7 +16,7 @@ type handlerTest struct {
 	expectedRegex string
 }
 
-func TestTree(t *testing.T) {
+func TestRouter(t *testing.T) {
 	initTheHandlers()
 	initTheTable()
 	for _, test := range testTable {
@@ -42,12 +43,12 @@ func TestTree(t *testing.T) {
 }
 
 func initTheHandlers() {
-	handler1 := &handler{regex: regexp.MustCompile("hi"), chatType: "all"}
-	handler2 := &handler{regex: regexp.MustCompile("hi guys"), chatType: "private"}
-	handler3 := &handler{regex: regexp.MustCompile("start"), chatType: "all"}
-	handler4 := &handler{regex: regexp.MustCompile("start again"), chatType: "private"}
-	handler5 := &handler{regex: regexp.MustCompile("start bot"), chatType: "all"}
-	handler6 := &handler{regex: regexp.MustCompile("hi everyone"), chatType: "private,group"}
+	handler1 := &handler{regex: regexp.MustCompile("hi"), chatTypes: []string{"all"}}
+	handler2 := &handler{regex: regexp.MustCompile("hi guys"), chatTypes: []string{"private"}}
+	handler3 := &handler{regex: regexp.MustCompile("start"), chatTypes: []string{"all"}}
+	handler4 := &handler{regex: regexp.MustCompile("start again"), chatTypes: []string{"private"}}
+	handler5 := &handler{regex: regexp.MustCompile("start bot"), chatTypes: []string{"all"}}
+	handler6 := &handler{regex: regexp.MustCompile("hi everyone"), chatTypes: []string{"private", "group"}}
 	tree.AddHandler(handler1)
 	tree.AddHandler(handler2)
 	tree.AddHandler(handler3)
@@ -67,3 +68,38 @@ func initTheTable() {
 	test8 := handlerTest{msg: &objects.Message{Text: "start again", Chat: &objects.Chat{Type: "private"}}, expectedRegex: "start again"}
 	testTable = []handlerTest{test1, test2, test3, test4, test5, test6, test7, test8}
 }
+
+func TestRouterPriorityAndModes(t *testing.T) {
+	newRouter := func(priority int) (*Router, *handler, *handler, *handler) {
+		rt := &Router{}
+		low := &handler{regex: regexp.MustCompile("^/start"), chatTypes: []string{"all"}, options: HandlerOptions{Fallthrough: true}}
+		catchAll := &handler{regex: regexp.MustCompile("^/"), chatTypes: []string{"all"}, options: HandlerOptions{Priority: priority}}
+		other := &handler{regex: regexp.MustCompile("^/start$"), chatTypes: []string{"supergroup"}}
+		rt.AddHandler(low)
+		rt.AddHandler(catchAll)
+		rt.AddHandler(other)
+		rt.AddHandler(&handler{regex: regexp.MustCompile("nothing"), chatTypes: []string{"all"}})
+		return rt, low, catchAll, other
+	}
+	msg := &objects.Message{Text: "/start", Chat: &objects.Chat{Type: "group"}}
+	rt, _, high, _ := newRouter(1)
+	if hdls := rt.GetHandlers(msg); len(hdls) != 1 || hdls[0] != high {
+		t.Error("handler with higher priority was not selected alone")
+	}
+	rt, low, lowest, other := newRouter(-1)
+	if hdls := rt.GetHandlers(msg); len(hdls) != 2 || hdls[0] != low || hdls[1] != lowest {
+		t.Error("fallthrough did not pass the message to the next handler")
+	}
+	rt.SetMode(AllMatches)
+	supergroupMsg := &objects.Message{Text: "/start", Chat: &objects.Chat{Type: "supergroup"}}
+	if hdls := rt.GetHandlers(supergroupMsg); len(hdls) != 3 || hdls[0] != other || hdls[1] != low || hdls[2] != lowest {
+		t.Error("wrong handlers in all matches mode :", len(hdls))
+	}
+	rt.SetMode(FirstMatch)
+	explanation := rt.Explain("/start", "group")
+	for _, line := range []string{"1. `^/start$` (priority 0, chat types : supergroup) : chat type is not accepted", "2. `^/start` (priority 0, chat types : all) : selected, falls through", "3. `nothing` (priority 0, chat types : all) : regex does not match", "4. `^/` (priority -1, chat types : all) : selected"} {
+		if !strings.Contains(explanation, line+"\n") {
+			t.Error("explanation does not contain :", line, "\n", explanation)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+//updateHandler is a handler for any type of update.
+type updateHandler struct {
+	updateType string
+	regex      *regexp.Regexp //The compiled regex. nil matches all updates.
+	filters    []Filter
+	function   *MatchHandlerFunc
+}
+
+/*AddUpdateHandler adds a handler for the given update type. Update types are the ones returned by the "GetType" method of the update, except "callback_query" and "poll" which are handled separately.
+
+"pattern" is a regex that is matched against the text of the update, which is the text or the caption for messages and channel posts, the query for inline queries and chosen inline results and the invoice payload for shipping and pre-checkout queries. Pass empty string to match all the updates. Other update types have no text, so their pattern must be empty.
+
+The update is processed by the handler only if all the given filters accept it. Update handlers are checked in the order they have been added and only the first matching one is executed.*/
+func (p *Parser) AddUpdateHandler(updateType, pattern string, handlerFunc MatchHandlerFunc, filters ...Filter) error {
+	hl := updateHandler{updateType: updateType, filters: filters, function: &handlerFunc}
+	switch updateType {
+	case "message", "edited_message", "channel_post", "edited_channel_post", "inline_query", "chosen_inline_result", "shipping_query", "pre_checkout_query":
+	case "poll_answer", "my_chat_member", "chat_member", "chat_join_request":
+		if pattern != "" {
+			return errors.New(updateType + " updates have no text, pattern must be empty")
+		}
+	default:
+		return errors.New("unknown update type : " + updateType)
+	}
+	if pattern != "" {
+		rgxp, err := regexp.Compile(pattern)
+		if err != nil {
+			return err
+		}
+		hl.regex = rgxp
+	}
+	p.registryLock.Lock()
+	defer p.registryLock.Unlock()
+	p.updateHandlers = append(p.updateHandlers, &hl)
+	return nil
+}
+
+func (p *Parser) checkUpdateHandlers(up *objs.Update) bool {
+	upType := up.GetType()
+	p.registryLock.RLock()
+	hls := p.updateHandlers
+	p.registryLock.RUnlock()
+	for _, hl := range hls {
+		if hl.updateType != upType || !hl.accepts(up) {
+			continue
+		}
+		function := *hl.function
+		match := &Match{}
+		if hl.regex != nil {
+			match = createMatch(hl.regex, getText(up))
+		}
+		p.runHandler(func(up *objs.Update) {
+			function(up, match)
+		}, up, false)
+		return true
+	}
+	return false
+}
+
+func (hl *updateHandler) accepts(up *objs.Update) bool {
+	if hl.regex != nil && !hl.regex.MatchString(getText(up)) {
+		return false
+	}
+	for _, filter := range hl.filters {
+		if !filter(up) {
+			return false
+		}
+	}
+	return true
+}
+
+//getText returns the text of the update that the pattern of the update handlers are matched against.
+func getText(up *objs.Update) string {
+	if msg := getMessage(up); msg != nil {
+		if msg.Text != "" {
+			return msg.Text
+		}
+		return msg.Caption
+	}
+	switch {
+	case up.InlineQuery != nil:
+		return up.InlineQuery.Query
+	case up.ChosenInlineResult != nil:
+		return up.ChosenInlineResult.Query
+	case up.ShippingQuery != nil:
+		return up.ShippingQuery.InvoicePayload
+	case up.PreCheckoutQuery != nil:
+		return up.PreCheckoutQuery.InvoicePayload
+	}
+	return ""
+}
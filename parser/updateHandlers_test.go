@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestUpdateHandlers(t *testing.T) {
+	p := NewParser()
+	received := make(chan string, 10)
+	record := func(name string) MatchHandlerFunc {
+		return func(up *objs.Update, match *Match) {
+			if len(match.Groups) > 1 {
+				received <- name + ":" + match.Groups[1]
+				return
+			}
+			received <- name
+		}
+	}
+	if p.AddUpdateHandler("chat_member", "pattern", record("")) == nil || p.AddUpdateHandler("poll", "", record("")) == nil {
+		t.Error("invalid update handlers were added")
+	}
+	_ = p.AddUpdateHandler("message", `^#(\w+)`, record("photo"), MediaTypes("photo"), ChatTypes("group"))
+	_ = p.AddUpdateHandler("message", "", record("document"), MediaTypes("document"), Senders(1))
+	_ = p.AddUpdateHandler("inline_query", "^gif", record("inline"))
+	_ = p.AddUpdateHandler("edited_message", "", record("edited"), ChatIds(10))
+	chat := &objs.Chat{Id: 10, Type: "group"}
+	tests := []struct {
+		up       *objs.Update
+		expected string
+	}{
+		{&objs.Update{Message: &objs.Message{Chat: chat, Caption: "#cats", Photo: []objs.PhotoSize{{}}}}, "photo:cats"},
+		{&objs.Update{Message: &objs.Message{Chat: &objs.Chat{Type: "private"}, Caption: "#cats", Photo: []objs.PhotoSize{{}}}}, ""},
+		{&objs.Update{Message: &objs.Message{Chat: chat, From: &objs.User{Id: 1}, Document: &objs.Document{}}}, "document"},
+		{&objs.Update{Message: &objs.Message{Chat: chat, From: &objs.User{Id: 2}, Document: &objs.Document{}}}, ""},
+		{&objs.Update{Message: &objs.Message{Chat: chat, Animation: &objs.Animation{}, Document: &objs.Document{}}}, ""},
+		{&objs.Update{InlineQuery: &objs.InlineQuery{Query: "gif dogs"}}, "inline"},
+		{&objs.Update{EditedMessage: &objs.Message{Chat: chat, Text: "edited"}}, "edited"},
+		{&objs.Update{EditedMessage: &objs.Message{Chat: &objs.Chat{Id: 11}, Text: "edited"}}, ""},
+	}
+	for _, test := range tests {
+		if p.checkUpdateHandlers(test.up) != (test.expected != "") {
+			t.Error("wrong result for", test.expected, test.up.GetType())
+			continue
+		}
+		_ = p.WaitForHandlers(context.Background())
+		if test.expected != "" {
+			if name := <-received; name != test.expected {
+				t.Error("wrong handler :", name, ", expected :", test.expected)
+			}
+		}
+	}
+}
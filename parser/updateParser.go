@@ -1,9 +1,11 @@
 package parser
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/SakoDroid/telego/configs"
 	errs "github.com/SakoDroid/telego/errors"
@@ -12,7 +14,7 @@ import (
 )
 
 //ParseUpdate parses the received update and returns the last update offset.
-func ParseUpdate(body []byte, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) (int, error) {
+func (p *Parser) ParseUpdate(body []byte, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) (int, error) {
 	def := &objs.DefaultResult{}
 	err2 := json.Unmarshal(body, def)
 	if err2 != nil {
@@ -26,58 +28,117 @@ func ParseUpdate(body []byte, uc *chan *objs.Update, cu *chan *objs.ChatUpdate,
 	if err != nil {
 		return 0, err
 	}
-	return parse(ur, uc, cu, cfg)
+	return p.parse(ur, uc, cu, cfg)
+}
+
+/*ParseUpdate parses the update with the default parser. See "ParseUpdate" method of the Parser.
+
+Deprecated: Use "ParseUpdate" method of a Parser.*/
+func ParseUpdate(body []byte, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) (int, error) {
+	return defaultParser.ParseUpdate(body, uc, cu, cfg)
 }
 
-func parse(ur *objs.UpdateResult, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) (int, error) {
+func (p *Parser) parse(ur *objs.UpdateResult, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) (int, error) {
 	lastOffset := 0
 	for _, val := range ur.Result {
 		if val.Update_id > lastOffset {
 			lastOffset = val.Update_id
 		}
-		ParseSingleUpdate(val, uc, cu, cfg)
+		p.ParseSingleUpdate(val, uc, cu, cfg)
 	}
 	return lastOffset, nil
 }
 
 //ParseSingleUpdate processes the given update object.
-func ParseSingleUpdate(up *objs.Update, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) {
+func (p *Parser) ParseSingleUpdate(up *objs.Update, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) {
 	userId, isUserBlocked := isUserBlocked(up, cfg)
 	if !isUserBlocked {
 		logger.Log("Update", "\t\t\t\t", up.GetType(), "Parsed", logger.HEADER, logger.OKCYAN, logger.OKGREEN)
-		if !checkHandlers(up) && !processChat(up, cu) {
-			*uc <- up
+		policy := cfg.DispatcherConfigs.Policy()
+		ctx := p.runContext()
+		if !p.checkHandlers(up) && !p.processChat(ctx, up, cu, policy) {
+			p.SendUpdate(ctx, *uc, up, policy)
 		}
 	} else {
 		logger.Log("Update", "\t\t\t\t", up.GetType(), fmt.Sprintf("User %d is blocked", userId), logger.HEADER, logger.OKCYAN, logger.FAIL)
 	}
 }
 
-func processChat(update *objs.Update, chatUpdateChannel *chan *objs.ChatUpdate) bool {
-	var chat *objs.Chat
+/*ParseSingleUpdate parses the update with the default parser. See "ParseSingleUpdate" method of the Parser.
+
+Deprecated: Use "ParseSingleUpdate" method of a Parser.*/
+func ParseSingleUpdate(up *objs.Update, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) {
+	defaultParser.ParseSingleUpdate(up, uc, cu, cfg)
+}
+
+/*ParseAndTrack works like "ParseSingleUpdate" but it returns a wait group that is done when all the handlers executed for this update have returned. If the update is not processed by any handler, the wait group is already done.*/
+func (p *Parser) ParseAndTrack(up *objs.Update, uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs) *sync.WaitGroup {
+	wg := &sync.WaitGroup{}
+	p.trackers.Store(up, wg)
+	defer p.trackers.Delete(up)
+	p.ParseSingleUpdate(up, uc, cu, cfg)
+	return wg
+}
+
+func (p *Parser) processChat(ctx context.Context, update *objs.Update, chatUpdateChannel *chan *objs.ChatUpdate, policy string) bool {
+	chat := GetChat(update)
+	if chat == nil {
+		return false
+	}
+	p.sendChatUpdate(ctx, *chatUpdateChannel, createChatUpdate(chat, update), policy)
+	return true
+}
+
+//GetChat returns the chat that the given update belongs to. Returns nil if the update is not related to a chat.
+func GetChat(update *objs.Update) *objs.Chat {
 	switch {
 	case update.Message != nil:
-		chat = update.Message.Chat
+		return update.Message.Chat
 	case update.EditedMessage != nil:
-		chat = update.EditedMessage.Chat
+		return update.EditedMessage.Chat
 	case update.ChannelPost != nil:
-		chat = update.ChannelPost.Chat
+		return update.ChannelPost.Chat
 	case update.EditedChannelPost != nil:
-		chat = update.EditedChannelPost.Chat
+		return update.EditedChannelPost.Chat
 	case update.MyChatMember != nil:
-		chat = update.MyChatMember.Chat
+		return update.MyChatMember.Chat
 	case update.ChatMember != nil:
-		chat = update.ChatMember.Chat
+		return update.ChatMember.Chat
 	case update.ChatJoinRequest != nil:
-		chat = update.ChatJoinRequest.Chat
+		return update.ChatJoinRequest.Chat
 	case update.CallbackQuery != nil:
-		chat = update.CallbackQuery.Message.Chat
+		return update.CallbackQuery.Message.Chat
 	}
-	if chat == nil {
-		return false
+	return nil
+}
+
+//GetSender returns the user who has sent the given update. Returns nil if the update has no sender (for example channel posts).
+func GetSender(update *objs.Update) *objs.User {
+	switch {
+	case update.Message != nil:
+		return update.Message.From
+	case update.EditedMessage != nil:
+		return update.EditedMessage.From
+	case update.InlineQuery != nil:
+		return update.InlineQuery.From
+	case update.ChosenInlineResult != nil:
+		return &update.ChosenInlineResult.From
+	case update.CallbackQuery != nil:
+		return &update.CallbackQuery.From
+	case update.ShippingQuery != nil:
+		return update.ShippingQuery.From
+	case update.PreCheckoutQuery != nil:
+		return update.PreCheckoutQuery.From
+	case update.PollAnswer != nil:
+		return update.PollAnswer.User
+	case update.MyChatMember != nil:
+		return update.MyChatMember.From
+	case update.ChatMember != nil:
+		return update.ChatMember.From
+	case update.ChatJoinRequest != nil:
+		return update.ChatJoinRequest.From
 	}
-	*chatUpdateChannel <- createChatUpdate(chat, update)
-	return true
+	return nil
 }
 
 func createChatUpdate(chat *objs.Chat, update *objs.Update) *objs.ChatUpdate {
@@ -92,29 +153,19 @@ func createChatUpdate(chat *objs.Chat, update *objs.Update) *objs.ChatUpdate {
 
 func isUserBlocked(up *objs.Update, cfg *configs.BotConfigs) (int, bool) {
 	switch up.GetType() {
-	case "message":
-		return checkBlocked(up.Message.From, cfg)
-	case "edited_message":
-		return checkBlocked(up.EditedMessage.From, cfg)
-	case "inline_query":
-		return checkBlocked(up.InlineQuery.From, cfg)
-	case "chosen_inline_result":
-		return checkBlocked(&up.ChosenInlineResult.From, cfg)
-	case "callback_query":
-		return checkBlocked(&up.CallbackQuery.From, cfg)
-	case "shipping_query":
-		return checkBlocked(up.ShippingQuery.From, cfg)
-	case "pre_checkout_query":
-		return checkBlocked(up.PreCheckoutQuery.From, cfg)
-	case "poll_answer":
-		return checkBlocked(up.PollAnswer.User, cfg)
+	case "message", "edited_message", "inline_query", "chosen_inline_result", "callback_query", "shipping_query", "pre_checkout_query", "poll_answer":
+		user := GetSender(up)
+		if user == nil {
+			return 0, false
+		}
+		return checkBlocked(user, cfg)
 	default:
 		return 0, false
 	}
 }
 
 func checkBlocked(user *objs.User, cfg *configs.BotConfigs) (int, bool) {
-	for _, us := range cfg.BlockedUsers {
+	for _, us := range cfg.GetBlockedUsers() {
 		if us.UserID == user.Id {
 			return us.UserID, true
 		}
@@ -1,14 +1,31 @@
 package telego
 
 import (
+	"context"
 	"errors"
+	"sync"
 
 	objs "github.com/SakoDroid/telego/objects"
 )
 
-//Polls contains the pointers to all of the created maps.
+/*Polls contains the pointers to all of the created polls. Polls are added to this map when they are sent and updated when the updates are received, so reading it directly while the bot is running is not safe. Use "GetPoll" instead.*/
 var Polls = make(map[string]*Poll)
 
+var pollsLock sync.RWMutex
+
+//GetPoll returns the poll with the given id. Returns nil if the poll has not been sent by this program.
+func GetPoll(id string) *Poll {
+	pollsLock.RLock()
+	defer pollsLock.RUnlock()
+	return Polls[id]
+}
+
+func storePoll(p *Poll) {
+	pollsLock.Lock()
+	defer pollsLock.Unlock()
+	Polls[p.id] = p
+}
+
 //Poll is an automatic poll.
 type Poll struct {
 	bot                                                                     *Bot
@@ -97,7 +114,7 @@ func (p *Poll) Update(poll *objs.Poll) error {
 		return errors.New("this update dos not belong to this poll")
 	}
 	p.result = poll.Options
-	Polls[p.id] = p
+	storePoll(p)
 	*p.updateChannel <- true
 	return nil
 }
@@ -153,7 +170,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (p *Poll) Send(silent, protectContent bool, replyTo int) error {
-	res, err := p.bot.apiInterface.SendPoll(
+	return p.SendContext(context.Background(), silent, protectContent, replyTo)
+}
+
+/*SendContext works like "Send" but sends the request with the given context.*/
+func (p *Poll) SendContext(ctx context.Context, silent, protectContent bool, replyTo int) error {
+	res, err := p.bot.apiInterface.SendPollContext(ctx,
 		p.chatIdInt, p.chatIdString, p.question, p.options, p.isClosed, p.isAnonymouse,
 		p.pollType, p.allowMultipleAnswers, p.correctOptionId, p.explanation, p.explanationParseMode,
 		p.explanationEntities, p.openPeriod, p.closeDate, replyTo, silent, false, protectContent, nil,
@@ -166,7 +188,7 @@ func (p *Poll) Send(silent, protectContent bool, replyTo int) error {
 	p.result = res.Result.Poll.Options
 	ch := make(chan bool)
 	p.updateChannel = &ch
-	Polls[p.id] = p
+	storePoll(p)
 	return nil
 }
 
@@ -176,7 +198,12 @@ If "silent" argument is true, the message will be sent without notification.
 
 If "protectContent" argument is true, the message can't be forwarded or saved.*/
 func (p *Poll) SendAdvanced(replyTo int, silent, allowSendingWithOutReply, protectContent bool, replyMarkup objs.ReplyMarkup) error {
-	res, err := p.bot.apiInterface.SendPoll(
+	return p.SendAdvancedContext(context.Background(), replyTo, silent, allowSendingWithOutReply, protectContent, replyMarkup)
+}
+
+/*SendAdvancedContext works like "SendAdvanced" but sends the request with the given context.*/
+func (p *Poll) SendAdvancedContext(ctx context.Context, replyTo int, silent, allowSendingWithOutReply, protectContent bool, replyMarkup objs.ReplyMarkup) error {
+	res, err := p.bot.apiInterface.SendPollContext(ctx,
 		p.chatIdInt, p.chatIdString, p.question, p.options, p.isClosed, p.isAnonymouse,
 		p.pollType, p.allowMultipleAnswers, p.correctOptionId, p.explanation, p.explanationParseMode,
 		p.explanationEntities, p.openPeriod, p.closeDate, replyTo, silent, allowSendingWithOutReply, protectContent, replyMarkup,
@@ -189,13 +216,18 @@ func (p *Poll) SendAdvanced(replyTo int, silent, allowSendingWithOutReply, prote
 	p.result = res.Result.Poll.Options
 	ch := make(chan bool)
 	p.updateChannel = &ch
-	Polls[p.id] = p
+	storePoll(p)
 	return nil
 }
 
 /*Stop stops the poll*/
 func (p *Poll) Stop() error {
-	_, err := p.bot.apiInterface.StopPoll(
+	return p.StopContext(context.Background())
+}
+
+/*StopContext works like "Stop" but sends the request with the given context.*/
+func (p *Poll) StopContext(ctx context.Context) error {
+	_, err := p.bot.apiInterface.StopPollContext(ctx,
 		p.chatIdInt, p.chatIdString, p.messageId, nil,
 	)
 	return err
@@ -0,0 +1,101 @@
+package telego
+
+import (
+	"context"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+	"testing"
+
+	cfg "github.com/SakoDroid/telego/configs"
+	logger "github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+	upp "github.com/SakoDroid/telego/parser"
+)
+
+//TestRegisterWhileDispatching should be run with the race detector.
+func TestRegisterWhileDispatching(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	uc := make(chan *objs.Update)
+	bot := &Bot{channelsMap: map[string]map[string]*chan *objs.Update{"global": {"all": &uc}}, parser: upp.NewParser()}
+	bot.ab = &AdvancedBot{bot: bot}
+	//Updates are dropped instead of blocking when no one receives them.
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	parserUc := make(chan *objs.Update, 10)
+	parserCu := make(chan *objs.ChatUpdate, 10)
+	go func() {
+		for range parserUc {
+		}
+	}()
+	go func() {
+		for range parserCu {
+		}
+	}()
+	botCfg := &cfg.BotConfigs{}
+	const rounds = 200
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			id := strconv.Itoa(i)
+			bot.AdvancedMode().RegisterChannel(id, "message")
+			fc := bot.AdvancedMode().RegisterFilteredChannel(upp.ChatIds(i))
+			_ = bot.AddHandler("^/cmd"+id+"$", func(u *objs.Update) {}, "all")
+			_ = bot.AddUpdateHandler("edited_message", "^"+id+"$", func(c *Context) error { return nil })
+			bot.parser.AddCallbackHandler("data"+id, func(u *objs.Update) {})
+			bot.AddCallbackPrefixHandler("prefix"+id, func(c *Context) error { return nil })
+			storePoll(&Poll{id: id})
+			bot.AdvancedMode().UnRegisterFilteredChannel(fc)
+			bot.AdvancedMode().UnRegisterChannel(id, "message")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			up := &objs.Update{Message: &objs.Message{Text: "hello", Chat: &objs.Chat{Id: i, Type: "group"}}}
+			bot.processUpdate(cancelled, up, strconv.Itoa(i))
+			bot.processUpdate(cancelled, &objs.Update{Poll: &objs.Poll{Id: "none"}}, "global")
+			_ = GetPoll(strconv.Itoa(i))
+			_ = bot.ExplainHandlers("/cmd1", "group")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			id := strconv.Itoa(i)
+			bot.parser.ParseSingleUpdate(&objs.Update{Message: &objs.Message{Text: "nothing", Chat: &objs.Chat{Id: i, Type: "group"}}}, &parserUc, &parserCu, botCfg)
+			bot.parser.ParseSingleUpdate(&objs.Update{EditedMessage: &objs.Message{Text: "x" + id, Chat: &objs.Chat{Id: i, Type: "group"}}}, &parserUc, &parserCu, botCfg)
+			bot.parser.ParseSingleUpdate(&objs.Update{CallbackQuery: &objs.CallbackQuery{Data: "nothing" + id}}, &parserUc, &parserCu, botCfg)
+		}
+	}()
+	wg.Wait()
+	_ = bot.parser.WaitForHandlers(context.Background())
+	close(parserUc)
+	close(parserCu)
+}
+
+func TestMiddlewarePanicOnChannel(t *testing.T) {
+	uc := make(chan *objs.Update, 1)
+	bot := &Bot{channelsMap: map[string]map[string]*chan *objs.Update{"global": {"all": &uc}}, parser: upp.NewParser()}
+	bot.parser.SetErrorHandler(bot.handleError)
+	var failure error
+	bot.SetErrorHandler(func(u *objs.Update, err error) { failure = err })
+	bot.parser.Use(func(next upp.HandlerFunc) upp.HandlerFunc {
+		return func(u *objs.Update) {
+			panic("middleware failed")
+		}
+	})
+	up := createTestMessage(1, "hello")
+	bot.deliver(context.Background(), bot.getChannel("global", "all"), up)
+	if failure == nil {
+		t.Fatal("panic of the middleware was not passed to the error handler")
+	}
+	if len(uc) != 0 {
+		t.Error("update was delivered although the middleware did not call next")
+	}
+}
@@ -0,0 +1,93 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+/*FileStorage is a storage that keeps all the values in a single json file. The whole file is loaded in memory when the storage is created and it is rewritten after every change, so it is suitable for small bots that need their sessions to survive restarts.*/
+type FileStorage struct {
+	path  string
+	items map[string]*storageItem
+	lock  sync.Mutex
+}
+
+/*NewFileStorage creates a file storage that uses the file located at the given path. If the file exists, the stored values are loaded from it.*/
+func NewFileStorage(path string) (*FileStorage, error) {
+	fs := &FileStorage{path: path, items: make(map[string]*storageItem)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fs, nil
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return fs, nil
+	}
+	err = json.Unmarshal(data, &fs.items)
+	if err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+//Get returns the value stored for the given key. If the key does not exist or has been expired ErrNotFound is returned.
+func (fs *FileStorage) Get(key string) ([]byte, error) {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+	item := fs.items[key]
+	if item == nil || item.expired(time.Now()) {
+		return nil, ErrNotFound
+	}
+	return item.Value, nil
+}
+
+//Set stores the value for the given key and writes the file. If ttl is not zero, the value expires after ttl.
+func (fs *FileStorage) Set(key string, value []byte, ttl time.Duration) error {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+	fs.items[key] = createItem(value, ttl)
+	return fs.write()
+}
+
+//Delete removes the given key and writes the file.
+func (fs *FileStorage) Delete(key string) error {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+	if fs.items[key] == nil {
+		return nil
+	}
+	delete(fs.items, key)
+	return fs.write()
+}
+
+/*write writes all the values that are not expired into a temporary file and then replaces the storage file with it, so the file is never left half written.*/
+func (fs *FileStorage) write() error {
+	now := time.Now()
+	for key, item := range fs.items {
+		if item.expired(now) {
+			delete(fs.items, key)
+		}
+	}
+	data, err := json.Marshal(fs.items)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(fs.path), filepath.Base(fs.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err2 := tmp.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), fs.path)
+}
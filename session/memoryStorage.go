@@ -0,0 +1,80 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+//MemoryStorage is a storage that keeps the values in memory. Values are lost when the program exits.
+type MemoryStorage struct {
+	items map[string]*storageItem
+	lock  sync.Mutex
+	sets  int
+}
+
+//storageItem is a stored value with its expiration time. Zero expiration time means the value never expires.
+type storageItem struct {
+	Value   []byte    `json:"value"`
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+//NewMemoryStorage creates a new in-memory storage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{items: make(map[string]*storageItem)}
+}
+
+//Get returns the value stored for the given key. If the key does not exist or has been expired ErrNotFound is returned.
+func (ms *MemoryStorage) Get(key string) ([]byte, error) {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+	item := ms.items[key]
+	if item == nil {
+		return nil, ErrNotFound
+	}
+	if item.expired(time.Now()) {
+		delete(ms.items, key)
+		return nil, ErrNotFound
+	}
+	return item.Value, nil
+}
+
+//Set stores the value for the given key. If ttl is not zero, the value expires after ttl.
+func (ms *MemoryStorage) Set(key string, value []byte, ttl time.Duration) error {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+	ms.items[key] = createItem(value, ttl)
+	ms.sets++
+	if ms.sets%1000 == 0 {
+		ms.removeExpired()
+	}
+	return nil
+}
+
+//Delete removes the given key.
+func (ms *MemoryStorage) Delete(key string) error {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+	delete(ms.items, key)
+	return nil
+}
+
+func (ms *MemoryStorage) removeExpired() {
+	now := time.Now()
+	for key, item := range ms.items {
+		if item.expired(now) {
+			delete(ms.items, key)
+		}
+	}
+}
+
+func createItem(value []byte, ttl time.Duration) *storageItem {
+	item := &storageItem{Value: value}
+	if ttl > 0 {
+		item.Expires = time.Now().Add(ttl)
+	}
+	return item
+}
+
+func (item *storageItem) expired(now time.Time) bool {
+	return !item.Expires.IsZero() && !now.Before(item.Expires)
+}
@@ -0,0 +1,201 @@
+package session
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*RESPStorage is a storage that keeps the values in a key-value server that speaks the redis serialization protocol (RESP), like redis, keydb or dragonfly. Only the GET, SET, DEL, AUTH and SELECT commands are used.
+
+RESPStorage uses a single connection which is opened on first use and reopened if it breaks.*/
+type RESPStorage struct {
+	address  string
+	password string
+	db       int
+	timeout  time.Duration
+	conn     net.Conn
+	reader   *bufio.Reader
+	lock     sync.Mutex
+}
+
+//respError is an error reply returned by the server.
+type respError string
+
+func (e respError) Error() string {
+	return "session : server returned an error : " + string(e)
+}
+
+/*NewRESPStorage creates a storage that connects to the server at the given address (host:port). If password is not empty, the connection is authenticated with it and if db is not zero, the given database is selected.*/
+func NewRESPStorage(address, password string, db int) *RESPStorage {
+	return &RESPStorage{address: address, password: password, db: db, timeout: 5 * time.Second}
+}
+
+//Get returns the value stored for the given key. If the key does not exist ErrNotFound is returned.
+func (rs *RESPStorage) Get(key string) ([]byte, error) {
+	res, err := rs.do("GET", key)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ErrNotFound
+	}
+	bts, ok := res.([]byte)
+	if !ok {
+		return nil, errors.New("session : unexpected reply for GET")
+	}
+	return bts, nil
+}
+
+//Set stores the value for the given key. If ttl is not zero, the value expires after ttl.
+func (rs *RESPStorage) Set(key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		ms := ttl.Milliseconds()
+		if ms == 0 {
+			ms = 1
+		}
+		args = append(args, "PX", strconv.FormatInt(ms, 10))
+	}
+	_, err := rs.do(args...)
+	return err
+}
+
+//Delete removes the given key.
+func (rs *RESPStorage) Delete(key string) error {
+	_, err := rs.do("DEL", key)
+	return err
+}
+
+//Close closes the connection to the server.
+func (rs *RESPStorage) Close() error {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	if rs.conn == nil {
+		return nil
+	}
+	err := rs.conn.Close()
+	rs.conn = nil
+	return err
+}
+
+/*do sends the given command to the server and returns the reply. If the connection is broken, it's reopened and the command is sent once more.*/
+func (rs *RESPStorage) do(args ...string) (interface{}, error) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	var res interface{}
+	var err error
+	for try := 0; try < 2; try++ {
+		if rs.conn == nil {
+			err = rs.connect()
+			if err != nil {
+				return nil, err
+			}
+		}
+		res, err = rs.command(args...)
+		if _, ok := err.(respError); err == nil || ok {
+			return res, err
+		}
+		rs.conn.Close()
+		rs.conn = nil
+	}
+	return nil, err
+}
+
+func (rs *RESPStorage) connect() error {
+	conn, err := net.DialTimeout("tcp", rs.address, rs.timeout)
+	if err != nil {
+		return err
+	}
+	rs.conn = conn
+	rs.reader = bufio.NewReader(conn)
+	if rs.password != "" {
+		_, err = rs.command("AUTH", rs.password)
+	}
+	if err == nil && rs.db != 0 {
+		_, err = rs.command("SELECT", strconv.Itoa(rs.db))
+	}
+	if err != nil {
+		conn.Close()
+		rs.conn = nil
+	}
+	return err
+}
+
+func (rs *RESPStorage) command(args ...string) (interface{}, error) {
+	err := rs.conn.SetDeadline(time.Now().Add(rs.timeout))
+	if err != nil {
+		return nil, err
+	}
+	_, err = rs.conn.Write(encodeCommand(args))
+	if err != nil {
+		return nil, err
+	}
+	return readReply(rs.reader)
+}
+
+//encodeCommand encodes the given command as an array of bulk strings.
+func encodeCommand(args []string) []byte {
+	out := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		out = append(out, "$"+strconv.Itoa(len(arg))+"\r\n"...)
+		out = append(out, arg...)
+		out = append(out, "\r\n"...)
+	}
+	return out
+}
+
+/*readReply reads a reply from the reader. Simple strings are returned as string, bulk strings as []byte, integers as int64 and arrays as []interface{}. Null replies are returned as nil and error replies as respError.*/
+func readReply(rd *bufio.Reader) (interface{}, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.New("session : malformed reply")
+	}
+	body := line[1 : len(line)-2]
+	switch line[0] {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, respError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		_, err = io.ReadFull(rd, buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf[:size], nil
+	case '*':
+		size, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		out := make([]interface{}, size)
+		for i := range out {
+			out[i], err = readReply(rd)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return nil, errors.New("session : unknown reply type " + string(line[0]))
+}
@@ -0,0 +1,166 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+//ErrNotFound is returned by storages when the given key does not exist or has been expired.
+var ErrNotFound = errors.New("session : key not found")
+
+/*Storage is the interface that session storage backends implement. Values are stored as raw bytes and a ttl of zero means the value never expires.*/
+type Storage interface {
+	//Get returns the value stored for the given key. If the key does not exist ErrNotFound is returned.
+	Get(key string) ([]byte, error)
+	//Set stores the value for the given key.
+	Set(key string, value []byte, ttl time.Duration) error
+	//Delete removes the given key. Deleting a key that does not exist is not an error.
+	Delete(key string) error
+}
+
+/*Manager loads and saves the sessions from/into a storage.*/
+type Manager struct {
+	storage Storage
+	ttl     time.Duration
+}
+
+/*Session holds the data of a user in a chat between the updates. Values are stored in json format and every change is written to the storage immediately, so sessions survive restarts if a persistent storage is used.
+
+Session is safe for concurrent use, but two sessions loaded separately for the same key don't see each other's changes until they are loaded again.*/
+type Session struct {
+	key     string
+	manager *Manager
+	data    map[string]json.RawMessage
+	lock    sync.Mutex
+}
+
+/*NewManager creates a session manager that stores the sessions in the given storage. Each session expires after "ttl" of its last change. Pass 0 for sessions that never expire.*/
+func NewManager(storage Storage, ttl time.Duration) *Manager {
+	return &Manager{storage: storage, ttl: ttl}
+}
+
+/*Load loads the session of the given user in the given chat. If no session exists, an empty session is returned. Pass 0 for chatId or userId if the update does not have a chat or a sender.*/
+func (m *Manager) Load(chatId, userId int) (*Session, error) {
+	return m.LoadKey(CreateKey(chatId, userId))
+}
+
+/*LoadKey loads the session with the given key. If no session exists, an empty session is returned.*/
+func (m *Manager) LoadKey(key string) (*Session, error) {
+	ss := &Session{key: key, manager: m, data: make(map[string]json.RawMessage)}
+	bts, err := m.storage.Get(key)
+	if err != nil {
+		if err == ErrNotFound {
+			return ss, nil
+		}
+		return nil, err
+	}
+	err = json.Unmarshal(bts, &ss.data)
+	if err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+//CreateKey creates the storage key of the session of the given user in the given chat.
+func CreateKey(chatId, userId int) string {
+	return "telego:session:" + strconv.Itoa(chatId) + ":" + strconv.Itoa(userId)
+}
+
+//Key returns the storage key of this session.
+func (ss *Session) Key() string {
+	return ss.key
+}
+
+/*Get decodes the value stored for the given name into "value" (which should be a pointer). Returns false if no value is stored for the name.*/
+func (ss *Session) Get(name string, value interface{}) (bool, error) {
+	ss.lock.Lock()
+	raw, ok := ss.data[name]
+	ss.lock.Unlock()
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, value)
+}
+
+//GetString returns the string stored for the given name. Returns an empty string if no string is stored for the name.
+func (ss *Session) GetString(name string) string {
+	var out string
+	_, _ = ss.Get(name, &out)
+	return out
+}
+
+//GetInt returns the int stored for the given name. Returns 0 if no int is stored for the name.
+func (ss *Session) GetInt(name string) int {
+	var out int
+	_, _ = ss.Get(name, &out)
+	return out
+}
+
+//Has returns true if a value is stored for the given name.
+func (ss *Session) Has(name string) bool {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	_, ok := ss.data[name]
+	return ok
+}
+
+/*Set stores the given value for the given name and saves the session. Value should be serializable to json. If the session can't be saved, the value is not changed.*/
+func (ss *Session) Set(name string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	old, existed := ss.data[name]
+	ss.data[name] = raw
+	err = ss.save()
+	if err != nil {
+		ss.restore(name, old, existed)
+	}
+	return err
+}
+
+//Delete removes the value stored for the given name and saves the session. If the session can't be saved, the value is not removed.
+func (ss *Session) Delete(name string) error {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	old, existed := ss.data[name]
+	delete(ss.data, name)
+	err := ss.save()
+	if err != nil {
+		ss.restore(name, old, existed)
+	}
+	return err
+}
+
+//Clear removes all the values of the session and deletes it from the storage. If the session can't be deleted from the storage, the values are kept.
+func (ss *Session) Clear() error {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	if err := ss.manager.storage.Delete(ss.key); err != nil {
+		return err
+	}
+	ss.data = make(map[string]json.RawMessage)
+	return nil
+}
+
+//restore sets the value of the given name back to what it was before a failed save.
+func (ss *Session) restore(name string, old json.RawMessage, existed bool) {
+	if existed {
+		ss.data[name] = old
+	} else {
+		delete(ss.data, name)
+	}
+}
+
+func (ss *Session) save() error {
+	bts, err := json.Marshal(ss.data)
+	if err != nil {
+		return err
+	}
+	return ss.manager.storage.Set(ss.key, bts, ss.manager.ttl)
+}
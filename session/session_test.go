@@ -0,0 +1,185 @@
+package session
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryStorageTTL(t *testing.T) {
+	ms := NewMemoryStorage()
+	_ = ms.Set("a", []byte("1"), 20*time.Millisecond)
+	_ = ms.Set("b", []byte("2"), 0)
+	if val, err := ms.Get("a"); err != nil || string(val) != "1" {
+		t.Fatal("wrong value :", string(val), err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := ms.Get("a"); err != ErrNotFound {
+		t.Error("value was not expired :", err)
+	}
+	if _, err := ms.Get("b"); err != nil {
+		t.Error("value without ttl was expired :", err)
+	}
+}
+
+//failingStorage is a memory storage whose writes fail when "fail" is true.
+type failingStorage struct {
+	*MemoryStorage
+	fail bool
+}
+
+func (fs *failingStorage) Set(key string, value []byte, ttl time.Duration) error {
+	if fs.fail {
+		return errors.New("storage is not available")
+	}
+	return fs.MemoryStorage.Set(key, value, ttl)
+}
+
+func (fs *failingStorage) Delete(key string) error {
+	if fs.fail {
+		return errors.New("storage is not available")
+	}
+	return fs.MemoryStorage.Delete(key)
+}
+
+func TestSessionFailedSave(t *testing.T) {
+	storage := &failingStorage{MemoryStorage: NewMemoryStorage()}
+	ss, err := NewManager(storage, 0).Load(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ss.Set("name", "John"); err != nil {
+		t.Fatal(err)
+	}
+	storage.fail = true
+	if ss.Set("name", "Jane") == nil || ss.Set("age", 25) == nil || ss.Delete("name") == nil || ss.Clear() == nil {
+		t.Fatal("failed saves did not return an error")
+	}
+	if ss.GetString("name") != "John" || ss.Has("age") {
+		t.Error("session was changed although it was not saved :", ss.GetString("name"), ss.Has("age"))
+	}
+}
+
+func TestSessionFileStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	fs, err := NewFileStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, err := NewManager(fs, 0).Load(10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = ss.Set("name", "John")
+	_ = ss.Set("age", 25)
+	fs2, err := NewFileStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss2, err := NewManager(fs2, 0).Load(10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ss2.GetString("name") != "John" || ss2.GetInt("age") != 25 {
+		t.Error("session was not loaded from the file :", ss2.GetString("name"), ss2.GetInt("age"))
+	}
+	_ = ss2.Clear()
+	if ss3, _ := NewManager(fs2, 0).Load(10, 1); ss3.Has("name") {
+		t.Error("session was not cleared")
+	}
+}
+
+func TestRESPStorage(t *testing.T) {
+	addr := startRESPStandIn(t)
+	rs := NewRESPStorage(addr, "secret", 2)
+	defer rs.Close()
+	if _, err := rs.Get("a"); err != ErrNotFound {
+		t.Fatal("expected ErrNotFound, got :", err)
+	}
+	if err := rs.Set("a", []byte("hello\r\nworld"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := rs.Get("a"); err != nil || string(val) != "hello\r\nworld" {
+		t.Fatal("wrong value :", string(val), err)
+	}
+	//The connection should be reopened when it breaks.
+	rs.conn.Close()
+	if err := rs.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rs.Get("a"); err != ErrNotFound {
+		t.Error("value was not deleted :", err)
+	}
+	if _, err := NewRESPStorage(addr, "wrong", 0).Get("a"); err == nil {
+		t.Error("authentication with wrong password succeeded")
+	}
+}
+
+//startRESPStandIn starts a tiny server that implements the commands used by RESPStorage.
+func startRESPStandIn(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	data := make(map[string]string)
+	var lock sync.Mutex
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				rd := bufio.NewReader(conn)
+				authed := false
+				for {
+					res, err := readReply(rd)
+					if err != nil {
+						return
+					}
+					args := res.([]interface{})
+					cmd := strings.ToUpper(string(args[0].([]byte)))
+					lock.Lock()
+					var out string
+					switch {
+					case cmd == "AUTH":
+						authed = string(args[1].([]byte)) == "secret"
+						out = "+OK\r\n"
+						if !authed {
+							out = "-WRONGPASS invalid password\r\n"
+						}
+					case !authed:
+						out = "-NOAUTH Authentication required.\r\n"
+					case cmd == "SELECT":
+						out = "+OK\r\n"
+					case cmd == "SET":
+						data[string(args[1].([]byte))] = string(args[2].([]byte))
+						out = "+OK\r\n"
+					case cmd == "GET":
+						val, ok := data[string(args[1].([]byte))]
+						out = "$-1\r\n"
+						if ok {
+							out = "$" + strconv.Itoa(len(val)) + "\r\n" + val + "\r\n"
+						}
+					case cmd == "DEL":
+						delete(data, string(args[1].([]byte)))
+						out = ":1\r\n"
+					default:
+						out = "-ERR unknown command\r\n"
+					}
+					lock.Unlock()
+					conn.Write([]byte(out))
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
@@ -1,6 +1,7 @@
 package telego
 
 import (
+	"context"
 	"errors"
 	"os"
 
@@ -16,9 +17,9 @@ type StickerSet struct {
 }
 
 /*update updates this sticker set*/
-func (ss *StickerSet) update() {
+func (ss *StickerSet) update(ctx context.Context) {
 	if ss != nil {
-		res, err := ss.bot.apiInterface.GetStickerSet(ss.stickerSet.Name)
+		res, err := ss.bot.apiInterface.GetStickerSetContext(ctx, ss.stickerSet.Name)
 		if err != nil {
 			logger.Logger.Println("Error while updating sticker set.", err.Error())
 		} else {
@@ -45,19 +46,29 @@ func (ss *StickerSet) GetName() string {
 
 /*GetStickers returns the sticker in this sticker set.*/
 func (ss *StickerSet) GetStickers() []objs.Sticker {
+	return ss.GetStickersContext(context.Background())
+}
+
+/*GetStickersContext works like "GetStickers" but sends the request with the given context.*/
+func (ss *StickerSet) GetStickersContext(ctx context.Context) []objs.Sticker {
 	if ss == nil {
 		return nil
 	}
-	ss.update()
+	ss.update(ctx)
 	return ss.stickerSet.Stickers
 }
 
 /*GetThumb returns the thumbnail of this sticker set*/
 func (ss *StickerSet) GetThumb() *objs.PhotoSize {
+	return ss.GetThumbContext(context.Background())
+}
+
+/*GetThumbContext works like "GetThumb" but sends the request with the given context.*/
+func (ss *StickerSet) GetThumbContext(ctx context.Context) *objs.PhotoSize {
 	if ss == nil {
 		return nil
 	}
-	ss.update()
+	ss.update(ctx)
 	return ss.stickerSet.Thumb
 }
 
@@ -67,6 +78,13 @@ Adds a sticker to the current set
 Use this method to add a new sticker to a set created by the bot. You must use exactly one of the fields png_sticker or tgs_sticker. Animated stickers can be added to animated sticker sets and only to them. Animated sticker sets can have up to 50 stickers. Static sticker sets can have up to 120 stickers. Returns True on success.
 png sticker can be passed as an file id or url (pngStickerFileIdOrUrl) or file(pngStickerFile).*/
 func (ss *StickerSet) AddSticker(pngStickerFileIdOrUrl string, pngStickerFile *os.File, tgsSticker *os.File, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
+	return ss.AddStickerContext(context.Background(), pngStickerFileIdOrUrl, pngStickerFile, tgsSticker, emojies, maskPosition)
+}
+
+/*AddStickerContext works like "AddSticker" but sends the request with the given context.
+
+Deprecated: Use "AddPngStickerContext","AddAnimatedStickerContext" or "AddVideoStickerContext" methods instead.*/
+func (ss *StickerSet) AddStickerContext(ctx context.Context, pngStickerFileIdOrUrl string, pngStickerFile *os.File, tgsSticker *os.File, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
 	if ss == nil {
 		return nil, errors.New("sticker set is nil")
 	}
@@ -75,7 +93,7 @@ func (ss *StickerSet) AddSticker(pngStickerFileIdOrUrl string, pngStickerFile *o
 			if pngStickerFileIdOrUrl == "" {
 				return nil, errors.New("wrong file id or url")
 			}
-			return ss.bot.apiInterface.AddStickerToSet(
+			return ss.bot.apiInterface.AddStickerToSetContext(ctx,
 				ss.userId, ss.stickerSet.Name, pngStickerFileIdOrUrl, "", "", emojies, maskPosition, nil,
 			)
 		} else {
@@ -83,7 +101,7 @@ func (ss *StickerSet) AddSticker(pngStickerFileIdOrUrl string, pngStickerFile *o
 			if er != nil {
 				return nil, er
 			}
-			return ss.bot.apiInterface.AddStickerToSet(
+			return ss.bot.apiInterface.AddStickerToSetContext(ctx,
 				ss.userId, ss.stickerSet.Name, "attach://"+stat.Name(), "", "", emojies, maskPosition, pngStickerFile,
 			)
 		}
@@ -92,7 +110,7 @@ func (ss *StickerSet) AddSticker(pngStickerFileIdOrUrl string, pngStickerFile *o
 		if er != nil {
 			return nil, er
 		}
-		return ss.bot.apiInterface.AddStickerToSet(
+		return ss.bot.apiInterface.AddStickerToSetContext(ctx,
 			ss.userId, ss.stickerSet.Name, "", "attach://"+stat.Name(), "", emojies, maskPosition, tgsSticker,
 		)
 	}
@@ -100,13 +118,23 @@ func (ss *StickerSet) AddSticker(pngStickerFileIdOrUrl string, pngStickerFile *o
 
 //AddPngSticker adds a new PNG picture to the sticker set. This method should be used when the PNG file in stored in telegram servers or it's an HTTP URL. If the file is stored in your computer, use "AddPngStickerByFile" method.
 func (ss *StickerSet) AddPngSticker(pngPicFileIdOrUrl, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
-	return ss.bot.apiInterface.AddStickerToSet(
+	return ss.AddPngStickerContext(context.Background(), pngPicFileIdOrUrl, emojies, maskPosition)
+}
+
+/*AddPngStickerContext works like "AddPngSticker" but sends the request with the given context.*/
+func (ss *StickerSet) AddPngStickerContext(ctx context.Context, pngPicFileIdOrUrl, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
+	return ss.bot.apiInterface.AddStickerToSetContext(ctx,
 		ss.userId, ss.stickerSet.Name, pngPicFileIdOrUrl, "", "", emojies, maskPosition, nil,
 	)
 }
 
 //AddPngStickerByFile adds a new PNG picture to the sticker set. This method should be used when the PNG file in stored in your computer.
 func (ss *StickerSet) AddPngStickerByFile(pngPicFile *os.File, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
+	return ss.AddPngStickerByFileContext(context.Background(), pngPicFile, emojies, maskPosition)
+}
+
+/*AddPngStickerByFileContext works like "AddPngStickerByFile" but sends the request with the given context.*/
+func (ss *StickerSet) AddPngStickerByFileContext(ctx context.Context, pngPicFile *os.File, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
 	if pngPicFile == nil {
 		return nil, errors.New("pngPicFile cannot be nil")
 	}
@@ -114,13 +142,18 @@ func (ss *StickerSet) AddPngStickerByFile(pngPicFile *os.File, emojies string, m
 	if er != nil {
 		return nil, er
 	}
-	return ss.bot.apiInterface.AddStickerToSet(
+	return ss.bot.apiInterface.AddStickerToSetContext(ctx,
 		ss.userId, ss.stickerSet.Name, "attach://"+stat.Name(), "", "", emojies, maskPosition, pngPicFile,
 	)
 }
 
 //AddAnimatedSticker adds a new TGS sticker (animated sticker) to the sticker set.
 func (ss *StickerSet) AddAnimatedSticker(tgsFile *os.File, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
+	return ss.AddAnimatedStickerContext(context.Background(), tgsFile, emojies, maskPosition)
+}
+
+/*AddAnimatedStickerContext works like "AddAnimatedSticker" but sends the request with the given context.*/
+func (ss *StickerSet) AddAnimatedStickerContext(ctx context.Context, tgsFile *os.File, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
 	if tgsFile == nil {
 		return nil, errors.New("tgsFile cannot be nil")
 	}
@@ -128,13 +161,18 @@ func (ss *StickerSet) AddAnimatedSticker(tgsFile *os.File, emojies string, maskP
 	if er != nil {
 		return nil, er
 	}
-	return ss.bot.apiInterface.AddStickerToSet(
+	return ss.bot.apiInterface.AddStickerToSetContext(ctx,
 		ss.userId, ss.stickerSet.Name, "", "attach://"+stat.Name(), "", emojies, maskPosition, tgsFile,
 	)
 }
 
 //AddVideoSticker adds a new WEBM sticker (video sticker) to the sticker set.
 func (ss *StickerSet) AddVideoSticker(webmFile *os.File, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
+	return ss.AddVideoStickerContext(context.Background(), webmFile, emojies, maskPosition)
+}
+
+/*AddVideoStickerContext works like "AddVideoSticker" but sends the request with the given context.*/
+func (ss *StickerSet) AddVideoStickerContext(ctx context.Context, webmFile *os.File, emojies string, maskPosition *objs.MaskPosition) (*objs.LogicalResult, error) {
 	if webmFile == nil {
 		return nil, errors.New("webmFile cannot be nil")
 	}
@@ -142,7 +180,7 @@ func (ss *StickerSet) AddVideoSticker(webmFile *os.File, emojies string, maskPos
 	if er != nil {
 		return nil, er
 	}
-	return ss.bot.apiInterface.AddStickerToSet(
+	return ss.bot.apiInterface.AddStickerToSetContext(ctx,
 		ss.userId, ss.stickerSet.Name, "", "", "attach://"+stat.Name(), emojies, maskPosition, webmFile,
 	)
 }
@@ -151,32 +189,52 @@ func (ss *StickerSet) AddVideoSticker(webmFile *os.File, emojies string, maskPos
 
 "sticker" is file identifier of the sticker and "position" is new sticker position in the set, zero-based*/
 func (ss *StickerSet) SetStickerPosition(sticker string, position int) (*objs.LogicalResult, error) {
+	return ss.SetStickerPositionContext(context.Background(), sticker, position)
+}
+
+/*SetStickerPositionContext works like "SetStickerPosition" but sends the request with the given context.*/
+func (ss *StickerSet) SetStickerPositionContext(ctx context.Context, sticker string, position int) (*objs.LogicalResult, error) {
 	if ss == nil {
 		return nil, errors.New("sticker set is nil")
 	}
-	return ss.bot.apiInterface.SetStickerPositionInSet(sticker, position)
+	return ss.bot.apiInterface.SetStickerPositionInSetContext(ctx, sticker, position)
 }
 
 /*DeleteStickerFromSet can be used to delete a sticker from a set created by the bot. Returns True on success.
 
 "sticker" is file identifier of the sticker.*/
 func (ss *StickerSet) DeleteStickerFromSet(sticker string) (*objs.LogicalResult, error) {
+	return ss.DeleteStickerFromSetContext(context.Background(), sticker)
+}
+
+/*DeleteStickerFromSetContext works like "DeleteStickerFromSet" but sends the request with the given context.*/
+func (ss *StickerSet) DeleteStickerFromSetContext(ctx context.Context, sticker string) (*objs.LogicalResult, error) {
 	if ss == nil {
 		return nil, errors.New("sticker set is nil")
 	}
-	return ss.bot.apiInterface.DeleteStickerFromSet(sticker)
+	return ss.bot.apiInterface.DeleteStickerFromSetContext(ctx, sticker)
 }
 
 /*SetThumb can be used to set the thumbnail of a sticker set using url or file id. Animated thumbnails can be set for animated sticker sets only. Returns True on success.*/
 func (ss *StickerSet) SetThumb(userId int, thumb string) (*objs.LogicalResult, error) {
+	return ss.SetThumbContext(context.Background(), userId, thumb)
+}
+
+/*SetThumbContext works like "SetThumb" but sends the request with the given context.*/
+func (ss *StickerSet) SetThumbContext(ctx context.Context, userId int, thumb string) (*objs.LogicalResult, error) {
 	if ss == nil {
 		return nil, errors.New("sticker set is nil")
 	}
-	return ss.bot.apiInterface.SetStickerSetThumb(ss.stickerSet.Name, thumb, userId, nil)
+	return ss.bot.apiInterface.SetStickerSetThumbContext(ctx, ss.stickerSet.Name, thumb, userId, nil)
 }
 
 /*SetThumbByFile can be used to set the thumbnail of a sticker set using a file on the computer. Animated thumbnails can be set for animated sticker sets only. Returns True on success.*/
 func (ss *StickerSet) SetThumbByFile(userId int, thumb *os.File) (*objs.LogicalResult, error) {
+	return ss.SetThumbByFileContext(context.Background(), userId, thumb)
+}
+
+/*SetThumbByFileContext works like "SetThumbByFile" but sends the request with the given context.*/
+func (ss *StickerSet) SetThumbByFileContext(ctx context.Context, userId int, thumb *os.File) (*objs.LogicalResult, error) {
 	if ss == nil {
 		return nil, errors.New("sticker set is nil")
 	}
@@ -184,5 +242,5 @@ func (ss *StickerSet) SetThumbByFile(userId int, thumb *os.File) (*objs.LogicalR
 	if err != nil {
 		return nil, err
 	}
-	return ss.bot.apiInterface.SetStickerSetThumb(ss.stickerSet.Name, "attach://"+stats.Name(), userId, thumb)
+	return ss.bot.apiInterface.SetStickerSetThumbContext(ctx, ss.stickerSet.Name, "attach://"+stats.Name(), userId, thumb)
 }
@@ -0,0 +1,231 @@
+package tba
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	cfg "github.com/SakoDroid/telego/configs"
+	log "github.com/SakoDroid/telego/logger"
+)
+
+/*PrepareCertificate generates a new certificate for the webhook if "GenerateCertificate" field of the webhook configs is true and the stored certificate does not exist, is not valid for the host of the webhook or is about to expire. It should be called before setting the webhook so the certificate can be uploaded. Returns true if a new certificate has been generated, which should be uploaded to telegram.*/
+func (bai *BotAPIInterface) PrepareCertificate() (bool, error) {
+	whCfg := bai.botConfigs.WebHookConfigs
+	if whCfg == nil || !whCfg.GenerateCertificate {
+		return false, nil
+	}
+	_, generated, err := loadOrGenerateCertificate(whCfg)
+	return generated, err
+}
+
+/*renewCertificate generates a new certificate for the started webhook and switches to it. The new certificate is stored in temporary files and replaces the stored one only after the server has started using it and it has been uploaded to telegram. If anything fails, the old certificate is kept and the renewal is tried again an hour later.*/
+func (bai *BotAPIInterface) renewCertificate(st *webhookState) {
+	wh := bai.webhook
+	whCfg := bai.botConfigs.WebHookConfigs
+	newCert, err := createCertificate(whCfg)
+	if err == nil {
+		err = newCert.store(whCfg.CertFile+".new", whCfg.KeyFile+".new")
+	}
+	if err == nil {
+		err = bai.switchCertificate(st, newCert)
+	}
+	if err == nil {
+		err = installCertificate(whCfg)
+	}
+	os.Remove(whCfg.CertFile + ".new")
+	os.Remove(whCfg.KeyFile + ".new")
+	wh.lock.Lock()
+	defer wh.lock.Unlock()
+	if wh.running != st {
+		return
+	}
+	next := time.Hour
+	if err != nil {
+		log.Logger.Println("Webhook : Unable to renew the certificate, retrying in an hour.", err)
+	} else {
+		next = time.Until(renewalTime(newCert.cert.Leaf))
+		log.Logger.Println("Webhook : Certificate renewed. The new certificate expires on", newCert.cert.Leaf.NotAfter)
+	}
+	st.renewal = time.AfterFunc(next, func() { bai.renewCertificate(st) })
+}
+
+/*installCertificate replaces the stored certificate and key with the new ones stored in ".new" files. The old key is kept until the certificate is replaced, so if replacing the certificate fails the old key is restored and the stored files still match.*/
+func installCertificate(whCfg *cfg.WebHookConfigs) error {
+	backup := whCfg.KeyFile + ".old"
+	hasOld := true
+	if err := os.Rename(whCfg.KeyFile, backup); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		hasOld = false
+	}
+	err := os.Rename(whCfg.KeyFile+".new", whCfg.KeyFile)
+	if err == nil {
+		err = os.Rename(whCfg.CertFile+".new", whCfg.CertFile)
+	}
+	if err != nil {
+		if hasOld {
+			os.Rename(backup, whCfg.KeyFile)
+		} else {
+			os.Remove(whCfg.KeyFile)
+		}
+		return err
+	}
+	if hasOld {
+		os.Remove(backup)
+	}
+	return nil
+}
+
+/*switchCertificate makes the server of the webhook use the given certificate and uploads it to telegram right after that. If the upload fails, the server goes back to the old certificate.*/
+func (bai *BotAPIInterface) switchCertificate(st *webhookState, newCert *generatedCertificate) error {
+	wh := bai.webhook
+	wh.lock.Lock()
+	if wh.running != st {
+		wh.lock.Unlock()
+		return errors.New("webhook has been stopped")
+	}
+	srv, path := wh.server, wh.path
+	old := srv.certificate(path)
+	srv.setCertificate(path, newCert.cert)
+	wh.lock.Unlock()
+	if err := bai.uploadCertificate(bai.botConfigs.WebHookConfigs, newCert.certPem); err != nil {
+		srv.setCertificate(path, old)
+		return err
+	}
+	return nil
+}
+
+func (bai *BotAPIInterface) uploadCertificate(whCfg *cfg.WebHookConfigs, certPem []byte) error {
+	cert := &InputFile{Name: filepath.Base(whCfg.CertFile), Reader: bytes.NewReader(certPem)}
+	res, err := bai.SetWebhook(whCfg.URL, whCfg.IP, whCfg.MaxConnections, whCfg.AllowedUpdates, false, cert)
+	if err != nil {
+		return err
+	}
+	if !res.Result {
+		return errors.New("unable to set webhook. API server returned false")
+	}
+	return nil
+}
+
+//generatedCertificate is a generated certificate and its PEM encoded certificate and key.
+type generatedCertificate struct {
+	cert            *tls.Certificate
+	certPem, keyPem []byte
+}
+
+/*loadOrGenerateCertificate loads the stored certificate of the webhook. A new one is generated if it can't be used, in which case true is returned.*/
+func loadOrGenerateCertificate(whCfg *cfg.WebHookConfigs) (*tls.Certificate, bool, error) {
+	host, err := certificateHost(whCfg)
+	if err != nil {
+		return nil, false, err
+	}
+	pair, err := tls.LoadX509KeyPair(whCfg.CertFile, whCfg.KeyFile)
+	if err == nil {
+		pair.Leaf, err = x509.ParseCertificate(pair.Certificate[0])
+		if err == nil && pair.Leaf.VerifyHostname(host) == nil && time.Now().Before(renewalTime(pair.Leaf)) {
+			return &pair, false, nil
+		}
+	}
+	cert, err := generateCertificate(whCfg)
+	return cert, err == nil, err
+}
+
+//generateCertificate generates a self signed certificate for the host of the webhook and stores it in the certificate and key files.
+func generateCertificate(whCfg *cfg.WebHookConfigs) (*tls.Certificate, error) {
+	gc, err := createCertificate(whCfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = gc.store(whCfg.CertFile, whCfg.KeyFile); err != nil {
+		return nil, err
+	}
+	return gc.cert, nil
+}
+
+//store writes the certificate and the key into the given files.
+func (gc *generatedCertificate) store(certFile, keyFile string) error {
+	if err := os.WriteFile(keyFile, gc.keyPem, 0600); err != nil {
+		return err
+	}
+	return os.WriteFile(certFile, gc.certPem, 0644)
+}
+
+//createCertificate creates a self signed certificate for the host of the webhook without storing it.
+func createCertificate(whCfg *cfg.WebHookConfigs) (*generatedCertificate, error) {
+	host, err := certificateHost(whCfg)
+	if err != nil {
+		return nil, err
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(whCfg.GetCertificateValidity()),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	hostIP := net.ParseIP(host)
+	if hostIP != nil {
+		tmpl.IPAddresses = append(tmpl.IPAddresses, hostIP)
+	} else {
+		tmpl.DNSNames = append(tmpl.DNSNames, host)
+	}
+	if ip := net.ParseIP(whCfg.IP); ip != nil && !ip.Equal(hostIP) {
+		tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	pair, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		return nil, err
+	}
+	pair.Leaf, err = x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &generatedCertificate{cert: &pair, certPem: certPem, keyPem: keyPem}, nil
+}
+
+//certificateHost returns the host of the webhook URL.
+func certificateHost(whCfg *cfg.WebHookConfigs) (string, error) {
+	u, err := url.Parse(whCfg.URL)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", errors.New("webhook URL has no host : " + whCfg.URL)
+	}
+	return u.Hostname(), nil
+}
+
+//renewalTime returns the time the given certificate should be renewed, which is when a quarter of its validity is left.
+func renewalTime(cert *x509.Certificate) time.Time {
+	return cert.NotAfter.Add(-cert.NotAfter.Sub(cert.NotBefore) / 4)
+}
@@ -0,0 +1,219 @@
+package tba
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	"github.com/SakoDroid/telego/logger"
+)
+
+func TestGenerateCertificate(t *testing.T) {
+	dir := t.TempDir()
+	whCfg := &cfgs.WebHookConfigs{URL: "https://127.0.0.1:8443", IP: "10.1.2.3", GenerateCertificate: true, CertFile: filepath.Join(dir, "cert.crt"), KeyFile: filepath.Join(dir, "cert.key")}
+	cert, generated, err := loadOrGenerateCertificate(whCfg)
+	if err != nil || !generated {
+		t.Fatal("certificate was not generated", err)
+	}
+	leaf := cert.Leaf
+	if leaf.VerifyHostname("127.0.0.1") != nil || leaf.VerifyHostname("10.1.2.3") != nil {
+		t.Error("certificate is not valid for the webhook addresses :", leaf.IPAddresses)
+	}
+	if leaf.NotAfter.Before(time.Now().Add(cfgs.DefaultCertificateValidity - time.Hour)) {
+		t.Error("wrong expiry time :", leaf.NotAfter)
+	}
+	cert, generated, err = loadOrGenerateCertificate(whCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if generated || cert.Leaf.SerialNumber.Cmp(leaf.SerialNumber) != 0 {
+		t.Error("stored certificate was not reused")
+	}
+	whCfg.URL = "https://example.com"
+	if cert, _, err = loadOrGenerateCertificate(whCfg); err != nil {
+		t.Fatal(err)
+	}
+	if cert.Leaf.SerialNumber.Cmp(leaf.SerialNumber) == 0 || cert.Leaf.VerifyHostname("example.com") != nil {
+		t.Error("certificate was not generated for the new host")
+	}
+	whCfg.CertificateValidity = time.Minute
+	if cert, err = generateCertificate(whCfg); err != nil {
+		t.Fatal(err)
+	}
+	leaf = cert.Leaf
+	if cert, _, err = loadOrGenerateCertificate(whCfg); err != nil {
+		t.Fatal(err)
+	}
+	if cert.Leaf.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+		t.Error("certificate that is about to expire was not renewed")
+	}
+}
+
+func TestWebhookWithGeneratedCertificate(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	dir := t.TempDir()
+	cfg := cfgs.Default("generated")
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://127.0.0.1:8443", ListenAddress: "127.0.0.1:0", GenerateCertificate: true, CertFile: filepath.Join(dir, "cert.crt"), KeyFile: filepath.Join(dir, "cert.key")}
+	if !cfg.Check() {
+		t.Fatal("config check failed")
+	}
+	bai, err := CreateInterface(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if generated, err := bai.PrepareCertificate(); err != nil || !generated {
+		t.Fatal("certificate was not generated", err)
+	}
+	stored, err := tls.LoadX509KeyPair(cfg.WebHookConfigs.CertFile, cfg.WebHookConfigs.KeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bai.StartWebHook(); err != nil {
+		t.Fatal(err)
+	}
+	serversLock.Lock()
+	srv := servers["127.0.0.1:0"]
+	serversLock.Unlock()
+	served, err := srv.getCertificate(&tls.ClientHelloInfo{ServerName: "127.0.0.1", Conn: &net.TCPConn{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(served.Certificate[0]) != string(stored.Certificate[0]) {
+		t.Error("server is not using the stored certificate")
+	}
+	bai.webhook.lock.RLock()
+	renewal := bai.webhook.running.renewal
+	bai.webhook.lock.RUnlock()
+	if renewal == nil {
+		t.Error("certificate renewal was not scheduled")
+	}
+	if err = bai.StopWebHook(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.certs) != 0 {
+		t.Error("certificate was not removed from the server")
+	}
+}
+
+func TestRenewCertificate(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	var fail int32
+	var uploads int32
+	api := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&uploads, 1)
+		if err := req.ParseMultipartForm(1 << 20); err != nil || req.FormValue("drop_pending_updates") == "true" || len(req.MultipartForm.File) != 1 {
+			t.Error("wrong certificate upload", err)
+		}
+		if atomic.LoadInt32(&fail) == 1 {
+			wr.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: bad webhook"}`))
+			return
+		}
+		wr.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer api.Close()
+	dir := t.TempDir()
+	cfg := cfgs.Default("renewal")
+	cfg.BotAPI = api.URL + "/bot"
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://127.0.0.1:8443", ListenAddress: "127.0.0.1:0", GenerateCertificate: true, DropPendingUpdates: true, CertFile: filepath.Join(dir, "cert.crt"), KeyFile: filepath.Join(dir, "cert.key")}
+	bai, err := CreateInterface(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bai.StartWebHook(); err != nil {
+		t.Fatal(err)
+	}
+	defer bai.StopWebHook(context.Background())
+	wh := bai.webhook
+	wh.lock.RLock()
+	st, srv, path := wh.running, wh.server, wh.path
+	wh.lock.RUnlock()
+	stored, err := os.ReadFile(cfg.WebHookConfigs.CertFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := srv.certificate(path)
+
+	//A failed upload keeps the old certificate.
+	atomic.StoreInt32(&fail, 1)
+	bai.renewCertificate(st)
+	if current, _ := os.ReadFile(cfg.WebHookConfigs.CertFile); string(current) != string(stored) {
+		t.Error("stored certificate was replaced although the upload failed")
+	}
+	if srv.certificate(path) != old {
+		t.Error("server is not using the old certificate after the failed upload")
+	}
+	if _, err = os.Stat(cfg.WebHookConfigs.CertFile + ".new"); !os.IsNotExist(err) {
+		t.Error("temporary certificate file was not removed", err)
+	}
+
+	atomic.StoreInt32(&fail, 0)
+	bai.renewCertificate(st)
+	renewed, err := tls.LoadX509KeyPair(cfg.WebHookConfigs.CertFile, cfg.WebHookConfigs.KeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := srv.certificate(path)
+	if served == old || string(served.Certificate[0]) != string(renewed.Certificate[0]) {
+		t.Error("server is not using the renewed certificate")
+	}
+	if atomic.LoadInt32(&uploads) != 2 {
+		t.Error("wrong number of uploads :", uploads)
+	}
+}
+
+func TestInstallCertificateRollback(t *testing.T) {
+	dir := t.TempDir()
+	whCfg := &cfgs.WebHookConfigs{CertFile: filepath.Join(dir, "cert.crt"), KeyFile: filepath.Join(dir, "cert.key")}
+	files := map[string]string{whCfg.KeyFile: "old key", whCfg.KeyFile + ".new": "new key", whCfg.CertFile + ".new": "new cert"}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	//The certificate can't be replaced since a non empty directory is in its place.
+	if err := os.MkdirAll(filepath.Join(whCfg.CertFile, "dir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := installCertificate(whCfg); err == nil {
+		t.Fatal("certificate was installed")
+	}
+	if key, _ := os.ReadFile(whCfg.KeyFile); string(key) != "old key" {
+		t.Error("old key was not restored :", string(key))
+	}
+	if _, err := os.Stat(whCfg.KeyFile + ".old"); !os.IsNotExist(err) {
+		t.Error("backup of the key was not removed", err)
+	}
+	if err := os.RemoveAll(whCfg.CertFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(whCfg.KeyFile+".new", []byte("new key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := installCertificate(whCfg); err != nil {
+		t.Fatal(err)
+	}
+	key, _ := os.ReadFile(whCfg.KeyFile)
+	cert, _ := os.ReadFile(whCfg.CertFile)
+	if string(key) != "new key" || string(cert) != "new cert" {
+		t.Error("new certificate was not installed :", string(key), string(cert))
+	}
+	if _, err := os.Stat(whCfg.KeyFile + ".old"); !os.IsNotExist(err) {
+		t.Error("backup of the key was not removed", err)
+	}
+}
@@ -0,0 +1,101 @@
+package tba
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	errs "github.com/SakoDroid/telego/errors"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+/*FileURL returns the URL of the file with the given path (the "FilePath" field of the File object) on the bot API server.*/
+func (bai *BotAPIInterface) FileURL(filePath string) string {
+	base := strings.TrimSuffix(bai.botConfigs.BotAPI, "bot")
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	return base + "file/bot" + bai.botConfigs.APIKey + "/" + strings.TrimPrefix(filePath, "/")
+}
+
+/*OpenFile opens the given file (returned by "GetFile" method) for reading from the given offset. If the path of the file is absolute, which is the case for the local bot API servers running with "--local" option, the file is read from the disk. Otherwise it's streamed from the bot API server and the offset is requested with a Range header.
+
+The returned reader must be closed. The second returned value is the offset the reader starts from, which is zero if the server does not support Range requests. If "MaxDownloadSize" field of the bot configs is set, errs.FileTooLarge is returned for the larger files (by the reader if the size of the file is not known beforehand).*/
+func (bai *BotAPIInterface) OpenFile(ctx context.Context, fileObject *objs.File, offset int64) (io.ReadCloser, int64, error) {
+	limit := bai.botConfigs.GetMaxDownloadSize()
+	if limit > 0 && fileObject.FileSize > limit {
+		return nil, 0, &errs.FileTooLarge{FileId: fileObject.FileId, Size: fileObject.FileSize, Limit: limit}
+	}
+	var body io.ReadCloser
+	start := offset
+	if filepath.IsAbs(fileObject.FilePath) {
+		fl, err := os.Open(fileObject.FilePath)
+		if err != nil {
+			return nil, 0, err
+		}
+		if _, err = fl.Seek(offset, io.SeekStart); err != nil {
+			fl.Close()
+			return nil, 0, err
+		}
+		body = fl
+	} else {
+		req, err := http.NewRequestWithContext(ctx, "GET", bai.FileURL(fileObject.FilePath), nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		if offset > 0 {
+			req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+		}
+		res, err := bai.client.Do(req)
+		if err != nil {
+			return nil, 0, &errs.MethodNotSentError{Method: "getFile", Reason: err.Error(), Err: err}
+		}
+		switch {
+		case res.StatusCode == http.StatusPartialContent:
+		case res.StatusCode == http.StatusRequestedRangeNotSatisfiable && fileObject.FileSize > 0 && offset >= fileObject.FileSize:
+			//The file has been downloaded completely.
+			res.Body.Close()
+			return io.NopCloser(strings.NewReader("")), offset, nil
+		case res.StatusCode < 300:
+			start = 0
+		default:
+			res.Body.Close()
+			return nil, 0, &errs.MethodNotSentError{Method: "getFile", Reason: "server returned status code " + strconv.Itoa(res.StatusCode), StatusCode: res.StatusCode}
+		}
+		body = res.Body
+	}
+	if limit > 0 {
+		if start > limit {
+			body.Close()
+			return nil, 0, &errs.FileTooLarge{FileId: fileObject.FileId, Size: fileObject.FileSize, Limit: limit}
+		}
+		body = &limitedBody{ReadCloser: body, fileId: fileObject.FileId, remaining: limit - start, limit: limit}
+	}
+	return body, start, nil
+}
+
+//limitedBody returns errs.FileTooLarge if more than the remaining bytes are read from it.
+type limitedBody struct {
+	io.ReadCloser
+	fileId           string
+	remaining, limit int64
+}
+
+func (lb *limitedBody) Read(p []byte) (int, error) {
+	//One more byte than the remaining is read to know if the file is larger.
+	if int64(len(p)) > lb.remaining+1 {
+		p = p[:lb.remaining+1]
+	}
+	n, err := lb.ReadCloser.Read(p)
+	if int64(n) > lb.remaining {
+		n = int(lb.remaining)
+		lb.remaining = 0
+		return n, &errs.FileTooLarge{FileId: lb.fileId, Limit: lb.limit}
+	}
+	lb.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,92 @@
+package tba
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errs "github.com/SakoDroid/telego/errors"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestOpenFile(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	var ranges []string
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/file/bottoken/documents/file.txt" {
+			http.NotFound(wr, req)
+			return
+		}
+		ranges = append(ranges, req.Header.Get("Range"))
+		http.ServeContent(wr, req, "file.txt", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	file := &objs.File{FileId: "id", FilePath: "documents/file.txt", FileSize: int64(len(content))}
+	if url := bai.FileURL(file.FilePath); url != srv.URL+"/file/bottoken/documents/file.txt" {
+		t.Fatal("wrong file URL", url)
+	}
+
+	read := func(offset int64) ([]byte, int64, error) {
+		body, start, err := bai.OpenFile(context.Background(), file, offset)
+		if err != nil {
+			return nil, start, err
+		}
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		return data, start, err
+	}
+	data, start, err := read(0)
+	if err != nil || start != 0 || !bytes.Equal(data, content) {
+		t.Fatal("wrong content", start, err)
+	}
+	data, start, err = read(600)
+	if err != nil || start != 600 || !bytes.Equal(data, content[600:]) {
+		t.Fatal("wrong content of the range", start, err)
+	}
+	data, start, err = read(int64(len(content)))
+	if err != nil || start != int64(len(content)) || len(data) != 0 {
+		t.Fatal("completed file should be empty", start, err)
+	}
+	if len(ranges) != 3 || ranges[0] != "" || ranges[1] != "bytes=600-" {
+		t.Fatal("wrong Range headers", ranges)
+	}
+
+	//Size limit
+	bai.botConfigs.MaxDownloadSize = 500
+	var tooLarge *errs.FileTooLarge
+	if _, _, err = read(0); !errors.As(err, &tooLarge) || tooLarge.Size != file.FileSize {
+		t.Fatal("expected FileTooLarge error", err)
+	}
+	if len(ranges) != 3 {
+		t.Fatal("file larger than the limit should not be requested")
+	}
+	file.FileSize = 0
+	if data, _, err = read(0); !errors.As(err, &tooLarge) || len(data) != 500 {
+		t.Fatal("expected FileTooLarge error while reading", len(data), err)
+	}
+}
+
+func TestOpenLocalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "photo.jpg")
+	if err := os.WriteFile(path, []byte("local content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bai := createTestInterface("http://127.0.0.1:1", nil)
+	body, start, err := bai.OpenFile(context.Background(), &objs.File{FileId: "id", FilePath: path}, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil || start != 6 || string(data) != "content" {
+		t.Fatal("wrong content of the local file", string(data), start, err)
+	}
+}
@@ -2,96 +2,147 @@ package tba
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
 	"net/textproto"
-	"os"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	mp "mime/multipart"
 
+	cfgs "github.com/SakoDroid/telego/configs"
 	errs "github.com/SakoDroid/telego/errors"
 	objs "github.com/SakoDroid/telego/objects"
 )
 
+//maxErrorBodyLength is the maximum number of bytes of an unknown error response added to the error.
+const maxErrorBodyLength = 512
+
 /*Client used for sending http requests to bot api*/
 type httpSenderClient struct {
 	botApi, apiKey string
+	client         *http.Client
+	timeout        time.Duration //Zero means no limit.
+}
+
+/*newHTTPClient creates the HTTP client of a bot based on the given HTTP configs. The client is shared by all the requests of the bot so the connections are reused.*/
+func newHTTPClient(hc *cfgs.HTTPConfigs) (*http.Client, error) {
+	if hc == nil {
+		hc = cfgs.DefaultHTTPConfigs()
+	}
+	if hc.Client != nil {
+		return hc.Client, nil
+	}
+	if hc.Transport != nil {
+		return &http.Client{Transport: hc.Transport}, nil
+	}
+	dialer := &net.Dialer{Timeout: hc.DialTimeout, KeepAlive: 30 * time.Second}
+	if dialer.Timeout == 0 {
+		dialer.Timeout = cfgs.DefaultDialTimeout
+	}
+	tr := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   hc.TLSHandshakeTimeout,
+		IdleConnTimeout:       hc.IdleConnTimeout,
+		MaxIdleConns:          hc.MaxIdleConns,
+		MaxIdleConnsPerHost:   hc.MaxIdleConns,
+		DisableKeepAlives:     hc.DisableKeepAlives,
+		ExpectContinueTimeout: time.Second,
+	}
+	if tr.TLSHandshakeTimeout == 0 {
+		tr.TLSHandshakeTimeout = cfgs.DefaultTLSHandshakeTimeout
+	}
+	if tr.IdleConnTimeout == 0 {
+		tr.IdleConnTimeout = cfgs.DefaultIdleConnTimeout
+	}
+	if tr.MaxIdleConns == 0 {
+		tr.MaxIdleConns, tr.MaxIdleConnsPerHost = cfgs.DefaultMaxIdleConns, cfgs.DefaultMaxIdleConns
+	}
+	if hc.Proxy != "" {
+		proxy, err := url.Parse(hc.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		tr.Proxy = http.ProxyURL(proxy)
+	}
+	return &http.Client{Transport: tr}, nil
 }
 
 /*This method sends an http request (without processing the response) as application/json. Returns the body of the response.*/
-func (hsc *httpSenderClient) sendHttpReqJson(method string, args objs.MethodArguments) ([]byte, error) {
+func (hsc *httpSenderClient) sendHttpReqJson(ctx context.Context, method string, args objs.MethodArguments) ([]byte, error) {
 	if args == nil {
-		return hsc.sendHttpReq(method, "application/json", make([]byte, 0))
+		return hsc.sendHttpReq(ctx, method, "application/json", http.NoBody, 0)
 	}
 	bd := args.ToJson()
-	return hsc.sendHttpReq(method, "application/json", bd)
+	return hsc.sendHttpReq(ctx, method, "application/json", bytes.NewReader(bd), int64(len(bd)))
 }
 
 /*This method sends an http request (without processing the response) as multipart/formdata. Returns the body of the response.
-This method is only used for uploading files to bot api server.*/
-func (hsc *httpSenderClient) sendHttpReqMultiPart(method string, args objs.MethodArguments, files ...*os.File) ([]byte, error) {
-	body := &bytes.Buffer{}
+This method is only used for uploading files to bot api server. The body is streamed through a pipe, so the files are not loaded into the memory.*/
+func (hsc *httpSenderClient) sendHttpReqMultiPart(ctx context.Context, method string, args objs.MethodArguments, files []*uploadFile) ([]byte, error) {
+	pr, pw := io.Pipe()
+	body := &bodyWriter{w: pw}
 	writer := mp.NewWriter(body)
-	args.ToMultiPart(writer)
-	for _, file := range files {
-		if file == nil {
-			continue
-		}
-		err := hsc.addFileToMultiPartForm(file, writer)
-		if err != nil {
-			return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to add file to the multipart form. " + err.Error()}
-		}
+	length := multiPartLength(writer.Boundary(), args, files)
+	writeErr := make(chan error, 1)
+	go func() {
+		err := writeMultiPart(body, writer, args, files, true)
+		pw.CloseWithError(err)
+		writeErr <- err
+	}()
+	res, err := hsc.sendHttpReq(ctx, method, writer.FormDataContentType(), pr, length)
+	//The server may respond before reading the whole body. The writer is stopped before returning since the files may be rewound and sent again.
+	pr.Close()
+	err2 := <-writeErr
+	if err != nil && err2 != nil && err2 != io.ErrClosedPipe {
+		return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to add file to the multipart form. " + err2.Error()}
 	}
-	_ = writer.Close()
-	bts := body.Bytes()
-	return hsc.sendHttpReq(method, writer.FormDataContentType(), bts)
+	return res, err
 }
 
-func (hsc *httpSenderClient) addFileToMultiPartForm(file *os.File, wr *mp.Writer) error {
-	if file != nil {
-		fileStat, err := file.Stat()
-		if err != nil {
-			return err
-		}
-		fw, err2 := wr.CreateFormFile(fileStat.Name(), fileStat.Name())
-		if err2 != nil {
-			return err2
-		}
-		_, err3 := io.Copy(fw, file)
-		if err3 != nil {
-			return err3
-		}
+/*sendHttpReq sends the request with the given body. "length" is the length of the body, -1 if it's unknown.*/
+func (hsc *httpSenderClient) sendHttpReq(ctx context.Context, method, contetType string, body io.Reader, length int64) ([]byte, error) {
+	if hsc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, hsc.timeout)
+		defer cancel()
 	}
-	return nil
-}
-
-func (hsc *httpSenderClient) sendHttpReq(method, contetType string, body []byte) ([]byte, error) {
-	cl := http.Client{}
-	req, err := http.NewRequest("POST", hsc.botApi+hsc.apiKey+"/"+method, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", hsc.botApi+hsc.apiKey+"/"+method, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Add(textproto.CanonicalMIMEHeaderKey("content-type"), contetType)
-	req.Header.Add(textproto.CanonicalMIMEHeaderKey("content-length"), strconv.Itoa(len(body)))
-	res, err2 := cl.Do(req)
+	req.ContentLength = length
+	res, err2 := hsc.client.Do(req)
 	if err2 != nil {
-		return nil, &errs.MethodNotSentError{Method: method, Reason: err2.Error()}
+		return nil, &errs.MethodNotSentError{Method: method, Reason: err2.Error(), Err: err2}
+	}
+	defer res.Body.Close()
+	out, err3 := io.ReadAll(res.Body)
+	if err3 != nil {
+		return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to read the body of the response. " + err3.Error(), StatusCode: res.StatusCode, Err: err3}
+	}
+	if res.StatusCode < 300 {
+		return out, nil
 	}
-	if res.StatusCode < 500 {
-		out := make([]byte, res.ContentLength)
-		_, err3 := res.Body.Read(out)
-		if err3 != nil {
-			return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to parse body into byte slice. " + err3.Error()}
+	reason := "received status code " + strconv.Itoa(res.StatusCode)
+	fr := &objs.FailureResult{}
+	if err = json.Unmarshal(out, fr); err != nil {
+		//The response is not sent by the bot API server (like the error pages of the proxies), so the body is added to the reason.
+		fr = nil
+		if len(out) > maxErrorBodyLength {
+			out = out[:maxErrorBodyLength]
 		}
-		if res.StatusCode < 300 {
-			return out, nil
+		if len(out) > 0 {
+			reason += ". " + strings.TrimSpace(string(out))
 		}
-		fr := &objs.FailureResult{}
-		_ = json.Unmarshal(out, fr)
-		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode), FailureResult: fr}
-	} else {
-		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode)}
 	}
+	return nil, &errs.MethodNotSentError{Method: method, Reason: reason, FailureResult: fr, StatusCode: res.StatusCode}
 }
@@ -0,0 +1,111 @@
+package tba
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	errs "github.com/SakoDroid/telego/errors"
+)
+
+func TestSendHttpReq(t *testing.T) {
+	large := `{"ok":true,"result":"` + strings.Repeat("a", 100000) + `"}`
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/bottoken/chunked":
+			//Flushing before writing the whole body makes the response chunked, without Content-Length.
+			wr.Write([]byte(large[:10]))
+			wr.(http.Flusher).Flush()
+			wr.Write([]byte(large[10:]))
+		case "/bottoken/failure":
+			wr.WriteHeader(http.StatusBadGateway)
+			wr.Write([]byte(`{"ok":false,"error_code":502,"description":"Bad Gateway"}`))
+		case "/bottoken/html":
+			wr.WriteHeader(http.StatusServiceUnavailable)
+			wr.Write([]byte("<html>maintenance</html>"))
+		case "/bottoken/slow":
+			time.Sleep(300 * time.Millisecond)
+			wr.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+
+	for i := 0; i < 3; i++ {
+		res, err := bai.newSender().sendHttpReqJson(context.Background(), "chunked", nil)
+		if err != nil || string(res) != large {
+			t.Fatal("chunked response was not read completely", len(res), err)
+		}
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Error("connections were not reused, opened connections :", n)
+	}
+
+	var mnse *errs.MethodNotSentError
+	_, err := bai.newSender().sendHttpReqJson(context.Background(), "failure", nil)
+	if !errors.As(err, &mnse) || mnse.FailureResult == nil || mnse.FailureResult.ErrorCode != 502 {
+		t.Fatal("failure result of 5xx response was not parsed", err)
+	}
+	_, err = bai.newSender().sendHttpReqJson(context.Background(), "html", nil)
+	if !errors.As(err, &mnse) || mnse.FailureResult != nil || !strings.Contains(mnse.Reason, "maintenance") {
+		t.Fatal("body of the unknown response was not added to the error", err)
+	}
+	if !errors.Is(err, errs.ErrServer) {
+		t.Fatal("error was not matched with the status code", err)
+	}
+
+	bai.botConfigs.HTTPConfigs.Timeout = 50 * time.Millisecond
+	if _, err = bai.newSender().sendHttpReqJson(context.Background(), "slow", nil); !errors.Is(err, errs.ErrNetwork) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("request did not time out", err)
+	}
+}
+
+type countingTransport struct {
+	requests int32
+}
+
+func (ct *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&ct.requests, 1)
+	req.Header.Set("X-Custom", "yes")
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestCustomTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		wr.Write([]byte(`{"ok":true,"result":"` + req.Header.Get("X-Custom") + `"}`))
+	}))
+	defer srv.Close()
+	tr := &countingTransport{}
+	cfg := cfgs.Default("token")
+	cfg.BotAPI = srv.URL + "/bot"
+	cfg.HTTPConfigs = &cfgs.HTTPConfigs{Transport: tr}
+	bai, err := CreateInterface(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := bai.SendCustom("getMe", nil, false)
+	if err != nil || !bytes.Contains(res, []byte("yes")) || atomic.LoadInt32(&tr.requests) != 1 {
+		t.Fatal("request was not sent by the custom transport", string(res), err)
+	}
+
+	client := &http.Client{Transport: tr}
+	cfg.HTTPConfigs = &cfgs.HTTPConfigs{Client: client}
+	if bai, err = CreateInterface(cfg); err != nil || bai.HTTPClient() != client {
+		t.Fatal("custom client was not used", err)
+	}
+}
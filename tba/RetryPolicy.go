@@ -0,0 +1,144 @@
+package tba
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	errs "github.com/SakoDroid/telego/errors"
+	logger "github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+/*sendWithRetry sends the request and retries it based on the retry configs of the bot when the API server rejects it because of flood control (429) or because the target group has been migrated to a supergroup.
+If the rate limiter is enabled, each attempt waits for its turn in the scheduler queue.*/
+func (bai *BotAPIInterface) sendWithRetry(ctx context.Context, cl *httpSenderClient, method string, args objs.MethodArguments, MP bool, files ...io.Reader) ([]byte, error) {
+	policy := bai.botConfigs.RetryConfigs
+	uploads, err := newUploadFiles(files)
+	if err != nil {
+		return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to add file to the multipart form. " + err.Error()}
+	}
+	for attempt := 0; ; attempt++ {
+		if bai.scheduler != nil {
+			if err := bai.scheduler.Wait(ctx, method, getChatId(args)); err != nil {
+				return nil, err
+			}
+		}
+		var res []byte
+		var err error
+		if MP {
+			res, err = cl.sendHttpReqMultiPart(ctx, method, args, uploads)
+		} else {
+			res, err = cl.sendHttpReqJson(ctx, method, args)
+		}
+		if err == nil || policy == nil || attempt >= policy.MaxRetries {
+			return res, err
+		}
+		wait, retry := bai.checkRetry(policy, method, args, err, attempt)
+		if !retry {
+			return nil, err
+		}
+		if err2 := rewindFiles(uploads); err2 != nil {
+			return nil, err
+		}
+		logger.Log(method, "\t\t\t", "Retry  ", "after "+wait.String(), logger.BOLD+logger.OKBLUE, logger.WARNING, "")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+/*checkRetry decides if the failed request should be sent again and how long to wait before sending it.*/
+func (bai *BotAPIInterface) checkRetry(policy *cfgs.RetryConfigs, method string, args objs.MethodArguments, err error, attempt int) (time.Duration, bool) {
+	var migrated *errs.ChatMigrated
+	if errors.As(err, &migrated) {
+		if !policy.FollowMigration || !retargetChat(args, migrated.MigrateToChatId) {
+			return 0, false
+		}
+		logger.Logger.Println(method, ": the group has been migrated to supergroup", migrated.MigrateToChatId, ". Sending the request to the new chat ...")
+		return 0, true
+	}
+	var tooMany *errs.TooManyRequests
+	if !errors.As(err, &tooMany) {
+		return 0, false
+	}
+	wait := policy.InitialBackoff << uint(attempt)
+	if policy.MaxBackoff > 0 && (wait > policy.MaxBackoff || wait <= 0) {
+		wait = policy.MaxBackoff
+	}
+	if tooMany.RetryAfter > 0 {
+		if policy.MaxRetryAfter > 0 && tooMany.RetryAfter > policy.MaxRetryAfter {
+			return 0, false
+		}
+		if tooMany.RetryAfter > wait {
+			wait = tooMany.RetryAfter
+		}
+	}
+	return wait, true
+}
+
+func rewindFiles(files []*uploadFile) error {
+	for _, file := range files {
+		if err := file.rewind(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*retargetChat changes the chat id of the given arguments to the given chat id. Returns false if the arguments don't have a chat id.*/
+func retargetChat(args objs.MethodArguments, chatId int) bool {
+	field := chatIdField(args)
+	if !field.IsValid() || !field.CanSet() {
+		return false
+	}
+	switch field.Kind() {
+	case reflect.Slice:
+		field.SetBytes([]byte(strconv.Itoa(chatId)))
+	case reflect.Int, reflect.Int64:
+		field.SetInt(int64(chatId))
+	default:
+		return false
+	}
+	return true
+}
+
+/*getChatId returns the chat id of the given arguments in form of a string. If the chat id is a username, it is returned with the quotes ("@username").
+Returns an empty string if the arguments don't have a chat id.*/
+func getChatId(args objs.MethodArguments) string {
+	field := chatIdField(args)
+	if !field.IsValid() {
+		return ""
+	}
+	switch field.Kind() {
+	case reflect.Slice:
+		return string(field.Bytes())
+	case reflect.Int, reflect.Int64:
+		if field.Int() == 0 {
+			return ""
+		}
+		return strconv.FormatInt(field.Int(), 10)
+	default:
+		return ""
+	}
+}
+
+/*chatIdField returns the "ChatId" field of the given arguments. The returned value is invalid if the arguments don't have a chat id field.*/
+func chatIdField(args objs.MethodArguments) reflect.Value {
+	val := reflect.ValueOf(args)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	field := val.Elem().FieldByName("ChatId")
+	if field.Kind() == reflect.Slice && field.Type() != reflect.TypeOf(json.RawMessage{}) {
+		return reflect.Value{}
+	}
+	return field
+}
@@ -0,0 +1,120 @@
+package tba
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	"github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestRetryAfterFloodControl(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls == 1 {
+			wr.WriteHeader(429)
+			wr.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		wr.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, &cfgs.RetryConfigs{MaxRetries: 2, InitialBackoff: time.Millisecond})
+	start := time.Now()
+	_, err := bai.SendMessage(1, "", "hi", "", nil, false, false, false, false, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Error("expected 2 calls, got", calls)
+	}
+	if time.Since(start) < time.Second {
+		t.Error("retry_after was not honored")
+	}
+}
+
+func TestRetryMigratedChat(t *testing.T) {
+	var chatIds []string
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		args := make(map[string]json.RawMessage)
+		_ = json.Unmarshal(body, &args)
+		chatIds = append(chatIds, string(args["chat_id"]))
+		if len(chatIds) == 1 {
+			wr.WriteHeader(400)
+			wr.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: group chat was upgraded to a supergroup chat","parameters":{"migrate_to_chat_id":-1001234}}`))
+			return
+		}
+		wr.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, cfgs.DefaultRetryConfigs())
+	_, err := bai.SendMessage(-1234, "", "hi", "", nil, false, false, false, false, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chatIds) != 2 || chatIds[0] != "-1234" || chatIds[1] != "-1001234" {
+		t.Error("wrong chat ids :", chatIds)
+	}
+}
+
+func TestNoRetryWithoutPolicy(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		calls++
+		wr.WriteHeader(429)
+		wr.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	_, err := bai.SendMessage(1, "", "hi", "", nil, false, false, false, false, 0, nil)
+	if err == nil {
+		t.Error("expected an error")
+	}
+	if calls != 1 {
+		t.Error("expected 1 call, got", calls)
+	}
+}
+
+/*TestChatIdOfSendArgs checks that the arguments of all the methods that send messages have a chat id which can be read and changed by the retry policy and the scheduler.*/
+func TestChatIdOfSendArgs(t *testing.T) {
+	args := []objs.MethodArguments{
+		&objs.SendMessageArgs{}, &objs.ForwardMessageArgs{}, &objs.CopyMessageArgs{}, &objs.SendPhotoArgs{},
+		&objs.SendAudioArgs{}, &objs.SendDocumentArgs{}, &objs.SendVideoArgs{}, &objs.SendAnimationArgs{},
+		&objs.SendVoiceArgs{}, &objs.SendVideoNoteArgs{}, &objs.SendMediaGroupArgs{}, &objs.SendLocationArgs{},
+		&objs.SendVenueArgs{}, &objs.SendContactArgs{}, &objs.SendPollArgs{}, &objs.SendDiceArgs{},
+		&objs.SendChatActionArgs{}, &objs.SendStickerArgs{}, &objs.SendInvoiceArgs{}, &objs.SendGameArgs{},
+	}
+	for _, arg := range args {
+		if !retargetChat(arg, 42) {
+			t.Errorf("chat id of %T can't be changed", arg)
+			continue
+		}
+		if id := getChatId(arg); id != "42" {
+			t.Errorf("wrong chat id of %T : %s", arg, id)
+		}
+	}
+	if retargetChat(&objs.GetFileArgs{}, 42) || getChatId(&objs.GetFileArgs{}) != "" {
+		t.Error("arguments without chat id have a chat id")
+	}
+}
+
+func createTestInterface(url string, retry *cfgs.RetryConfigs) *BotAPIInterface {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	cfg := cfgs.Default("token")
+	cfg.BotAPI = url + "/bot"
+	cfg.RetryConfigs = retry
+	ch := make(chan *objs.Update)
+	ch2 := make(chan *objs.ChatUpdate)
+	client, _ := newHTTPClient(cfg.HTTPConfigs)
+	return &BotAPIInterface{botConfigs: cfg, updateChannel: &ch, chatUpadateChannel: &ch2, client: client}
+}
@@ -0,0 +1,225 @@
+package tba
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+)
+
+/*Scheduler queues the outgoing requests and delays them so telegram rate limits are not exceeded. Requests are sent in order of their priority, and requests with the same priority are sent in the order they were queued.
+A request waits only for the limits of its own chat, so a request to a chat which has not reached its limit is not blocked by the requests of another chat.*/
+type Scheduler struct {
+	configs *cfgs.RateLimitConfigs
+	lock    sync.Mutex
+	queue   []*ticket
+	seq     uint64
+	global  *window
+	chats   map[string]*window
+	changed chan bool
+}
+
+/*messageMethods are the methods that send a message to a chat. Only these methods are limited by the limits of the chats.*/
+var messageMethods = map[string]bool{
+	"sendMessage": true, "forwardMessage": true, "copyMessage": true, "sendPhoto": true, "sendAudio": true,
+	"sendDocument": true, "sendVideo": true, "sendAnimation": true, "sendVoice": true, "sendVideoNote": true,
+	"sendMediaGroup": true, "sendLocation": true, "sendVenue": true, "sendContact": true, "sendPoll": true,
+	"sendDice": true, "sendSticker": true, "sendInvoice": true, "sendGame": true,
+}
+
+//ticket represents a request waiting in the queue.
+type ticket struct {
+	chatId   string
+	priority cfgs.Priority
+	seq      uint64
+}
+
+//window limits the number of events in a period of time.
+type window struct {
+	limit  int
+	period time.Duration
+	times  []time.Time
+}
+
+//NewScheduler creates a new scheduler with the given configs.
+func NewScheduler(configs *cfgs.RateLimitConfigs) *Scheduler {
+	return &Scheduler{
+		configs: configs,
+		global:  &window{limit: configs.GlobalLimit, period: time.Second},
+		chats:   make(map[string]*window),
+		changed: make(chan bool),
+	}
+}
+
+/*Wait blocks until a request of the given method can be sent to the given chat. "chatId" can be empty for the methods that are not sent to a chat. The limits of the chat are applied only if the method sends a message (like "sendMessage"), for other methods only the global limit is applied.
+If the context is done before the request can be sent, the request is removed from the queue and the context's error is returned.*/
+func (s *Scheduler) Wait(ctx context.Context, method, chatId string) error {
+	if !messageMethods[method] {
+		chatId = ""
+	}
+	return s.WaitWithPriority(ctx, chatId, s.GetPriority(method))
+}
+
+/*WaitWithPriority works the same way as "Wait" but it uses the given priority instead of the priority of the method. The limits of the given chat are always applied, pass an empty chat id to apply only the global limit.*/
+func (s *Scheduler) WaitWithPriority(ctx context.Context, chatId string, priority cfgs.Priority) error {
+	s.lock.Lock()
+	s.seq++
+	t := &ticket{chatId: chatId, priority: priority, seq: s.seq}
+	s.queue = append(s.queue, t)
+	for {
+		now := time.Now()
+		delay, ok := s.check(t, now)
+		if ok {
+			s.remove(t)
+			s.record(t, now)
+			s.notify()
+			s.lock.Unlock()
+			return nil
+		}
+		changed := s.changed
+		s.lock.Unlock()
+		//Zero delay means the ticket waits for another one which notifies the others when it's sent.
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if delay > 0 {
+			timer = time.NewTimer(delay)
+			timeout = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			s.lock.Lock()
+			s.remove(t)
+			s.notify()
+			s.lock.Unlock()
+			return ctx.Err()
+		case <-changed:
+		case <-timeout:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		s.lock.Lock()
+	}
+}
+
+//GetPriority returns the priority of the given method.
+func (s *Scheduler) GetPriority(method string) cfgs.Priority {
+	return s.configs.MethodPriorities[method]
+}
+
+//QueueDepth returns the number of requests waiting in the queue.
+func (s *Scheduler) QueueDepth() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.queue)
+}
+
+/*check returns true if the ticket can be sent now. If not, it returns the time that should be waited before checking again. Zero delay means a ticket which should be sent before this one is ready, so this ticket is checked again when that one is sent.*/
+func (s *Scheduler) check(t *ticket, now time.Time) (time.Duration, bool) {
+	if delay := s.delayOf(t, now); delay > 0 {
+		return delay, false
+	}
+	for _, other := range s.queue {
+		if other != t && other.before(t) && s.delayOf(other, now) == 0 {
+			//Another ticket with higher priority is ready. It will notify the others after it is sent.
+			return 0, false
+		}
+	}
+	return 0, true
+}
+
+func (s *Scheduler) delayOf(t *ticket, now time.Time) time.Duration {
+	delay := s.global.delay(now)
+	if w := s.chats[t.chatId]; w != nil {
+		if d := w.delay(now); d > delay {
+			delay = d
+		}
+	}
+	return delay
+}
+
+func (s *Scheduler) record(t *ticket, now time.Time) {
+	s.global.add(now)
+	if t.chatId == "" {
+		return
+	}
+	w := s.chats[t.chatId]
+	if w == nil {
+		w = s.createWindow(t.chatId)
+		s.chats[t.chatId] = w
+	}
+	w.add(now)
+	if len(s.chats) > 1000 {
+		s.cleanUp(now)
+	}
+}
+
+/*createWindow creates the window for the given chat. Chat ids of private chats are positive numbers, others are negative numbers or usernames.*/
+func (s *Scheduler) createWindow(chatId string) *window {
+	if strings.HasPrefix(chatId, "-") || strings.HasPrefix(chatId, "\"") || strings.HasPrefix(chatId, "@") {
+		return &window{limit: s.configs.GroupLimit, period: time.Minute}
+	}
+	return &window{limit: s.configs.PrivateChatLimit, period: time.Second}
+}
+
+//cleanUp removes the windows that have no event in their period.
+func (s *Scheduler) cleanUp(now time.Time) {
+	for chatId, w := range s.chats {
+		w.prune(now)
+		if len(w.times) == 0 {
+			delete(s.chats, chatId)
+		}
+	}
+}
+
+func (s *Scheduler) remove(t *ticket) {
+	for i, other := range s.queue {
+		if other == t {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+//notify wakes up all the waiting requests.
+func (s *Scheduler) notify() {
+	close(s.changed)
+	s.changed = make(chan bool)
+}
+
+func (t *ticket) before(other *ticket) bool {
+	if t.priority != other.priority {
+		return t.priority > other.priority
+	}
+	return t.seq < other.seq
+}
+
+func (w *window) delay(now time.Time) time.Duration {
+	if w.limit <= 0 {
+		return 0
+	}
+	w.prune(now)
+	if len(w.times) < w.limit {
+		return 0
+	}
+	return w.times[0].Add(w.period).Sub(now)
+}
+
+func (w *window) add(now time.Time) {
+	if w.limit > 0 {
+		w.times = append(w.times, now)
+	}
+}
+
+func (w *window) prune(now time.Time) {
+	i := 0
+	for i < len(w.times) && !w.times[i].Add(w.period).After(now) {
+		i++
+	}
+	w.times = w.times[i:]
+}
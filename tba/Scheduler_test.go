@@ -0,0 +1,90 @@
+package tba
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+)
+
+func TestSchedulerChatLimit(t *testing.T) {
+	sc := NewScheduler(&cfgs.RateLimitConfigs{GlobalLimit: 100, PrivateChatLimit: 2})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := sc.Wait(context.Background(), "sendMessage", "123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if time.Since(start) < time.Second {
+		t.Error("third request to the same chat was not delayed")
+	}
+	start = time.Now()
+	if err := sc.Wait(context.Background(), "sendMessage", "456"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > 100*time.Millisecond {
+		t.Error("request to another chat was delayed")
+	}
+}
+
+func TestSchedulerChatLimitOfMethods(t *testing.T) {
+	sc := NewScheduler(&cfgs.RateLimitConfigs{GlobalLimit: 100, PrivateChatLimit: 1})
+	start := time.Now()
+	for _, method := range []string{"sendMessage", "editMessageText", "answerCallbackQuery", "getChat", "deleteMessage"} {
+		if err := sc.Wait(context.Background(), method, "123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if time.Since(start) > 100*time.Millisecond {
+		t.Error("methods that don't send messages were delayed by the limit of the chat")
+	}
+	if err := sc.Wait(context.Background(), "sendPhoto", "123"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) < time.Second {
+		t.Error("second message to the same chat was not delayed")
+	}
+}
+
+func TestSchedulerPriority(t *testing.T) {
+	sc := NewScheduler(&cfgs.RateLimitConfigs{GlobalLimit: 1, MethodPriorities: map[string]cfgs.Priority{"answerCallbackQuery": cfgs.HighPriority}})
+	_ = sc.Wait(context.Background(), "sendMessage", "")
+	var lock sync.Mutex
+	order := make([]string, 0)
+	var wg sync.WaitGroup
+	for i, method := range []string{"sendMessage", "answerCallbackQuery"} {
+		wg.Add(1)
+		go func(method string) {
+			defer wg.Done()
+			_ = sc.Wait(context.Background(), method, "")
+			lock.Lock()
+			order = append(order, method)
+			lock.Unlock()
+		}(method)
+		for sc.QueueDepth() != i+1 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if sc.QueueDepth() != 2 {
+		t.Error("wrong queue depth :", sc.QueueDepth())
+	}
+	wg.Wait()
+	if order[0] != "answerCallbackQuery" {
+		t.Error("high priority request was not sent first :", order)
+	}
+}
+
+func TestSchedulerCancel(t *testing.T) {
+	sc := NewScheduler(&cfgs.RateLimitConfigs{GlobalLimit: 1})
+	_ = sc.Wait(context.Background(), "sendMessage", "")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := sc.Wait(ctx, "sendMessage", ""); err != context.DeadlineExceeded {
+		t.Error("expected deadline exceeded, got", err)
+	}
+	if sc.QueueDepth() != 0 {
+		t.Error("cancelled request was not removed from the queue")
+	}
+}
@@ -1,6 +1,7 @@
 package tba
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -17,66 +18,107 @@ import (
 	up "github.com/SakoDroid/telego/parser"
 )
 
-var interfaceCreated = false
-
 //BotAPIInterface is the interface which connects the telegram bot API to the bot.
 type BotAPIInterface struct {
 	botConfigs           *cfgs.BotConfigs
 	updateRoutineRunning bool
 	updateChannel        *chan *objs.Update
 	chatUpadateChannel   *chan *objs.ChatUpdate
-	updateRoutineChannel chan bool
+	updateRoutineCancel  context.CancelFunc
+	updateRoutineDone    chan bool
 	lastOffset           int
+	scheduler            *Scheduler
+	parser               *up.Parser
+	webhook              *webhook
+	client               *http.Client
 }
 
 /*StartUpdateRoutine starts the update routine to receive updates from api sever*/
 func (bai *BotAPIInterface) StartUpdateRoutine() error {
+	return bai.StartUpdateRoutineContext(context.Background())
+}
+
+/*StartUpdateRoutineContext starts the update routine to receive updates from api sever. The routine stops when the given context is cancelled or "StopUpdateRoutine" is called.
+If a getUpdates request is in progress when the routine is stopped, the request is cancelled.*/
+func (bai *BotAPIInterface) StartUpdateRoutineContext(ctx context.Context) error {
 	if !bai.botConfigs.Webhook {
 		if bai.updateRoutineRunning {
 			return &errs.UpdateRoutineAlreadyStarted{}
 		}
 		bai.updateRoutineRunning = true
-		bai.updateRoutineChannel = make(chan bool)
-		go bai.startReceiving()
+		ctx, bai.updateRoutineCancel = context.WithCancel(ctx)
+		bai.updateRoutineDone = make(chan bool)
+		go bai.startReceiving(ctx)
 		return nil
 	} else {
 		return errors.New("webhook option is true")
 	}
 }
 
-/*StopUpdateRoutine stops the update routine*/
+/*StopUpdateRoutine stops the update routine and waits for it to return.*/
 func (bai *BotAPIInterface) StopUpdateRoutine() {
 	if bai.updateRoutineRunning {
 		bai.updateRoutineRunning = false
-		bai.updateRoutineChannel <- true
+		bai.updateRoutineCancel()
+		<-bai.updateRoutineDone
 	}
 }
 
+/*GetQueueDepth returns the number of requests waiting in the rate limiter queue. If the rate limiter is disabled it returns 0.*/
+func (bai *BotAPIInterface) GetQueueDepth() int {
+	if bai.scheduler == nil {
+		return 0
+	}
+	return bai.scheduler.QueueDepth()
+}
+
 /*GetUpdateChannel returns the update channel*/
 func (bai *BotAPIInterface) GetUpdateChannel() *chan *objs.Update {
 	return bai.updateChannel
 }
 
+/*GetParser returns the parser that parses the updates received by this interface and dispatches them to the handlers.*/
+func (bai *BotAPIInterface) GetParser() *up.Parser {
+	return bai.parser
+}
+
+/*HTTPClient returns the HTTP client that sends the requests of the bot to the API server.*/
+func (bai *BotAPIInterface) HTTPClient() *http.Client {
+	return bai.client
+}
+
+//newSender returns a sender which sends the requests using the HTTP client of the bot.
+func (bai *BotAPIInterface) newSender() *httpSenderClient {
+	return &httpSenderClient{botApi: bai.botConfigs.BotAPI, apiKey: bai.botConfigs.APIKey, client: bai.client, timeout: bai.botConfigs.HTTPConfigs.RequestTimeout()}
+}
+
 /*GetChatUpdateChannel returnes the chat update channel*/
 func (bai *BotAPIInterface) GetChatUpdateChannel() *chan *objs.ChatUpdate {
 	return bai.chatUpadateChannel
 }
 
-func (bai *BotAPIInterface) startReceiving() {
-	cl := httpSenderClient{botApi: bai.botConfigs.BotAPI, apiKey: bai.botConfigs.APIKey}
+func (bai *BotAPIInterface) startReceiving(ctx context.Context) {
+	defer close(bai.updateRoutineDone)
+	cl := bai.newSender()
+	if cl.timeout > 0 {
+		//The server holds getUpdates requests for the long polling timeout.
+		cl.timeout += time.Duration(bai.botConfigs.UpdateConfigs.Timeout) * time.Second
+	}
 loop:
 	for {
-		time.Sleep(bai.botConfigs.UpdateConfigs.UpdateFrequency)
 		select {
-		case <-bai.updateRoutineChannel:
+		case <-ctx.Done():
 			break loop
-		default:
+		case <-time.After(bai.botConfigs.UpdateConfigs.UpdateFrequency):
 			args := objs.GetUpdatesArgs{Offset: bai.lastOffset + 1, Limit: bai.botConfigs.UpdateConfigs.Limit, Timeout: bai.botConfigs.UpdateConfigs.Timeout}
 			if bai.botConfigs.UpdateConfigs.AllowedUpdates != nil {
 				args.AllowedUpdates = bai.botConfigs.UpdateConfigs.AllowedUpdates
 			}
-			res, err := cl.sendHttpReqJson("getUpdates", &args)
+			res, err := cl.sendHttpReqJson(ctx, "getUpdates", &args)
 			if err != nil {
+				if ctx.Err() != nil {
+					break loop
+				}
 				logger.Logger.Println("Error receiving updates.", err)
 				continue loop
 			}
@@ -89,7 +131,7 @@ loop:
 }
 
 func (bai *BotAPIInterface) parseUpdateresults(body []byte) error {
-	of, err := up.ParseUpdate(
+	of, err := bai.parser.ParseUpdate(
 		body, bai.updateChannel, bai.chatUpadateChannel, bai.botConfigs,
 	)
 	if err != nil {
@@ -111,7 +153,12 @@ func (bai *BotAPIInterface) isChatIdOk(chatIdInt int, chatIdString string) bool
 
 /*GetMe gets the bot info*/
 func (bai *BotAPIInterface) GetMe() (*objs.UserResult, error) {
-	res, err := bai.SendCustom("getMe", nil, false, nil)
+	return bai.GetMeContext(context.Background())
+}
+
+/*GetMeContext works like "GetMe" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetMeContext(ctx context.Context) (*objs.UserResult, error) {
+	res, err := bai.SendCustomContext(ctx, "getMe", nil, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -126,6 +173,11 @@ func (bai *BotAPIInterface) GetMe() (*objs.UserResult, error) {
 /*SendMessage sends a message to the user. chatIdInt is used for all chats but channles and chatidString is used for channels (in form of @channleusername) and only of them has be populated, otherwise ChatIdProblem error will be returned.
 "chatId" and "text" arguments are required. other arguments are optional for bot api.*/
 func (bai *BotAPIInterface) SendMessage(chatIdInt int, chatIdString, text, parseMode string, entities []objs.MessageEntity, disable_web_page_preview, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_to_message_id int, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendMessageContext(context.Background(), chatIdInt, chatIdString, text, parseMode, entities, disable_web_page_preview, disable_notification, allow_sending_without_reply, ProtectContent, reply_to_message_id, reply_markup)
+}
+
+/*SendMessageContext works like "SendMessage" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendMessageContext(ctx context.Context, chatIdInt int, chatIdString, text, parseMode string, entities []objs.MessageEntity, disable_web_page_preview, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_to_message_id int, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -139,7 +191,7 @@ func (bai *BotAPIInterface) SendMessage(chatIdInt int, chatIdString, text, parse
 			Entities:                    entities,
 		}
 
-		res, err := bai.SendCustom("sendMessage", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "sendMessage", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -157,6 +209,11 @@ func (bai *BotAPIInterface) SendMessage(chatIdInt int, chatIdString, text, parse
 /*ForwardMessage forwards a message from a user or channel to a user or channel. If the source or destination (or both) of the forwarded message is a channel, only string chat ids should be given to the function, and if it is user only int chat ids should be given.
 "chatId", "fromChatId" and "messageId" arguments are required. other arguments are optional for bot api.*/
 func (bai *BotAPIInterface) ForwardMessage(chatIdInt, fromChatIdInt int, chatIdString, fromChatIdString string, disableNotif, ProtectContent bool, messageId int) (*objs.SendMethodsResult, error) {
+	return bai.ForwardMessageContext(context.Background(), chatIdInt, fromChatIdInt, chatIdString, fromChatIdString, disableNotif, ProtectContent, messageId)
+}
+
+/*ForwardMessageContext works like "ForwardMessage" but sends the request with the given context.*/
+func (bai *BotAPIInterface) ForwardMessageContext(ctx context.Context, chatIdInt, fromChatIdInt int, chatIdString, fromChatIdString string, disableNotif, ProtectContent bool, messageId int) (*objs.SendMethodsResult, error) {
 	if (chatIdInt != 0 && chatIdString != "") && (fromChatIdInt != 0 && fromChatIdString != "") {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -168,7 +225,7 @@ func (bai *BotAPIInterface) ForwardMessage(chatIdInt, fromChatIdInt int, chatIdS
 		}
 		fm.ChatId = bai.fixChatId(chatIdInt, chatIdString)
 		fm.FromChatId = bai.fixChatId(fromChatIdInt, fromChatIdString)
-		res, err := bai.SendCustom("forwardMessage", fm, false, nil, nil)
+		res, err := bai.SendCustomContext(ctx, "forwardMessage", fm, false, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -185,7 +242,12 @@ func (bai *BotAPIInterface) ForwardMessage(chatIdInt, fromChatIdInt int, chatIdS
 
 /*SendPhoto sends a photo (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "photo" arguments are required. other arguments are optional for bot api.*/
-func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string, photoFile *os.File, caption, parseMode string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.SendMethodsResult, error) {
+func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string, photoFile io.Reader, caption, parseMode string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.SendMethodsResult, error) {
+	return bai.SendPhotoContext(context.Background(), chatIdInt, chatIdString, photo, photoFile, caption, parseMode, reply_to_message_id, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup, captionEntities)
+}
+
+/*SendPhotoContext works like "SendPhoto" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendPhotoContext(ctx context.Context, chatIdInt int, chatIdString, photo string, photoFile io.Reader, caption, parseMode string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -203,9 +265,9 @@ func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string,
 		var res []byte
 		var err error
 		if photoFile != nil {
-			res, err = bai.SendCustom("sendPhoto", args, true, photoFile, nil)
+			res, err = bai.SendCustomContext(ctx, "sendPhoto", args, true, photoFile, nil)
 		} else {
-			res, err = bai.SendCustom("sendPhoto", args, false, nil, nil)
+			res, err = bai.SendCustomContext(ctx, "sendPhoto", args, false, nil, nil)
 		}
 		if err != nil {
 			return nil, err
@@ -223,7 +285,12 @@ func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string,
 
 /*SendVideo sends a video (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "video" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
-func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string, videoFile *os.File, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string, videoFile io.Reader, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendVideoContext(context.Background(), chatIdInt, chatIdString, video, videoFile, caption, parseMode, reply_to_message_id, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, duration, supportsStreaming, reply_markup)
+}
+
+/*SendVideoContext works like "SendVideo" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendVideoContext(ctx context.Context, chatIdInt int, chatIdString, video string, videoFile io.Reader, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -241,7 +308,7 @@ func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string,
 			Duration:          duration,
 			SupportsStreaming: supportsStreaming,
 		}
-		res, err := bai.SendCustom("sendVideo", args, true, videoFile, thumbFile)
+		res, err := bai.SendCustomContext(ctx, "sendVideo", args, true, videoFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -258,7 +325,12 @@ func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string,
 
 /*SendAudio sends an audio (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "audio" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0,to ignore string arguments pass "")*/
-func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string, audioFile *os.File, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer, title string, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string, audioFile io.Reader, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer, title string, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendAudioContext(context.Background(), chatIdInt, chatIdString, audio, audioFile, caption, parseMode, reply_to_message_id, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, duration, performer, title, reply_markup)
+}
+
+/*SendAudioContext works like "SendAudio" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendAudioContext(ctx context.Context, chatIdInt int, chatIdString, audio string, audioFile io.Reader, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer, title string, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -277,7 +349,7 @@ func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string,
 			Performer:       performer,
 			Title:           title,
 		}
-		res, err := bai.SendCustom("sendAudio", args, true, audioFile, thumbFile)
+		res, err := bai.SendCustomContext(ctx, "sendAudio", args, true, audioFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -294,7 +366,12 @@ func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string,
 
 /*sSendDocument sends a document (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "document" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
-func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document string, documentFile *os.File, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document string, documentFile io.Reader, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendDocumentContext(context.Background(), chatIdInt, chatIdString, document, documentFile, caption, parseMode, reply_to_message_id, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, DisableContentTypeDetection, reply_markup)
+}
+
+/*SendDocumentContext works like "SendDocument" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendDocumentContext(ctx context.Context, chatIdInt int, chatIdString, document string, documentFile io.Reader, caption, parseMode string, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -311,7 +388,7 @@ func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document s
 			CaptionEntities:             captionEntities,
 			DisableContentTypeDetection: DisableContentTypeDetection,
 		}
-		res, err := bai.SendCustom("sendDocument", args, true, documentFile, thumbFile)
+		res, err := bai.SendCustomContext(ctx, "sendDocument", args, true, documentFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -328,7 +405,12 @@ func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document s
 
 /*SendAnimation sends an animation (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "animation" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
-func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation string, animationFile *os.File, caption, parseMode string, width, height, duration int, reply_to_message_id int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation string, animationFile io.Reader, caption, parseMode string, width, height, duration int, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendAnimationContext(context.Background(), chatIdInt, chatIdString, animation, animationFile, caption, parseMode, width, height, duration, reply_to_message_id, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, reply_markup)
+}
+
+/*SendAnimationContext works like "SendAnimation" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendAnimationContext(ctx context.Context, chatIdInt int, chatIdString, animation string, animationFile io.Reader, caption, parseMode string, width, height, duration int, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -347,7 +429,7 @@ func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation
 			Height:          height,
 			Duration:        duration,
 		}
-		res, err := bai.SendCustom("sendAnimation", args, true, animationFile, thumbFile)
+		res, err := bai.SendCustomContext(ctx, "sendAnimation", args, true, animationFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -364,7 +446,12 @@ func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation
 
 /*sSendVoice sends a voice (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "voice" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
-func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string, voiceFile *os.File, caption, parseMode string, duration int, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string, voiceFile io.Reader, caption, parseMode string, duration int, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendVoiceContext(context.Background(), chatIdInt, chatIdString, voice, voiceFile, caption, parseMode, duration, reply_to_message_id, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, reply_markup)
+}
+
+/*SendVoiceContext works like "SendVoice" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendVoiceContext(ctx context.Context, chatIdInt int, chatIdString, voice string, voiceFile io.Reader, caption, parseMode string, duration int, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -380,7 +467,7 @@ func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string,
 			CaptionEntities: captionEntities,
 			Duration:        duration,
 		}
-		res, err := bai.SendCustom("sendVoice", args, true, voiceFile)
+		res, err := bai.SendCustomContext(ctx, "sendVoice", args, true, voiceFile)
 		if err != nil {
 			return nil, err
 		}
@@ -398,7 +485,12 @@ func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string,
 /*SendVideoNote sends a video note (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "videoNote" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 Note that sending video note by URL is not supported by telegram.*/
-func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote string, videoNoteFile *os.File, caption, parseMode string, length, duration int, reply_to_message_id int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote string, videoNoteFile io.Reader, caption, parseMode string, length, duration int, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendVideoNoteContext(context.Background(), chatIdInt, chatIdString, videoNote, videoNoteFile, caption, parseMode, length, duration, reply_to_message_id, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, reply_markup)
+}
+
+/*SendVideoNoteContext works like "SendVideoNote" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendVideoNoteContext(ctx context.Context, chatIdInt int, chatIdString, videoNote string, videoNoteFile io.Reader, caption, parseMode string, length, duration int, reply_to_message_id int, thumb string, thumbFile io.Reader, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -416,7 +508,7 @@ func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote
 			Length:          length,
 			Duration:        duration,
 		}
-		res, err := bai.SendCustom("sendVideoNote", args, true, videoNoteFile, thumbFile)
+		res, err := bai.SendCustomContext(ctx, "sendVideoNote", args, true, videoNoteFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -433,7 +525,12 @@ func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote
 
 /*SendMediaGroup sends an album of media (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "media" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
-func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, reply_to_message_id int, media []objs.InputMedia, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...*os.File) (*objs.SendMediaGroupMethodResult, error) {
+func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, reply_to_message_id int, media []objs.InputMedia, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...io.Reader) (*objs.SendMediaGroupMethodResult, error) {
+	return bai.SendMediaGroupContext(context.Background(), chatIdInt, chatIdString, reply_to_message_id, media, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup, files...)
+}
+
+/*SendMediaGroupContext works like "SendMediaGroup" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendMediaGroupContext(ctx context.Context, chatIdInt int, chatIdString string, reply_to_message_id int, media []objs.InputMedia, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...io.Reader) (*objs.SendMediaGroupMethodResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -445,7 +542,7 @@ func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, r
 			),
 			Media: media,
 		}
-		res, err := bai.SendCustom("sendMediaGroup", args, true, files...)
+		res, err := bai.SendCustomContext(ctx, "sendMediaGroup", args, true, files...)
 		if err != nil {
 			return nil, err
 		}
@@ -463,6 +560,11 @@ func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, r
 /*SendLocation sends a location to a channel (chatIdString) or a chat (chatIdInt)
 "chatId","latitude" and "longitude" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
 func (bai *BotAPIInterface) SendLocation(chatIdInt int, chatIdString string, latitude, longitude, horizontalAccuracy float32, livePeriod, heading, proximityAlertRadius, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendLocationContext(context.Background(), chatIdInt, chatIdString, latitude, longitude, horizontalAccuracy, livePeriod, heading, proximityAlertRadius, reply_to_message_id, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+}
+
+/*SendLocationContext works like "SendLocation" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendLocationContext(ctx context.Context, chatIdInt int, chatIdString string, latitude, longitude, horizontalAccuracy float32, livePeriod, heading, proximityAlertRadius, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -479,7 +581,7 @@ func (bai *BotAPIInterface) SendLocation(chatIdInt int, chatIdString string, lat
 			Heading:              heading,
 			ProximityAlertRadius: proximityAlertRadius,
 		}
-		res, err := bai.SendCustom("sendLocation", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "sendLocation", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -497,6 +599,11 @@ func (bai *BotAPIInterface) SendLocation(chatIdInt int, chatIdString string, lat
 /*EditMessageLiveLocation edits a live location sent to a channel (chatIdString) or a chat (chatIdInt)
 "chatId","latitude" and "longitude" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
 func (bai *BotAPIInterface) EditMessageLiveLocation(chatIdInt int, chatIdString, inlineMessageId string, messageId int, latitude, longitude, horizontalAccuracy float32, heading, proximityAlertRadius int, reply_markup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
+	return bai.EditMessageLiveLocationContext(context.Background(), chatIdInt, chatIdString, inlineMessageId, messageId, latitude, longitude, horizontalAccuracy, heading, proximityAlertRadius, reply_markup)
+}
+
+/*EditMessageLiveLocationContext works like "EditMessageLiveLocation" but sends the request with the given context.*/
+func (bai *BotAPIInterface) EditMessageLiveLocationContext(ctx context.Context, chatIdInt int, chatIdString, inlineMessageId string, messageId int, latitude, longitude, horizontalAccuracy float32, heading, proximityAlertRadius int, reply_markup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -512,7 +619,7 @@ func (bai *BotAPIInterface) EditMessageLiveLocation(chatIdInt int, chatIdString,
 			ReplyMarkup:          reply_markup,
 		}
 		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-		res, err := bai.SendCustom("editMessageLiveLocation", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "editMessageLiveLocation", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -530,6 +637,11 @@ func (bai *BotAPIInterface) EditMessageLiveLocation(chatIdInt int, chatIdString,
 /*StopMessageLiveLocation stops a live location sent to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" argument is required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
 func (bai *BotAPIInterface) StopMessageLiveLocation(chatIdInt int, chatIdString, inlineMessageId string, messageId int, replyMarkup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
+	return bai.StopMessageLiveLocationContext(context.Background(), chatIdInt, chatIdString, inlineMessageId, messageId, replyMarkup)
+}
+
+/*StopMessageLiveLocationContext works like "StopMessageLiveLocation" but sends the request with the given context.*/
+func (bai *BotAPIInterface) StopMessageLiveLocationContext(ctx context.Context, chatIdInt int, chatIdString, inlineMessageId string, messageId int, replyMarkup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -540,7 +652,7 @@ func (bai *BotAPIInterface) StopMessageLiveLocation(chatIdInt int, chatIdString,
 			ReplyMarkup:     replyMarkup,
 		}
 		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-		res, err := bai.SendCustom("stopMessageLiveLocation", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "stopMessageLiveLocation", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -558,6 +670,11 @@ func (bai *BotAPIInterface) StopMessageLiveLocation(chatIdInt int, chatIdString,
 /*SendVenue sends a venue to a channel (chatIdString) or a chat (chatIdInt)
 "chatId","latitude","longitude","title" and "address" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
 func (bai *BotAPIInterface) SendVenue(chatIdInt int, chatIdString string, latitude, longitude float32, title, address, fourSquareId, fourSquareType, googlePlaceId, googlePlaceType string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendVenueContext(context.Background(), chatIdInt, chatIdString, latitude, longitude, title, address, fourSquareId, fourSquareType, googlePlaceId, googlePlaceType, reply_to_message_id, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+}
+
+/*SendVenueContext works like "SendVenue" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendVenueContext(ctx context.Context, chatIdInt int, chatIdString string, latitude, longitude float32, title, address, fourSquareId, fourSquareType, googlePlaceId, googlePlaceType string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -576,7 +693,7 @@ func (bai *BotAPIInterface) SendVenue(chatIdInt int, chatIdString string, latitu
 			GooglePlaceId:   googlePlaceId,
 			GooglePlaceType: googlePlaceType,
 		}
-		res, err := bai.SendCustom("sendVnue", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "sendVnue", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -594,6 +711,11 @@ func (bai *BotAPIInterface) SendVenue(chatIdInt int, chatIdString string, latitu
 /*SendContact sends a contact to a channel (chatIdString) or a chat (chatIdInt)
 "chatId","phoneNumber" and "firstName" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
 func (bai *BotAPIInterface) SendContact(chatIdInt int, chatIdString, phoneNumber, firstName, lastName, vCard string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendContactContext(context.Background(), chatIdInt, chatIdString, phoneNumber, firstName, lastName, vCard, reply_to_message_id, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+}
+
+/*SendContactContext works like "SendContact" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendContactContext(ctx context.Context, chatIdInt int, chatIdString, phoneNumber, firstName, lastName, vCard string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -608,7 +730,7 @@ func (bai *BotAPIInterface) SendContact(chatIdInt int, chatIdString, phoneNumber
 			LastName:    lastName,
 			Vcard:       vCard,
 		}
-		res, err := bai.SendCustom("sendContact", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "sendContact", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -626,6 +748,11 @@ func (bai *BotAPIInterface) SendContact(chatIdInt int, chatIdString, phoneNumber
 /*SendPoll sends a poll to a channel (chatIdString) or a chat (chatIdInt)
 "chatId","phoneNumber" and "firstName" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
 func (bai *BotAPIInterface) SendPoll(chatIdInt int, chatIdString, question string, options []string, isClosed, isAnonymous bool, pollType string, allowMultipleAnswers bool, correctOptionIndex int, explanation, explanationParseMode string, explanationEntities []objs.MessageEntity, openPeriod, closeDate int, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendPollContext(context.Background(), chatIdInt, chatIdString, question, options, isClosed, isAnonymous, pollType, allowMultipleAnswers, correctOptionIndex, explanation, explanationParseMode, explanationEntities, openPeriod, closeDate, reply_to_message_id, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+}
+
+/*SendPollContext works like "SendPoll" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendPollContext(ctx context.Context, chatIdInt int, chatIdString, question string, options []string, isClosed, isAnonymous bool, pollType string, allowMultipleAnswers bool, correctOptionIndex int, explanation, explanationParseMode string, explanationEntities []objs.MessageEntity, openPeriod, closeDate int, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -648,7 +775,7 @@ func (bai *BotAPIInterface) SendPoll(chatIdInt int, chatIdString, question strin
 			OpenPeriod:            openPeriod,
 			CloseDate:             closeDate,
 		}
-		res, err := bai.SendCustom("sendPoll", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "sendPoll", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -666,6 +793,11 @@ func (bai *BotAPIInterface) SendPoll(chatIdInt int, chatIdString, question strin
 /*SendDice sends a dice message to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" argument is required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
 func (bai *BotAPIInterface) SendDice(chatIdInt int, chatIdString, emoji string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendDiceContext(context.Background(), chatIdInt, chatIdString, emoji, reply_to_message_id, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+}
+
+/*SendDiceContext works like "SendDice" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendDiceContext(ctx context.Context, chatIdInt int, chatIdString, emoji string, reply_to_message_id int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -677,7 +809,7 @@ func (bai *BotAPIInterface) SendDice(chatIdInt int, chatIdString, emoji string,
 			),
 			Emoji: emoji,
 		}
-		res, err := bai.SendCustom("sendDice", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "sendDice", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -695,6 +827,11 @@ func (bai *BotAPIInterface) SendDice(chatIdInt int, chatIdString, emoji string,
 /*SendChatAction sends a chat action message to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" argument is required. other arguments are optional for bot api. (to ignore int arguments, pass 0)*/
 func (bai *BotAPIInterface) SendChatAction(chatIdInt int, chatIdString, chatAction string) (*objs.SendMethodsResult, error) {
+	return bai.SendChatActionContext(context.Background(), chatIdInt, chatIdString, chatAction)
+}
+
+/*SendChatActionContext works like "SendChatAction" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendChatActionContext(ctx context.Context, chatIdInt int, chatIdString, chatAction string) (*objs.SendMethodsResult, error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -703,7 +840,7 @@ func (bai *BotAPIInterface) SendChatAction(chatIdInt int, chatIdString, chatActi
 			Action: chatAction,
 		}
 		args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-		res, err := bai.SendCustom("sendChatAction", args, false, nil)
+		res, err := bai.SendCustomContext(ctx, "sendChatAction", args, false, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -720,8 +857,13 @@ func (bai *BotAPIInterface) SendChatAction(chatIdInt int, chatIdString, chatActi
 
 /*GetUserProfilePhotos gets the user profile photos*/
 func (bai *BotAPIInterface) GetUserProfilePhotos(userId, offset, limit int) (*objs.ProfilePhototsResult, error) {
+	return bai.GetUserProfilePhotosContext(context.Background(), userId, offset, limit)
+}
+
+/*GetUserProfilePhotosContext works like "GetUserProfilePhotos" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetUserProfilePhotosContext(ctx context.Context, userId, offset, limit int) (*objs.ProfilePhototsResult, error) {
 	args := &objs.GetUserProfilePhototsArgs{UserId: userId, Offset: offset, Limit: limit}
-	res, err := bai.SendCustom("getUserProfilePhotos", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getUserProfilePhotos", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -735,8 +877,13 @@ func (bai *BotAPIInterface) GetUserProfilePhotos(userId, offset, limit int) (*ob
 
 /*GetFile gets the file based on the given file id and returns the file object. */
 func (bai *BotAPIInterface) GetFile(fileId string) (*objs.GetFileResult, error) {
+	return bai.GetFileContext(context.Background(), fileId)
+}
+
+/*GetFileContext works like "GetFile" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetFileContext(ctx context.Context, fileId string) (*objs.GetFileResult, error) {
 	args := &objs.GetFileArgs{FileId: fileId}
-	res, err := bai.SendCustom("getFile", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getFile", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -752,41 +899,47 @@ func (bai *BotAPIInterface) GetFile(fileId string) (*objs.GetFileResult, error)
 
 This method closes the given file. If the file is nil, this method will create a file based on the name of the file stored in telegram servers.*/
 func (bai *BotAPIInterface) DownloadFile(fileObject *objs.File, file *os.File) error {
-	url := "https://api.telegram.org/file/bot" + bai.botConfigs.APIKey + "/" + fileObject.FilePath
-	res, err := http.Get(url)
+	return bai.DownloadFileContext(context.Background(), fileObject, file)
+}
+
+/*DownloadFileContext works like "DownloadFile" but sends the request with the given context.*/
+func (bai *BotAPIInterface) DownloadFileContext(ctx context.Context, fileObject *objs.File, file *os.File) error {
+	body, _, err := bai.OpenFile(ctx, fileObject, 0)
 	if err != nil {
 		return err
 	}
+	defer body.Close()
 	if file == nil {
 		ar := strings.Split(fileObject.FilePath, "/")
 		name := ar[len(ar)-1]
 		var er error
-		file, er = os.OpenFile(name, os.O_CREATE|os.O_WRONLY, 0666)
+		file, er = os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
 		if er != nil {
 			return er
 		}
 	}
-	if res.StatusCode < 300 {
-		_, err2 := io.Copy(file, res.Body)
-		if err2 != nil {
-			return err2
-		}
-		err3 := file.Close()
-		return err3
-	} else {
-		return &errs.MethodNotSentError{Method: "getFile", Reason: "server returned status code " + strconv.Itoa(res.StatusCode)}
+	_, err2 := io.Copy(file, body)
+	err3 := file.Close()
+	if err2 != nil {
+		return err2
 	}
+	return err3
 }
 
 /*BanChatMember bans a chat member*/
 func (bai *BotAPIInterface) BanChatMember(chatIdInt int, chatIdString string, userId, untilDate int, revokeMessages bool) (*objs.LogicalResult, error) {
+	return bai.BanChatMemberContext(context.Background(), chatIdInt, chatIdString, userId, untilDate, revokeMessages)
+}
+
+/*BanChatMemberContext works like "BanChatMember" but sends the request with the given context.*/
+func (bai *BotAPIInterface) BanChatMemberContext(ctx context.Context, chatIdInt int, chatIdString string, userId, untilDate int, revokeMessages bool) (*objs.LogicalResult, error) {
 	args := &objs.BanChatMemberArgs{
 		UserId:         userId,
 		UntilDate:      untilDate,
 		RevokeMessages: revokeMessages,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("banChatMember", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "banChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -800,12 +953,17 @@ func (bai *BotAPIInterface) BanChatMember(chatIdInt int, chatIdString string, us
 
 /*UnbanChatMember unbans a chat member*/
 func (bai *BotAPIInterface) UnbanChatMember(chatIdInt int, chatIdString string, userId int, onlyIfBanned bool) (*objs.LogicalResult, error) {
+	return bai.UnbanChatMemberContext(context.Background(), chatIdInt, chatIdString, userId, onlyIfBanned)
+}
+
+/*UnbanChatMemberContext works like "UnbanChatMember" but sends the request with the given context.*/
+func (bai *BotAPIInterface) UnbanChatMemberContext(ctx context.Context, chatIdInt int, chatIdString string, userId int, onlyIfBanned bool) (*objs.LogicalResult, error) {
 	args := &objs.UnbanChatMemberArgsArgs{
 		UserId:       userId,
 		OnlyIfBanned: onlyIfBanned,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("unbanChatMember", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "unbanChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -819,12 +977,17 @@ func (bai *BotAPIInterface) UnbanChatMember(chatIdInt int, chatIdString string,
 
 /*RestrictChatMember restricts a chat member*/
 func (bai *BotAPIInterface) RestrictChatMember(chatIdInt int, chatIdString string, userId int, permissions objs.ChatPermissions, untilDate int) (*objs.LogicalResult, error) {
+	return bai.RestrictChatMemberContext(context.Background(), chatIdInt, chatIdString, userId, permissions, untilDate)
+}
+
+/*RestrictChatMemberContext works like "RestrictChatMember" but sends the request with the given context.*/
+func (bai *BotAPIInterface) RestrictChatMemberContext(ctx context.Context, chatIdInt int, chatIdString string, userId int, permissions objs.ChatPermissions, untilDate int) (*objs.LogicalResult, error) {
 	args := &objs.RestrictChatMemberArgs{
 		UserId:     userId,
 		Permission: permissions,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("restrictChatMember", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "restrictChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -838,6 +1001,11 @@ func (bai *BotAPIInterface) RestrictChatMember(chatIdInt int, chatIdString strin
 
 /*PromoteChatMember promotes a chat member*/
 func (bai *BotAPIInterface) PromoteChatMember(chatIdInt int, chatIdString string, userId int, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
+	return bai.PromoteChatMemberContext(context.Background(), chatIdInt, chatIdString, userId, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages)
+}
+
+/*PromoteChatMemberContext works like "PromoteChatMember" but sends the request with the given context.*/
+func (bai *BotAPIInterface) PromoteChatMemberContext(ctx context.Context, chatIdInt int, chatIdString string, userId int, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
 	args := &objs.PromoteChatMemberArgs{
 		UserId:              userId,
 		IsAnonymous:         isAnonymous,
@@ -853,7 +1021,7 @@ func (bai *BotAPIInterface) PromoteChatMember(chatIdInt int, chatIdString string
 		CanPinMessages:      canPinMessages,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("promoteChatMember", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "promoteChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -867,6 +1035,11 @@ func (bai *BotAPIInterface) PromoteChatMember(chatIdInt int, chatIdString string
 
 /*SetMyDefaultAdministratorRights sets the admin rights*/
 func (bai *BotAPIInterface) SetMyDefaultAdministratorRights(forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
+	return bai.SetMyDefaultAdministratorRightsContext(context.Background(), forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages)
+}
+
+/*SetMyDefaultAdministratorRightsContext works like "SetMyDefaultAdministratorRights" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetMyDefaultAdministratorRightsContext(ctx context.Context, forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.LogicalResult, error) {
 	args := &objs.MyDefaultAdministratorRightsArgs{
 		Rights: &objs.ChatAdministratorRights{
 			IsAnonymous:         isAnonymous,
@@ -883,7 +1056,7 @@ func (bai *BotAPIInterface) SetMyDefaultAdministratorRights(forChannels, isAnony
 		},
 		ForChannels: forChannels,
 	}
-	res, err := bai.SendCustom("setMyDefaultAdministratorRights", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setMyDefaultAdministratorRights", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -897,10 +1070,15 @@ func (bai *BotAPIInterface) SetMyDefaultAdministratorRights(forChannels, isAnony
 
 /*GetMyDefaultAdministratorRights gets the admin rights*/
 func (bai *BotAPIInterface) GetMyDefaultAdministratorRights(forChannels bool) (*objs.ChatAdministratorRightsResult, error) {
+	return bai.GetMyDefaultAdministratorRightsContext(context.Background(), forChannels)
+}
+
+/*GetMyDefaultAdministratorRightsContext works like "GetMyDefaultAdministratorRights" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetMyDefaultAdministratorRightsContext(ctx context.Context, forChannels bool) (*objs.ChatAdministratorRightsResult, error) {
 	args := &objs.MyDefaultAdministratorRightsArgs{
 		ForChannels: forChannels,
 	}
-	res, err := bai.SendCustom("getMyDefaultAdministratorRights", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getMyDefaultAdministratorRights", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -914,12 +1092,17 @@ func (bai *BotAPIInterface) GetMyDefaultAdministratorRights(forChannels bool) (*
 
 /*SetChatAdministratorCustomTitle sets a custom title for the administrator.*/
 func (bai *BotAPIInterface) SetChatAdministratorCustomTitle(chatIdInt int, chatIdString string, userId int, customTitle string) (*objs.LogicalResult, error) {
+	return bai.SetChatAdministratorCustomTitleContext(context.Background(), chatIdInt, chatIdString, userId, customTitle)
+}
+
+/*SetChatAdministratorCustomTitleContext works like "SetChatAdministratorCustomTitle" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetChatAdministratorCustomTitleContext(ctx context.Context, chatIdInt int, chatIdString string, userId int, customTitle string) (*objs.LogicalResult, error) {
 	args := &objs.SetChatAdministratorCustomTitleArgs{
 		UserId:      userId,
 		CustomTitle: customTitle,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("setChatAdministratorCustomTitle", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setChatAdministratorCustomTitle", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -933,6 +1116,11 @@ func (bai *BotAPIInterface) SetChatAdministratorCustomTitle(chatIdInt int, chatI
 
 /*BanOrUnbanChatSenderChat bans or unbans a channel in the group..*/
 func (bai *BotAPIInterface) BanOrUnbanChatSenderChat(chatIdInt int, chatIdString string, senderChatId int, ban bool) (*objs.LogicalResult, error) {
+	return bai.BanOrUnbanChatSenderChatContext(context.Background(), chatIdInt, chatIdString, senderChatId, ban)
+}
+
+/*BanOrUnbanChatSenderChatContext works like "BanOrUnbanChatSenderChat" but sends the request with the given context.*/
+func (bai *BotAPIInterface) BanOrUnbanChatSenderChatContext(ctx context.Context, chatIdInt int, chatIdString string, senderChatId int, ban bool) (*objs.LogicalResult, error) {
 	args := &objs.BanChatSenderChatArgs{
 		SenderChatId: senderChatId,
 	}
@@ -943,7 +1131,7 @@ func (bai *BotAPIInterface) BanOrUnbanChatSenderChat(chatIdInt int, chatIdString
 	} else {
 		method = "unbanChatSenderChat"
 	}
-	res, err := bai.SendCustom(method, args, false, nil)
+	res, err := bai.SendCustomContext(ctx, method, args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -957,11 +1145,16 @@ func (bai *BotAPIInterface) BanOrUnbanChatSenderChat(chatIdInt int, chatIdString
 
 /*SetChatPermissions sets default permissions for all users in the chat.*/
 func (bai *BotAPIInterface) SetChatPermissions(chatIdInt int, chatIdString string, permissions objs.ChatPermissions) (*objs.LogicalResult, error) {
+	return bai.SetChatPermissionsContext(context.Background(), chatIdInt, chatIdString, permissions)
+}
+
+/*SetChatPermissionsContext works like "SetChatPermissions" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetChatPermissionsContext(ctx context.Context, chatIdInt int, chatIdString string, permissions objs.ChatPermissions) (*objs.LogicalResult, error) {
 	args := &objs.SetChatPermissionsArgs{
 		Permissions: permissions,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("setChatPermissions", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setChatPermissions", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -975,9 +1168,14 @@ func (bai *BotAPIInterface) SetChatPermissions(chatIdInt int, chatIdString strin
 
 /*ExportChatInviteLink exports the chat invite link and returns the new invite link as string.*/
 func (bai *BotAPIInterface) ExportChatInviteLink(chatIdInt int, chatIdString string) (*objs.StringResult, error) {
+	return bai.ExportChatInviteLinkContext(context.Background(), chatIdInt, chatIdString)
+}
+
+/*ExportChatInviteLinkContext works like "ExportChatInviteLink" but sends the request with the given context.*/
+func (bai *BotAPIInterface) ExportChatInviteLinkContext(ctx context.Context, chatIdInt int, chatIdString string) (*objs.StringResult, error) {
 	args := &objs.DefaultChatArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("exprotChatInviteLink", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "exprotChatInviteLink", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -991,6 +1189,11 @@ func (bai *BotAPIInterface) ExportChatInviteLink(chatIdInt int, chatIdString str
 
 /*CreateChatInviteLink creates a new invite link for the chat.*/
 func (bai *BotAPIInterface) CreateChatInviteLink(chatIdInt int, chatIdString, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.ChatInviteLinkResult, error) {
+	return bai.CreateChatInviteLinkContext(context.Background(), chatIdInt, chatIdString, name, expireDate, memberLimit, createsJoinRequest)
+}
+
+/*CreateChatInviteLinkContext works like "CreateChatInviteLink" but sends the request with the given context.*/
+func (bai *BotAPIInterface) CreateChatInviteLinkContext(ctx context.Context, chatIdInt int, chatIdString, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.ChatInviteLinkResult, error) {
 	args := &objs.CreateChatInviteLinkArgs{
 		Name:               name,
 		ExpireDate:         expireDate,
@@ -998,7 +1201,7 @@ func (bai *BotAPIInterface) CreateChatInviteLink(chatIdInt int, chatIdString, na
 		CreatesjoinRequest: createsJoinRequest,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("createChatInviteLink", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "createChatInviteLink", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1012,6 +1215,11 @@ func (bai *BotAPIInterface) CreateChatInviteLink(chatIdInt int, chatIdString, na
 
 /*EditChatInviteLink edits an existing invite link for the chat.*/
 func (bai *BotAPIInterface) EditChatInviteLink(chatIdInt int, chatIdString, inviteLink, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.ChatInviteLinkResult, error) {
+	return bai.EditChatInviteLinkContext(context.Background(), chatIdInt, chatIdString, inviteLink, name, expireDate, memberLimit, createsJoinRequest)
+}
+
+/*EditChatInviteLinkContext works like "EditChatInviteLink" but sends the request with the given context.*/
+func (bai *BotAPIInterface) EditChatInviteLinkContext(ctx context.Context, chatIdInt int, chatIdString, inviteLink, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.ChatInviteLinkResult, error) {
 	args := &objs.EditChatInviteLinkArgs{
 		InviteLink:         inviteLink,
 		Name:               name,
@@ -1020,7 +1228,7 @@ func (bai *BotAPIInterface) EditChatInviteLink(chatIdInt int, chatIdString, invi
 		CreatesjoinRequest: createsJoinRequest,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("editChatInviteLink", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "editChatInviteLink", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1034,11 +1242,16 @@ func (bai *BotAPIInterface) EditChatInviteLink(chatIdInt int, chatIdString, invi
 
 /*RevokeChatInviteLink revokes the given invite link.*/
 func (bai *BotAPIInterface) RevokeChatInviteLink(chatIdInt int, chatIdString, inviteLink string) (*objs.ChatInviteLinkResult, error) {
+	return bai.RevokeChatInviteLinkContext(context.Background(), chatIdInt, chatIdString, inviteLink)
+}
+
+/*RevokeChatInviteLinkContext works like "RevokeChatInviteLink" but sends the request with the given context.*/
+func (bai *BotAPIInterface) RevokeChatInviteLinkContext(ctx context.Context, chatIdInt int, chatIdString, inviteLink string) (*objs.ChatInviteLinkResult, error) {
 	args := &objs.RevokeChatInviteLinkArgs{
 		InviteLink: inviteLink,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("revokeChatInviteLink", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "revokeChatInviteLink", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1052,11 +1265,16 @@ func (bai *BotAPIInterface) RevokeChatInviteLink(chatIdInt int, chatIdString, in
 
 /*ApproveChatJoinRequest approves a request from the given user to join the chat.*/
 func (bai *BotAPIInterface) ApproveChatJoinRequest(chatIdInt int, chatIdString string, userId int) (*objs.LogicalResult, error) {
+	return bai.ApproveChatJoinRequestContext(context.Background(), chatIdInt, chatIdString, userId)
+}
+
+/*ApproveChatJoinRequestContext works like "ApproveChatJoinRequest" but sends the request with the given context.*/
+func (bai *BotAPIInterface) ApproveChatJoinRequestContext(ctx context.Context, chatIdInt int, chatIdString string, userId int) (*objs.LogicalResult, error) {
 	args := &objs.ApproveChatJoinRequestArgs{
 		UserId: userId,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("approveChatJoinRequest", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "approveChatJoinRequest", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1070,11 +1288,16 @@ func (bai *BotAPIInterface) ApproveChatJoinRequest(chatIdInt int, chatIdString s
 
 /*DeclineChatJoinRequest declines a request from the given user to join the chat.*/
 func (bai *BotAPIInterface) DeclineChatJoinRequest(chatIdInt int, chatIdString string, userId int) (*objs.LogicalResult, error) {
+	return bai.DeclineChatJoinRequestContext(context.Background(), chatIdInt, chatIdString, userId)
+}
+
+/*DeclineChatJoinRequestContext works like "DeclineChatJoinRequest" but sends the request with the given context.*/
+func (bai *BotAPIInterface) DeclineChatJoinRequestContext(ctx context.Context, chatIdInt int, chatIdString string, userId int) (*objs.LogicalResult, error) {
 	args := &objs.DeclineChatJoinRequestArgs{
 		UserId: userId,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("declineChatJoinRequest", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "declineChatJoinRequest", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1087,15 +1310,20 @@ func (bai *BotAPIInterface) DeclineChatJoinRequest(chatIdInt int, chatIdString s
 }
 
 /*SetChatPhoto sets the chat photo to given file.*/
-func (bai *BotAPIInterface) SetChatPhoto(chatIdInt int, chatIdString string, file *os.File) (*objs.LogicalResult, error) {
+func (bai *BotAPIInterface) SetChatPhoto(chatIdInt int, chatIdString string, file io.Reader) (*objs.LogicalResult, error) {
+	return bai.SetChatPhotoContext(context.Background(), chatIdInt, chatIdString, file)
+}
+
+/*SetChatPhotoContext works like "SetChatPhoto" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetChatPhotoContext(ctx context.Context, chatIdInt int, chatIdString string, file io.Reader) (*objs.LogicalResult, error) {
 	args := &objs.SetChatPhotoArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	stats, er := file.Stat()
+	photo, er := attachName(file)
 	if er != nil {
 		return nil, er
 	}
-	args.Photo = "attach://" + stats.Name()
-	res, err := bai.SendCustom("setChatPhoto", args, true, file)
+	args.Photo = photo
+	res, err := bai.SendCustomContext(ctx, "setChatPhoto", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1109,9 +1337,14 @@ func (bai *BotAPIInterface) SetChatPhoto(chatIdInt int, chatIdString string, fil
 
 /*DeleteChatPhoto deletes chat photo.*/
 func (bai *BotAPIInterface) DeleteChatPhoto(chatIdInt int, chatIdString string) (*objs.LogicalResult, error) {
+	return bai.DeleteChatPhotoContext(context.Background(), chatIdInt, chatIdString)
+}
+
+/*DeleteChatPhotoContext works like "DeleteChatPhoto" but sends the request with the given context.*/
+func (bai *BotAPIInterface) DeleteChatPhotoContext(ctx context.Context, chatIdInt int, chatIdString string) (*objs.LogicalResult, error) {
 	args := &objs.DefaultChatArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("deleteChatPhoto", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "deleteChatPhoto", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1125,11 +1358,16 @@ func (bai *BotAPIInterface) DeleteChatPhoto(chatIdInt int, chatIdString string)
 
 /*SetChatTitle sets the chat title.*/
 func (bai *BotAPIInterface) SetChatTitle(chatIdInt int, chatIdString, title string) (*objs.LogicalResult, error) {
+	return bai.SetChatTitleContext(context.Background(), chatIdInt, chatIdString, title)
+}
+
+/*SetChatTitleContext works like "SetChatTitle" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetChatTitleContext(ctx context.Context, chatIdInt int, chatIdString, title string) (*objs.LogicalResult, error) {
 	args := &objs.SetChatTitleArgs{
 		Title: title,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("setChatTitle", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setChatTitle", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1143,11 +1381,16 @@ func (bai *BotAPIInterface) SetChatTitle(chatIdInt int, chatIdString, title stri
 
 /*SetChatDescription sets the chat description.*/
 func (bai *BotAPIInterface) SetChatDescription(chatIdInt int, chatIdString, descriptions string) (*objs.LogicalResult, error) {
+	return bai.SetChatDescriptionContext(context.Background(), chatIdInt, chatIdString, descriptions)
+}
+
+/*SetChatDescriptionContext works like "SetChatDescription" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetChatDescriptionContext(ctx context.Context, chatIdInt int, chatIdString, descriptions string) (*objs.LogicalResult, error) {
 	args := &objs.SetChatDescriptionArgs{
 		Description: descriptions,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("setChatDescription", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setChatDescription", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1161,12 +1404,17 @@ func (bai *BotAPIInterface) SetChatDescription(chatIdInt int, chatIdString, desc
 
 /*PinChatMessage pins the message in the chat.*/
 func (bai *BotAPIInterface) PinChatMessage(chatIdInt int, chatIdString string, messageId int, disableNotification bool) (*objs.LogicalResult, error) {
+	return bai.PinChatMessageContext(context.Background(), chatIdInt, chatIdString, messageId, disableNotification)
+}
+
+/*PinChatMessageContext works like "PinChatMessage" but sends the request with the given context.*/
+func (bai *BotAPIInterface) PinChatMessageContext(ctx context.Context, chatIdInt int, chatIdString string, messageId int, disableNotification bool) (*objs.LogicalResult, error) {
 	args := &objs.PinChatMessageArgs{
 		MessageId:           messageId,
 		DisableNotification: disableNotification,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("pinChatMessage", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "pinChatMessage", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1180,11 +1428,16 @@ func (bai *BotAPIInterface) PinChatMessage(chatIdInt int, chatIdString string, m
 
 /*UnpinChatMessage unpins the pinned message in the chat.*/
 func (bai *BotAPIInterface) UnpinChatMessage(chatIdInt int, chatIdString string, messageId int) (*objs.LogicalResult, error) {
+	return bai.UnpinChatMessageContext(context.Background(), chatIdInt, chatIdString, messageId)
+}
+
+/*UnpinChatMessageContext works like "UnpinChatMessage" but sends the request with the given context.*/
+func (bai *BotAPIInterface) UnpinChatMessageContext(ctx context.Context, chatIdInt int, chatIdString string, messageId int) (*objs.LogicalResult, error) {
 	args := &objs.UnpinChatMessageArgs{
 		MessageId: messageId,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("unpinChatMessage", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "unpinChatMessage", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1198,9 +1451,14 @@ func (bai *BotAPIInterface) UnpinChatMessage(chatIdInt int, chatIdString string,
 
 /*UnpinAllChatMessages unpins all the pinned messages in the chat.*/
 func (bai *BotAPIInterface) UnpinAllChatMessages(chatIdInt int, chatIdString string) (*objs.LogicalResult, error) {
+	return bai.UnpinAllChatMessagesContext(context.Background(), chatIdInt, chatIdString)
+}
+
+/*UnpinAllChatMessagesContext works like "UnpinAllChatMessages" but sends the request with the given context.*/
+func (bai *BotAPIInterface) UnpinAllChatMessagesContext(ctx context.Context, chatIdInt int, chatIdString string) (*objs.LogicalResult, error) {
 	args := &objs.DefaultChatArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("unpinAllChatMessages", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "unpinAllChatMessages", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1214,9 +1472,14 @@ func (bai *BotAPIInterface) UnpinAllChatMessages(chatIdInt int, chatIdString str
 
 /*LeaveChat, the bot will leave the chat if this method is called.*/
 func (bai *BotAPIInterface) LeaveChat(chatIdInt int, chatIdString string) (*objs.LogicalResult, error) {
+	return bai.LeaveChatContext(context.Background(), chatIdInt, chatIdString)
+}
+
+/*LeaveChatContext works like "LeaveChat" but sends the request with the given context.*/
+func (bai *BotAPIInterface) LeaveChatContext(ctx context.Context, chatIdInt int, chatIdString string) (*objs.LogicalResult, error) {
 	args := &objs.DefaultChatArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("leaveChat", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "leaveChat", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1230,9 +1493,14 @@ func (bai *BotAPIInterface) LeaveChat(chatIdInt int, chatIdString string) (*objs
 
 /*GetChat : a Chat object containing the information of the chat will be returned*/
 func (bai *BotAPIInterface) GetChat(chatIdInt int, chatIdString string) (*objs.ChatResult, error) {
+	return bai.GetChatContext(context.Background(), chatIdInt, chatIdString)
+}
+
+/*GetChatContext works like "GetChat" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetChatContext(ctx context.Context, chatIdInt int, chatIdString string) (*objs.ChatResult, error) {
 	args := &objs.DefaultChatArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("getChat", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getChat", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1246,9 +1514,14 @@ func (bai *BotAPIInterface) GetChat(chatIdInt int, chatIdString string) (*objs.C
 
 /*GetChatAdministrators returns an array of ChatMember containing the informations of the chat administrators.*/
 func (bai *BotAPIInterface) GetChatAdministrators(chatIdInt int, chatIdString string) (*objs.ChatAdministratorsResult, error) {
+	return bai.GetChatAdministratorsContext(context.Background(), chatIdInt, chatIdString)
+}
+
+/*GetChatAdministratorsContext works like "GetChatAdministrators" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetChatAdministratorsContext(ctx context.Context, chatIdInt int, chatIdString string) (*objs.ChatAdministratorsResult, error) {
 	args := &objs.DefaultChatArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("getChatAdministrators", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getChatAdministrators", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1262,9 +1535,14 @@ func (bai *BotAPIInterface) GetChatAdministrators(chatIdInt int, chatIdString st
 
 /* GetChatMemberCount returns the number of the memebrs of the chat.*/
 func (bai *BotAPIInterface) GetChatMemberCount(chatIdInt int, chatIdString string) (*objs.IntResult, error) {
+	return bai.GetChatMemberCountContext(context.Background(), chatIdInt, chatIdString)
+}
+
+/*GetChatMemberCountContext works like "GetChatMemberCount" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetChatMemberCountContext(ctx context.Context, chatIdInt int, chatIdString string) (*objs.IntResult, error) {
 	args := &objs.DefaultChatArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("getChatMemberCount", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getChatMemberCount", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1278,11 +1556,16 @@ func (bai *BotAPIInterface) GetChatMemberCount(chatIdInt int, chatIdString strin
 
 /*GetChatMember returns the information of the member in a ChatMember object.*/
 func (bai *BotAPIInterface) GetChatMember(chatIdInt int, chatIdString string, userId int) (*objs.DefaultResult, error) {
+	return bai.GetChatMemberContext(context.Background(), chatIdInt, chatIdString, userId)
+}
+
+/*GetChatMemberContext works like "GetChatMember" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetChatMemberContext(ctx context.Context, chatIdInt int, chatIdString string, userId int) (*objs.DefaultResult, error) {
 	args := &objs.GetChatMemberArgs{
 		UserId: userId,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("getChatMember", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getChatMember", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1296,11 +1579,16 @@ func (bai *BotAPIInterface) GetChatMember(chatIdInt int, chatIdString string, us
 
 /*SetChatStickerSet sets the sticker set of the chat.*/
 func (bai *BotAPIInterface) SetChatStickerSet(chatIdInt int, chatIdString, stickerSetName string) (*objs.LogicalResult, error) {
+	return bai.SetChatStickerSetContext(context.Background(), chatIdInt, chatIdString, stickerSetName)
+}
+
+/*SetChatStickerSetContext works like "SetChatStickerSet" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetChatStickerSetContext(ctx context.Context, chatIdInt int, chatIdString, stickerSetName string) (*objs.LogicalResult, error) {
 	args := &objs.SetChatStcikerSet{
 		StickerSetName: stickerSetName,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("setChatStickerSet", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setChatStickerSet", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1314,9 +1602,14 @@ func (bai *BotAPIInterface) SetChatStickerSet(chatIdInt int, chatIdString, stick
 
 /*DeleteChatStickerSet deletes the sticker set of the chat..*/
 func (bai *BotAPIInterface) DeleteChatStickerSet(chatIdInt int, chatIdString string) (*objs.LogicalResult, error) {
+	return bai.DeleteChatStickerSetContext(context.Background(), chatIdInt, chatIdString)
+}
+
+/*DeleteChatStickerSetContext works like "DeleteChatStickerSet" but sends the request with the given context.*/
+func (bai *BotAPIInterface) DeleteChatStickerSetContext(ctx context.Context, chatIdInt int, chatIdString string) (*objs.LogicalResult, error) {
 	args := &objs.DefaultChatArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("deleteChatStickerSet", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "deleteChatStickerSet", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1330,6 +1623,11 @@ func (bai *BotAPIInterface) DeleteChatStickerSet(chatIdInt int, chatIdString str
 
 /*AnswerCallbackQuery answers a callback query*/
 func (bai *BotAPIInterface) AnswerCallbackQuery(callbackQueryId, text, url string, showAlert bool, CacheTime int) (*objs.LogicalResult, error) {
+	return bai.AnswerCallbackQueryContext(context.Background(), callbackQueryId, text, url, showAlert, CacheTime)
+}
+
+/*AnswerCallbackQueryContext works like "AnswerCallbackQuery" but sends the request with the given context.*/
+func (bai *BotAPIInterface) AnswerCallbackQueryContext(ctx context.Context, callbackQueryId, text, url string, showAlert bool, CacheTime int) (*objs.LogicalResult, error) {
 	args := &objs.AnswerCallbackQueryArgs{
 		CallbackQueyId: callbackQueryId,
 		Text:           text,
@@ -1337,7 +1635,7 @@ func (bai *BotAPIInterface) AnswerCallbackQuery(callbackQueryId, text, url strin
 		URL:            url,
 		CacheTime:      CacheTime,
 	}
-	res, err := bai.SendCustom("answerCallbackQuery", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "answerCallbackQuery", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1351,6 +1649,11 @@ func (bai *BotAPIInterface) AnswerCallbackQuery(callbackQueryId, text, url strin
 
 /*SetMyCommands sets the commands of the bot*/
 func (bai *BotAPIInterface) SetMyCommands(commands []objs.BotCommand, scope objs.BotCommandScope, languageCode string) (*objs.LogicalResult, error) {
+	return bai.SetMyCommandsContext(context.Background(), commands, scope, languageCode)
+}
+
+/*SetMyCommandsContext works like "SetMyCommands" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetMyCommandsContext(ctx context.Context, commands []objs.BotCommand, scope objs.BotCommandScope, languageCode string) (*objs.LogicalResult, error) {
 	args := &objs.SetMyCommandsArgs{
 		Commands: commands,
 		MyCommandsDefault: objs.MyCommandsDefault{
@@ -1358,7 +1661,7 @@ func (bai *BotAPIInterface) SetMyCommands(commands []objs.BotCommand, scope objs
 			LanguageCode: languageCode,
 		},
 	}
-	res, err := bai.SendCustom("setMyCommands", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setMyCommands", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1372,11 +1675,16 @@ func (bai *BotAPIInterface) SetMyCommands(commands []objs.BotCommand, scope objs
 
 /*DeleteMyCommands deletes the commands of the bot*/
 func (bai *BotAPIInterface) DeleteMyCommands(scope objs.BotCommandScope, languageCode string) (*objs.LogicalResult, error) {
+	return bai.DeleteMyCommandsContext(context.Background(), scope, languageCode)
+}
+
+/*DeleteMyCommandsContext works like "DeleteMyCommands" but sends the request with the given context.*/
+func (bai *BotAPIInterface) DeleteMyCommandsContext(ctx context.Context, scope objs.BotCommandScope, languageCode string) (*objs.LogicalResult, error) {
 	args := &objs.MyCommandsDefault{
 		Scope:        scope,
 		LanguageCode: languageCode,
 	}
-	res, err := bai.SendCustom("deleteMyCommands", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "deleteMyCommands", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1390,11 +1698,16 @@ func (bai *BotAPIInterface) DeleteMyCommands(scope objs.BotCommandScope, languag
 
 /*GetMyCommands gets the commands of the bot*/
 func (bai *BotAPIInterface) GetMyCommands(scope objs.BotCommandScope, languageCode string) (*objs.GetCommandsResult, error) {
+	return bai.GetMyCommandsContext(context.Background(), scope, languageCode)
+}
+
+/*GetMyCommandsContext works like "GetMyCommands" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetMyCommandsContext(ctx context.Context, scope objs.BotCommandScope, languageCode string) (*objs.GetCommandsResult, error) {
 	args := &objs.MyCommandsDefault{
 		Scope:        scope,
 		LanguageCode: languageCode,
 	}
-	res, err := bai.SendCustom("getMyCommands", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getMyCommands", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1408,6 +1721,11 @@ func (bai *BotAPIInterface) GetMyCommands(scope objs.BotCommandScope, languageCo
 
 /*EditMessageText edits the text of the given message in the given chat.*/
 func (bai *BotAPIInterface) EditMessageText(chatIdInt int, chatIdString string, messageId int, inlineMessageId, text, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, replyMakrup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
+	return bai.EditMessageTextContext(context.Background(), chatIdInt, chatIdString, messageId, inlineMessageId, text, parseMode, entities, disableWebPagePreview, replyMakrup)
+}
+
+/*EditMessageTextContext works like "EditMessageText" but sends the request with the given context.*/
+func (bai *BotAPIInterface) EditMessageTextContext(ctx context.Context, chatIdInt int, chatIdString string, messageId int, inlineMessageId, text, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, replyMakrup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
 	args := &objs.EditMessageTextArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1420,7 +1738,7 @@ func (bai *BotAPIInterface) EditMessageText(chatIdInt int, chatIdString string,
 		DisablewebpagePreview: disableWebPagePreview,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("editMessageText", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "editMessageText", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1434,6 +1752,11 @@ func (bai *BotAPIInterface) EditMessageText(chatIdInt int, chatIdString string,
 
 /*EditMessageCaption edits the caption of the given message in the given chat.*/
 func (bai *BotAPIInterface) EditMessageCaption(chatIdInt int, chatIdString string, messageId int, inlineMessageId, caption, parseMode string, captionEntities []objs.MessageEntity, replyMakrup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
+	return bai.EditMessageCaptionContext(context.Background(), chatIdInt, chatIdString, messageId, inlineMessageId, caption, parseMode, captionEntities, replyMakrup)
+}
+
+/*EditMessageCaptionContext works like "EditMessageCaption" but sends the request with the given context.*/
+func (bai *BotAPIInterface) EditMessageCaptionContext(ctx context.Context, chatIdInt int, chatIdString string, messageId int, inlineMessageId, caption, parseMode string, captionEntities []objs.MessageEntity, replyMakrup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
 	args := &objs.EditMessageCaptionArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1445,7 +1768,7 @@ func (bai *BotAPIInterface) EditMessageCaption(chatIdInt int, chatIdString strin
 		CaptionEntities: captionEntities,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("editMessageCaption", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "editMessageCaption", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1458,7 +1781,12 @@ func (bai *BotAPIInterface) EditMessageCaption(chatIdInt int, chatIdString strin
 }
 
 /*EditMessageMedia edits the media of the given message in the given chat.*/
-func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...*os.File) (*objs.DefaultResult, error) {
+func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...io.Reader) (*objs.DefaultResult, error) {
+	return bai.EditMessageMediaContext(context.Background(), chatIdInt, chatIdString, messageId, inlineMessageId, media, replyMakrup, file...)
+}
+
+/*EditMessageMediaContext works like "EditMessageMedia" but sends the request with the given context.*/
+func (bai *BotAPIInterface) EditMessageMediaContext(ctx context.Context, chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...io.Reader) (*objs.DefaultResult, error) {
 	args := &objs.EditMessageMediaArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1468,7 +1796,7 @@ func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string,
 		Media: media,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("editMessageMedia", args, true, file...)
+	res, err := bai.SendCustomContext(ctx, "editMessageMedia", args, true, file...)
 	if err != nil {
 		return nil, err
 	}
@@ -1482,6 +1810,11 @@ func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string,
 
 /*EditMessagereplyMarkup edits the reply makrup of the given message in the given chat.*/
 func (bai *BotAPIInterface) EditMessagereplyMarkup(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, replyMakrup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
+	return bai.EditMessagereplyMarkupContext(context.Background(), chatIdInt, chatIdString, messageId, inlineMessageId, replyMakrup)
+}
+
+/*EditMessagereplyMarkupContext works like "EditMessagereplyMarkup" but sends the request with the given context.*/
+func (bai *BotAPIInterface) EditMessagereplyMarkupContext(ctx context.Context, chatIdInt int, chatIdString string, messageId int, inlineMessageId string, replyMakrup *objs.InlineKeyboardMarkup) (*objs.DefaultResult, error) {
 	args := &objs.EditMessageReplyMakrupArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1490,7 +1823,7 @@ func (bai *BotAPIInterface) EditMessagereplyMarkup(chatIdInt int, chatIdString s
 		},
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("editMessageReplyMarkup", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "editMessageReplyMarkup", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1504,12 +1837,17 @@ func (bai *BotAPIInterface) EditMessagereplyMarkup(chatIdInt int, chatIdString s
 
 /*StopPoll stops the poll.*/
 func (bai *BotAPIInterface) StopPoll(chatIdInt int, chatIdString string, messageId int, replyMakrup *objs.InlineKeyboardMarkup) (*objs.PollResult, error) {
+	return bai.StopPollContext(context.Background(), chatIdInt, chatIdString, messageId, replyMakrup)
+}
+
+/*StopPollContext works like "StopPoll" but sends the request with the given context.*/
+func (bai *BotAPIInterface) StopPollContext(ctx context.Context, chatIdInt int, chatIdString string, messageId int, replyMakrup *objs.InlineKeyboardMarkup) (*objs.PollResult, error) {
 	args := &objs.StopPollArgs{
 		MessageId:   messageId,
 		ReplyMarkup: replyMakrup,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("stopPoll", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "stopPoll", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1523,11 +1861,16 @@ func (bai *BotAPIInterface) StopPoll(chatIdInt int, chatIdString string, message
 
 /*DeleteMessage deletes the given message int the given chat.*/
 func (bai *BotAPIInterface) DeleteMessage(chatIdInt int, chatIdString string, messageId int) (*objs.LogicalResult, error) {
+	return bai.DeleteMessageContext(context.Background(), chatIdInt, chatIdString, messageId)
+}
+
+/*DeleteMessageContext works like "DeleteMessage" but sends the request with the given context.*/
+func (bai *BotAPIInterface) DeleteMessageContext(ctx context.Context, chatIdInt int, chatIdString string, messageId int) (*objs.LogicalResult, error) {
 	args := &objs.DeleteMessageArgs{
 		MessageId: messageId,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("deleteMessage", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "deleteMessage", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1540,7 +1883,12 @@ func (bai *BotAPIInterface) DeleteMessage(chatIdInt int, chatIdString string, me
 }
 
 /*SendSticker sends an sticker to the given chat id.*/
-func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker string, disableNotif, allowSendingWithoutreply, protectContent bool, replyTo int, replyMarkup objs.ReplyMarkup, file *os.File) (*objs.SendMethodsResult, error) {
+func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker string, disableNotif, allowSendingWithoutreply, protectContent bool, replyTo int, replyMarkup objs.ReplyMarkup, file io.Reader) (*objs.SendMethodsResult, error) {
+	return bai.SendStickerContext(context.Background(), chatIdInt, chatIdString, sticker, disableNotif, allowSendingWithoutreply, protectContent, replyTo, replyMarkup, file)
+}
+
+/*SendStickerContext works like "SendSticker" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendStickerContext(ctx context.Context, chatIdInt int, chatIdString, sticker string, disableNotif, allowSendingWithoutreply, protectContent bool, replyTo int, replyMarkup objs.ReplyMarkup, file io.Reader) (*objs.SendMethodsResult, error) {
 	args := &objs.SendStickerArgs{
 		DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{
 			DisableNotification:      disableNotif,
@@ -1552,7 +1900,7 @@ func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker str
 		Sticker: sticker,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("sendSticker", args, true, file)
+	res, err := bai.SendCustomContext(ctx, "sendSticker", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1566,10 +1914,15 @@ func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker str
 
 /*GetStickerSet gets the sticker set by the given name*/
 func (bai *BotAPIInterface) GetStickerSet(name string) (*objs.StickerSetResult, error) {
+	return bai.GetStickerSetContext(context.Background(), name)
+}
+
+/*GetStickerSetContext works like "GetStickerSet" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetStickerSetContext(ctx context.Context, name string) (*objs.StickerSetResult, error) {
 	args := &objs.GetStickerSetArgs{
 		Name: name,
 	}
-	res, err := bai.SendCustom("getStickerSet", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getStickerSet", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1582,12 +1935,17 @@ func (bai *BotAPIInterface) GetStickerSet(name string) (*objs.StickerSetResult,
 }
 
 /*UploadStickerFile uploads the given file as an sticker on the telegram servers.*/
-func (bai *BotAPIInterface) UploadStickerFile(userId int, pngSticker string, file *os.File) (*objs.GetFileResult, error) {
+func (bai *BotAPIInterface) UploadStickerFile(userId int, pngSticker string, file io.Reader) (*objs.GetFileResult, error) {
+	return bai.UploadStickerFileContext(context.Background(), userId, pngSticker, file)
+}
+
+/*UploadStickerFileContext works like "UploadStickerFile" but sends the request with the given context.*/
+func (bai *BotAPIInterface) UploadStickerFileContext(ctx context.Context, userId int, pngSticker string, file io.Reader) (*objs.GetFileResult, error) {
 	args := &objs.UploadStickerFileArgs{
 		UserId:     userId,
 		PngSticker: pngSticker,
 	}
-	res, err := bai.SendCustom("uploadStickerFile", args, true, file)
+	res, err := bai.SendCustomContext(ctx, "uploadStickerFile", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1600,7 +1958,12 @@ func (bai *BotAPIInterface) UploadStickerFile(userId int, pngSticker string, fil
 }
 
 /*CreateNewStickerSet creates a new sticker set with the given arguments*/
-func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, pngSticker, tgsSticker, webmSticker, emojies string, containsMasks bool, maskPosition *objs.MaskPosition, file *os.File) (*objs.LogicalResult, error) {
+func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, pngSticker, tgsSticker, webmSticker, emojies string, containsMasks bool, maskPosition *objs.MaskPosition, file io.Reader) (*objs.LogicalResult, error) {
+	return bai.CreateNewStickerSetContext(context.Background(), userId, name, title, pngSticker, tgsSticker, webmSticker, emojies, containsMasks, maskPosition, file)
+}
+
+/*CreateNewStickerSetContext works like "CreateNewStickerSet" but sends the request with the given context.*/
+func (bai *BotAPIInterface) CreateNewStickerSetContext(ctx context.Context, userId int, name, title, pngSticker, tgsSticker, webmSticker, emojies string, containsMasks bool, maskPosition *objs.MaskPosition, file io.Reader) (*objs.LogicalResult, error) {
 	args := &objs.CreateNewStickerSetArgs{
 		UserId:        userId,
 		Name:          name,
@@ -1612,7 +1975,7 @@ func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, pngStic
 		ContainsMasks: containsMasks,
 		MaskPosition:  maskPosition,
 	}
-	res, err := bai.SendCustom("createNewStickerSet", args, true, file)
+	res, err := bai.SendCustomContext(ctx, "createNewStickerSet", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1625,7 +1988,12 @@ func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, pngStic
 }
 
 /*AddStickerToSet adds a new sticker to the given set.*/
-func (bai *BotAPIInterface) AddStickerToSet(userId int, name, pngSticker, tgsSticker, webmSticker, emojies string, maskPosition *objs.MaskPosition, file *os.File) (*objs.LogicalResult, error) {
+func (bai *BotAPIInterface) AddStickerToSet(userId int, name, pngSticker, tgsSticker, webmSticker, emojies string, maskPosition *objs.MaskPosition, file io.Reader) (*objs.LogicalResult, error) {
+	return bai.AddStickerToSetContext(context.Background(), userId, name, pngSticker, tgsSticker, webmSticker, emojies, maskPosition, file)
+}
+
+/*AddStickerToSetContext works like "AddStickerToSet" but sends the request with the given context.*/
+func (bai *BotAPIInterface) AddStickerToSetContext(ctx context.Context, userId int, name, pngSticker, tgsSticker, webmSticker, emojies string, maskPosition *objs.MaskPosition, file io.Reader) (*objs.LogicalResult, error) {
 	args := &objs.AddStickerSetArgs{
 		UserId:       userId,
 		Name:         name,
@@ -1635,7 +2003,7 @@ func (bai *BotAPIInterface) AddStickerToSet(userId int, name, pngSticker, tgsSti
 		Emojis:       emojies,
 		MaskPosition: maskPosition,
 	}
-	res, err := bai.SendCustom("addStickerToSet", args, true, file)
+	res, err := bai.SendCustomContext(ctx, "addStickerToSet", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1649,11 +2017,16 @@ func (bai *BotAPIInterface) AddStickerToSet(userId int, name, pngSticker, tgsSti
 
 /*SetStickerPositionInSet sets the position of a sticker in an sticker set*/
 func (bai *BotAPIInterface) SetStickerPositionInSet(sticker string, position int) (*objs.LogicalResult, error) {
+	return bai.SetStickerPositionInSetContext(context.Background(), sticker, position)
+}
+
+/*SetStickerPositionInSetContext works like "SetStickerPositionInSet" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetStickerPositionInSetContext(ctx context.Context, sticker string, position int) (*objs.LogicalResult, error) {
 	args := &objs.SetStickerPositionInSetArgs{
 		Sticker:  sticker,
 		Position: position,
 	}
-	res, err := bai.SendCustom("setStickerPositionInSet", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setStickerPositionInSet", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1667,10 +2040,15 @@ func (bai *BotAPIInterface) SetStickerPositionInSet(sticker string, position int
 
 /*DeleteStickerFromSet deletes the given sticker from a set created by the bot*/
 func (bai *BotAPIInterface) DeleteStickerFromSet(sticker string) (*objs.LogicalResult, error) {
+	return bai.DeleteStickerFromSetContext(context.Background(), sticker)
+}
+
+/*DeleteStickerFromSetContext works like "DeleteStickerFromSet" but sends the request with the given context.*/
+func (bai *BotAPIInterface) DeleteStickerFromSetContext(ctx context.Context, sticker string) (*objs.LogicalResult, error) {
 	args := &objs.DeleteStickerFromSetArgs{
 		Sticker: sticker,
 	}
-	res, err := bai.SendCustom("deleteStickerFromSet", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "deleteStickerFromSet", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1683,13 +2061,18 @@ func (bai *BotAPIInterface) DeleteStickerFromSet(sticker string) (*objs.LogicalR
 }
 
 /*SetStickerSetThumb sets the thumbnail for the given sticker*/
-func (bai *BotAPIInterface) SetStickerSetThumb(name, thumb string, userId int, file *os.File) (*objs.LogicalResult, error) {
+func (bai *BotAPIInterface) SetStickerSetThumb(name, thumb string, userId int, file io.Reader) (*objs.LogicalResult, error) {
+	return bai.SetStickerSetThumbContext(context.Background(), name, thumb, userId, file)
+}
+
+/*SetStickerSetThumbContext works like "SetStickerSetThumb" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetStickerSetThumbContext(ctx context.Context, name, thumb string, userId int, file io.Reader) (*objs.LogicalResult, error) {
 	args := &objs.SetStickerSetThumbArgs{
 		Name:   name,
 		Thumb:  thumb,
 		UserId: userId,
 	}
-	res, err := bai.SendCustom("setStickerSetThumb", args, true, file)
+	res, err := bai.SendCustomContext(ctx, "setStickerSetThumb", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1703,6 +2086,11 @@ func (bai *BotAPIInterface) SetStickerSetThumb(name, thumb string, userId int, f
 
 /*AnswerInlineQuery answers an inline query with the given parameters*/
 func (bai *BotAPIInterface) AnswerInlineQuery(inlineQueryId string, results []objs.InlineQueryResult, cacheTime int, isPersonal bool, nextOffset, switchPmText, switchPmParameter string) (*objs.LogicalResult, error) {
+	return bai.AnswerInlineQueryContext(context.Background(), inlineQueryId, results, cacheTime, isPersonal, nextOffset, switchPmText, switchPmParameter)
+}
+
+/*AnswerInlineQueryContext works like "AnswerInlineQuery" but sends the request with the given context.*/
+func (bai *BotAPIInterface) AnswerInlineQueryContext(ctx context.Context, inlineQueryId string, results []objs.InlineQueryResult, cacheTime int, isPersonal bool, nextOffset, switchPmText, switchPmParameter string) (*objs.LogicalResult, error) {
 	args := &objs.AnswerInlineQueryArgs{
 		InlineQueryId:     inlineQueryId,
 		Results:           results,
@@ -1712,7 +2100,7 @@ func (bai *BotAPIInterface) AnswerInlineQuery(inlineQueryId string, results []ob
 		SwitchPmText:      switchPmText,
 		SwitchPmParameter: switchPmParameter,
 	}
-	res, err := bai.SendCustom("answerInlineQuery", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "answerInlineQuery", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1726,6 +2114,11 @@ func (bai *BotAPIInterface) AnswerInlineQuery(inlineQueryId string, results []ob
 
 /*SendInvoice sends an invoice*/
 func (bai *BotAPIInterface) SendInvoice(chatIdInt int, chatIdString, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, disableNotif bool, replyToMessageId int, allowSendingWithoutReply bool, replyMarkup objs.InlineKeyboardMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendInvoiceContext(context.Background(), chatIdInt, chatIdString, title, description, payload, providerToken, currency, prices, maxTipAmount, suggestedTipAmounts, startParameter, providerData, photoURL, photoSize, photoWidth, photoHeight, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, disableNotif, replyToMessageId, allowSendingWithoutReply, replyMarkup)
+}
+
+/*SendInvoiceContext works like "SendInvoice" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendInvoiceContext(ctx context.Context, chatIdInt int, chatIdString, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, disableNotif bool, replyToMessageId int, allowSendingWithoutReply bool, replyMarkup objs.InlineKeyboardMarkup) (*objs.SendMethodsResult, error) {
 	args := &objs.SendInvoiceArgs{
 		DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{
 			DisableNotification:      disableNotif,
@@ -1756,7 +2149,7 @@ func (bai *BotAPIInterface) SendInvoice(chatIdInt int, chatIdString, title, desc
 		IsFlexible:                isFlexible,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("sendInvoice", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "sendInvoice", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1770,6 +2163,11 @@ func (bai *BotAPIInterface) SendInvoice(chatIdInt int, chatIdString, title, desc
 
 /*CreateInvoiceLink sends an invoice*/
 func (bai *BotAPIInterface) CreateInvoiceLink(title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible bool) (*objs.StringResult, error) {
+	return bai.CreateInvoiceLinkContext(context.Background(), title, description, payload, providerToken, currency, prices, maxTipAmount, suggestedTipAmounts, providerData, photoURL, photoSize, photoWidth, photoHeight, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible)
+}
+
+/*CreateInvoiceLinkContext works like "CreateInvoiceLink" but sends the request with the given context.*/
+func (bai *BotAPIInterface) CreateInvoiceLinkContext(ctx context.Context, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible bool) (*objs.StringResult, error) {
 	args := &objs.SendInvoiceArgs{
 		Title:                     title,
 		Description:               description,
@@ -1792,7 +2190,7 @@ func (bai *BotAPIInterface) CreateInvoiceLink(title, description, payload, provi
 		SendEmailToProvider:       sendEmailToProvider,
 		IsFlexible:                isFlexible,
 	}
-	res, err := bai.SendCustom("createInvoiceLink", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "createInvoiceLink", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1806,13 +2204,18 @@ func (bai *BotAPIInterface) CreateInvoiceLink(title, description, payload, provi
 
 /*AnswerShippingQuery answers a shipping query*/
 func (bai *BotAPIInterface) AnswerShippingQuery(shippingQueryId string, ok bool, shippingOptions []objs.ShippingOption, errorMessage string) (*objs.LogicalResult, error) {
+	return bai.AnswerShippingQueryContext(context.Background(), shippingQueryId, ok, shippingOptions, errorMessage)
+}
+
+/*AnswerShippingQueryContext works like "AnswerShippingQuery" but sends the request with the given context.*/
+func (bai *BotAPIInterface) AnswerShippingQueryContext(ctx context.Context, shippingQueryId string, ok bool, shippingOptions []objs.ShippingOption, errorMessage string) (*objs.LogicalResult, error) {
 	args := &objs.AnswerShippingQueryArgs{
 		ShippingQueryId: shippingQueryId,
 		OK:              ok,
 		ShippingOptions: shippingOptions,
 		ErrorMessage:    errorMessage,
 	}
-	res, err := bai.SendCustom("answerShippingQuery", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "answerShippingQuery", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1826,12 +2229,17 @@ func (bai *BotAPIInterface) AnswerShippingQuery(shippingQueryId string, ok bool,
 
 /*AnswerPreCheckoutQuery answers a pre checkout query*/
 func (bai *BotAPIInterface) AnswerPreCheckoutQuery(preCheckoutQueryId string, ok bool, errorMessage string) (*objs.LogicalResult, error) {
+	return bai.AnswerPreCheckoutQueryContext(context.Background(), preCheckoutQueryId, ok, errorMessage)
+}
+
+/*AnswerPreCheckoutQueryContext works like "AnswerPreCheckoutQuery" but sends the request with the given context.*/
+func (bai *BotAPIInterface) AnswerPreCheckoutQueryContext(ctx context.Context, preCheckoutQueryId string, ok bool, errorMessage string) (*objs.LogicalResult, error) {
 	args := &objs.AnswerPreCheckoutQueryArgs{
 		PreCheckoutQueryId: preCheckoutQueryId,
 		Ok:                 ok,
 		ErrorMessage:       errorMessage,
 	}
-	res, err := bai.SendCustom("answerPreCheckoutQuery", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "answerPreCheckoutQuery", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1846,6 +2254,11 @@ func (bai *BotAPIInterface) AnswerPreCheckoutQuery(preCheckoutQueryId string, ok
 /*CopyMessage copies a message from a user or channel and sends it to a user or channel. If the source or destination (or both) of the forwarded message is a channel, only string chat ids should be given to the function, and if it is user only int chat ids should be given.
 "chatId", "fromChatId" and "messageId" arguments are required. other arguments are optional for bot api.*/
 func (bai *BotAPIInterface) CopyMessage(chatIdInt, fromChatIdInt int, chatIdString, fromChatIdString string, messageId int, disableNotif bool, caption, parseMode string, replyTo int, allowSendingWihtoutReply, ProtectContent bool, replyMarkUp objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.SendMethodsResult, error) {
+	return bai.CopyMessageContext(context.Background(), chatIdInt, fromChatIdInt, chatIdString, fromChatIdString, messageId, disableNotif, caption, parseMode, replyTo, allowSendingWihtoutReply, ProtectContent, replyMarkUp, captionEntities)
+}
+
+/*CopyMessageContext works like "CopyMessage" but sends the request with the given context.*/
+func (bai *BotAPIInterface) CopyMessageContext(ctx context.Context, chatIdInt, fromChatIdInt int, chatIdString, fromChatIdString string, messageId int, disableNotif bool, caption, parseMode string, replyTo int, allowSendingWihtoutReply, ProtectContent bool, replyMarkUp objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.SendMethodsResult, error) {
 	if (chatIdInt != 0 && chatIdString != "") && (fromChatIdInt != 0 && fromChatIdString != "") {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -1868,7 +2281,7 @@ func (bai *BotAPIInterface) CopyMessage(chatIdInt, fromChatIdInt int, chatIdStri
 		if replyTo != 0 {
 			cp.ReplyToMessageId = replyTo
 		}
-		res, err := bai.SendCustom("copyMessage", cp, false, nil, nil)
+		res, err := bai.SendCustomContext(ctx, "copyMessage", cp, false, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -1885,10 +2298,15 @@ func (bai *BotAPIInterface) CopyMessage(chatIdInt, fromChatIdInt int, chatIdStri
 
 /*SetPassportDataErrors sets passport data errors*/
 func (bai *BotAPIInterface) SetPassportDataErrors(userId int, errors []objs.PassportElementError) (*objs.LogicalResult, error) {
+	return bai.SetPassportDataErrorsContext(context.Background(), userId, errors)
+}
+
+/*SetPassportDataErrorsContext works like "SetPassportDataErrors" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetPassportDataErrorsContext(ctx context.Context, userId int, errors []objs.PassportElementError) (*objs.LogicalResult, error) {
 	args := &objs.SetPassportDataErrorsArgs{
 		UserId: userId, Errors: errors,
 	}
-	res, err := bai.SendCustom("setPassportDataErrors", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setPassportDataErrors", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1902,6 +2320,11 @@ func (bai *BotAPIInterface) SetPassportDataErrors(userId int, errors []objs.Pass
 
 /*SendGame sends a game*/
 func (bai *BotAPIInterface) SendGame(chatId int, gameShortName string, disableNotif bool, replyTo int, allowSendingWithoutReply bool, replyMarkup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
+	return bai.SendGameContext(context.Background(), chatId, gameShortName, disableNotif, replyTo, allowSendingWithoutReply, replyMarkup)
+}
+
+/*SendGameContext works like "SendGame" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SendGameContext(ctx context.Context, chatId int, gameShortName string, disableNotif bool, replyTo int, allowSendingWithoutReply bool, replyMarkup objs.ReplyMarkup) (*objs.SendMethodsResult, error) {
 	args := &objs.SendGameArgs{
 		DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{
 			ReplyToMessageId:         replyTo,
@@ -1913,7 +2336,7 @@ func (bai *BotAPIInterface) SendGame(chatId int, gameShortName string, disableNo
 	}
 	bt, _ := json.Marshal(chatId)
 	args.ChatId = bt
-	res, err := bai.SendCustom("sendGame", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "sendGame", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1927,6 +2350,11 @@ func (bai *BotAPIInterface) SendGame(chatId int, gameShortName string, disableNo
 
 /*SetGameScore sets the game high score*/
 func (bai *BotAPIInterface) SetGameScore(userId, score int, force, disableEditMessage bool, chatId, messageId int, inlineMessageId string) (*objs.DefaultResult, error) {
+	return bai.SetGameScoreContext(context.Background(), userId, score, force, disableEditMessage, chatId, messageId, inlineMessageId)
+}
+
+/*SetGameScoreContext works like "SetGameScore" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetGameScoreContext(ctx context.Context, userId, score int, force, disableEditMessage bool, chatId, messageId int, inlineMessageId string) (*objs.DefaultResult, error) {
 	args := &objs.SetGameScoreArgs{
 		UserId:             userId,
 		Score:              score,
@@ -1936,7 +2364,7 @@ func (bai *BotAPIInterface) SetGameScore(userId, score int, force, disableEditMe
 		MessageId:          messageId,
 		InlineMessageId:    inlineMessageId,
 	}
-	res, err := bai.SendCustom("setGameScore", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setGameScore", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1950,13 +2378,18 @@ func (bai *BotAPIInterface) SetGameScore(userId, score int, force, disableEditMe
 
 /*GetGameHighScores gets the high scores of the user*/
 func (bai *BotAPIInterface) GetGameHighScores(userId, chatId, messageId int, inlineMessageId string) (*objs.GameHighScoresResult, error) {
+	return bai.GetGameHighScoresContext(context.Background(), userId, chatId, messageId, inlineMessageId)
+}
+
+/*GetGameHighScoresContext works like "GetGameHighScores" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetGameHighScoresContext(ctx context.Context, userId, chatId, messageId int, inlineMessageId string) (*objs.GameHighScoresResult, error) {
 	args := &objs.GetGameHighScoresArgs{
 		UserId:          userId,
 		ChatId:          chatId,
 		MessageId:       messageId,
 		InlineMessageId: inlineMessageId,
 	}
-	res, err := bai.SendCustom("getGameHighScores", args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getGameHighScores", args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1970,7 +2403,12 @@ func (bai *BotAPIInterface) GetGameHighScores(userId, chatId, messageId int, inl
 
 /*GetWebhookInfo returns the web hook info of the bot.*/
 func (bai *BotAPIInterface) GetWebhookInfo() (*objs.WebhookInfoResult, error) {
-	res, err := bai.SendCustom("getWebhookInfo", nil, false, nil)
+	return bai.GetWebhookInfoContext(context.Background())
+}
+
+/*GetWebhookInfoContext works like "GetWebhookInfo" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetWebhookInfoContext(ctx context.Context) (*objs.WebhookInfoResult, error) {
+	res, err := bai.SendCustomContext(ctx, "getWebhookInfo", nil, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1983,7 +2421,12 @@ func (bai *BotAPIInterface) GetWebhookInfo() (*objs.WebhookInfoResult, error) {
 }
 
 /*SetWebhook sets a webhook for the bot.*/
-func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile *os.File) (*objs.LogicalResult, error) {
+func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile io.Reader) (*objs.LogicalResult, error) {
+	return bai.SetWebhookContext(context.Background(), url, ip, maxCnc, allowedUpdates, dropPendingUpdates, keyFile)
+}
+
+/*SetWebhookContext works like "SetWebhook" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetWebhookContext(ctx context.Context, url, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile io.Reader) (*objs.LogicalResult, error) {
 	args := objs.SetWebhookArgs{
 		URL:                url,
 		IPAddress:          ip,
@@ -1992,13 +2435,13 @@ func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdate
 		DropPendingUpdates: dropPendingUpdates,
 	}
 	if keyFile != nil {
-		stat, errs := keyFile.Stat()
+		cert, errs := attachName(keyFile)
 		if errs != nil {
 			return nil, errs
 		}
-		args.Certificate = "attach://" + stat.Name()
+		args.Certificate = cert
 	}
-	res, err := bai.SendCustom("setWebhook", &args, keyFile != nil, keyFile)
+	res, err := bai.SendCustomContext(ctx, "setWebhook", &args, keyFile != nil, keyFile)
 	if err != nil {
 		return nil, err
 	}
@@ -2012,10 +2455,15 @@ func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdate
 
 /*DeleteWebhook deletes the webhook for this bot*/
 func (bai *BotAPIInterface) DeleteWebhook(dropPendingUpdates bool) (*objs.LogicalResult, error) {
+	return bai.DeleteWebhookContext(context.Background(), dropPendingUpdates)
+}
+
+/*DeleteWebhookContext works like "DeleteWebhook" but sends the request with the given context.*/
+func (bai *BotAPIInterface) DeleteWebhookContext(ctx context.Context, dropPendingUpdates bool) (*objs.LogicalResult, error) {
 	args := objs.DeleteWebhookArgs{
 		DropPendingUpdates: dropPendingUpdates,
 	}
-	res, err := bai.SendCustom("deleteWebhook", &args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "deleteWebhook", &args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2029,11 +2477,16 @@ func (bai *BotAPIInterface) DeleteWebhook(dropPendingUpdates bool) (*objs.Logica
 
 /*AnswerWebAppQuery answers a web app query*/
 func (bai *BotAPIInterface) AnswerWebAppQuery(webAppQueryId string, result objs.InlineQueryResult) (*objs.SentWebAppMessage, error) {
+	return bai.AnswerWebAppQueryContext(context.Background(), webAppQueryId, result)
+}
+
+/*AnswerWebAppQueryContext works like "AnswerWebAppQuery" but sends the request with the given context.*/
+func (bai *BotAPIInterface) AnswerWebAppQueryContext(ctx context.Context, webAppQueryId string, result objs.InlineQueryResult) (*objs.SentWebAppMessage, error) {
 	args := objs.AnswerWebAppQueryArgs{
 		WebAppQueryId: webAppQueryId,
 		Result:        result,
 	}
-	res, err := bai.SendCustom("answerWebAppQuery", &args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "answerWebAppQuery", &args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2047,10 +2500,15 @@ func (bai *BotAPIInterface) AnswerWebAppQuery(webAppQueryId string, result objs.
 
 /*GetChatMenuButton gets the menu button for the given chat*/
 func (bai *BotAPIInterface) GetChatMenuButton(chatId int64) (*objs.MenuButtonResult, error) {
+	return bai.GetChatMenuButtonContext(context.Background(), chatId)
+}
+
+/*GetChatMenuButtonContext works like "GetChatMenuButton" but sends the request with the given context.*/
+func (bai *BotAPIInterface) GetChatMenuButtonContext(ctx context.Context, chatId int64) (*objs.MenuButtonResult, error) {
 	args := objs.ChatMenuButtonArgs{
 		ChatId: chatId,
 	}
-	res, err := bai.SendCustom("getChatMenuButton", &args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "getChatMenuButton", &args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2064,11 +2522,16 @@ func (bai *BotAPIInterface) GetChatMenuButton(chatId int64) (*objs.MenuButtonRes
 
 /*SetChatMenuButton sets the menu button for the given chat*/
 func (bai *BotAPIInterface) SetChatMenuButton(chatId int64, menuButton *objs.MenuButton) (*objs.LogicalResult, error) {
+	return bai.SetChatMenuButtonContext(context.Background(), chatId, menuButton)
+}
+
+/*SetChatMenuButtonContext works like "SetChatMenuButton" but sends the request with the given context.*/
+func (bai *BotAPIInterface) SetChatMenuButtonContext(ctx context.Context, chatId int64, menuButton *objs.MenuButton) (*objs.LogicalResult, error) {
 	args := objs.ChatMenuButtonArgs{
 		ChatId:     chatId,
 		MenuButton: menuButton,
 	}
-	res, err := bai.SendCustom("setChatMenuButton", &args, false, nil)
+	res, err := bai.SendCustomContext(ctx, "setChatMenuButton", &args, false, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2080,17 +2543,15 @@ func (bai *BotAPIInterface) SetChatMenuButton(chatId int64, menuButton *objs.Men
 	return msg, nil
 }
 
-/*SendCustom calls the given method on api server with the given arguments. "MP" options indicates that the request should be made in multipart/formdata form. If this method sends a file to the api server the "MP" option should be true*/
-func (bai *BotAPIInterface) SendCustom(methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error) {
+/*SendCustom calls the given method on api server with the given arguments. "MP" options indicates that the request should be made in multipart/formdata form. If this method sends a file to the api server the "MP" option should be true. Files can be *os.File or *InputFile and are streamed to the api server.*/
+func (bai *BotAPIInterface) SendCustom(methodName string, args objs.MethodArguments, MP bool, files ...io.Reader) ([]byte, error) {
+	return bai.SendCustomContext(context.Background(), methodName, args, MP, files...)
+}
+
+/*SendCustomContext works like "SendCustom" but sends the request with the given context. If the context is cancelled or its deadline is exceeded, the request (and the retries and the wait in the rate limiter queue) is cancelled.*/
+func (bai *BotAPIInterface) SendCustomContext(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...io.Reader) ([]byte, error) {
 	start := time.Now().UnixMicro()
-	cl := httpSenderClient{botApi: bai.botConfigs.BotAPI, apiKey: bai.botConfigs.APIKey}
-	var res []byte
-	var err2 error
-	if MP {
-		res, err2 = cl.sendHttpReqMultiPart(methodName, args, files...)
-	} else {
-		res, err2 = cl.sendHttpReqJson(methodName, args)
-	}
+	res, err2 := bai.sendWithRetry(ctx, bai.newSender(), methodName, args, MP, files...)
 	done := time.Now().UnixMicro()
 	if err2 != nil {
 		logger.Log(methodName, "\t\t\t", "Error  ", strconv.FormatInt((done-start), 10)+"µs", logger.BOLD+logger.OKBLUE, logger.FAIL, "")
@@ -2142,15 +2603,18 @@ func (bai *BotAPIInterface) fixChatId(chatIdInt int, chatIdString string) []byte
 	}
 }
 
-/*CreateInterface returns an iterface to communicate with the bot api.
-If the updateFrequency argument is not nil, the update routine begins automtically*/
+/*CreateInterface returns an iterface to communicate with the bot api. Each interface has its own parser and webhook, so several interfaces can be created for different bots.*/
 func CreateInterface(botCfg *cfgs.BotConfigs) (*BotAPIInterface, error) {
-	if interfaceCreated {
-		return nil, &errs.BotInterfaceAlreadyCreated{}
+	ch := make(chan *objs.Update, botCfg.DispatcherConfigs.BufferSize())
+	ch3 := make(chan *objs.ChatUpdate, botCfg.DispatcherConfigs.BufferSize())
+	client, err := newHTTPClient(botCfg.HTTPConfigs)
+	if err != nil {
+		return nil, err
+	}
+	temp := &BotAPIInterface{botConfigs: botCfg, updateChannel: &ch, chatUpadateChannel: &ch3, parser: up.NewParser(), client: client}
+	temp.webhook = &webhook{configs: botCfg, parser: temp.parser, interfaceUpdateChannel: &ch, chatUpdateChannel: &ch3}
+	if botCfg.RateLimitConfigs != nil {
+		temp.scheduler = NewScheduler(botCfg.RateLimitConfigs)
 	}
-	interfaceCreated = true
-	ch := make(chan *objs.Update)
-	ch3 := make(chan *objs.ChatUpdate)
-	temp := &BotAPIInterface{botConfigs: botCfg, updateChannel: &ch, chatUpadateChannel: &ch3}
 	return temp, nil
 }
@@ -0,0 +1,49 @@
+package tba
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContextCancellation(t *testing.T) {
+	release := make(chan bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		//The server is stuck until the test ends.
+		io.Copy(io.Discard, req.Body)
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+	bai := createTestInterface(srv.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	done := make(chan error, 1)
+	go func() {
+		file := &InputFile{Name: "report.txt", Reader: strings.NewReader("report")}
+		_, err := bai.SendDocumentContext(ctx, 1, "", "attach://report.txt", file, "", "", 0, "", nil, false, false, false, nil, false, nil)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatal("expected context.Canceled error", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not cancelled")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := bai.GetMeContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected context.DeadlineExceeded error", err)
+	}
+}
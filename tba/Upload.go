@@ -0,0 +1,206 @@
+package tba
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"strings"
+
+	mp "mime/multipart"
+
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+/*InputFile is a file that is uploaded from a reader instead of a file stored on the device, like an image generated in memory. It can be passed to all the methods that upload files.
+
+"Name" is the file name sent to the API server and the files are attached by it ("attach://<Name>"), so the files of a request should have different names. "ContentType" is the MIME type of the file ("application/octet-stream" if empty). If "Size" (the number of bytes the reader returns) is known the request is sent with its length, otherwise it's sent in chunks. Sizes of *bytes.Reader, *bytes.Buffer and *strings.Reader are known.
+
+The request is retried (see "RetryConfigs" field of the bot configs) only if the reader is also an io.Seeker.*/
+type InputFile struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Reader      io.Reader
+}
+
+//Read reads from the reader of the file.
+func (inf *InputFile) Read(p []byte) (int, error) {
+	return inf.Reader.Read(p)
+}
+
+//uploadFile is a file in a multipart request.
+type uploadFile struct {
+	name, contentType string
+	size              int64 //-1 if unknown
+	reader            io.Reader
+	seeker            io.Seeker //nil if the reader can't be rewound.
+	offset            int64     //The offset of the seeker when the upload started.
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+/*newUploadFiles prepares the given files for uploading. Nil files and the files that are passed more than once are skipped. Only *os.File and *InputFile can be uploaded since other readers have no name.
+Files are referenced by their names, so two different files with the same name are rejected.*/
+func newUploadFiles(files []io.Reader) ([]*uploadFile, error) {
+	out := make([]*uploadFile, 0, len(files))
+	names := make(map[string]io.Reader, len(files))
+	for _, file := range files {
+		var uf *uploadFile
+		switch f := file.(type) {
+		case nil:
+			continue
+		case *os.File:
+			if f == nil {
+				continue
+			}
+			stat, err := f.Stat()
+			if err != nil {
+				return nil, err
+			}
+			uf = &uploadFile{name: stat.Name(), contentType: "application/octet-stream", size: -1, reader: f}
+			if off, err := f.Seek(0, io.SeekCurrent); err == nil {
+				uf.seeker, uf.offset = f, off
+				if stat.Mode().IsRegular() {
+					uf.size = stat.Size() - off
+				}
+			}
+		case *InputFile:
+			if f == nil {
+				continue
+			}
+			if f.Name == "" || f.Reader == nil {
+				return nil, errors.New("name and reader of the input file are required")
+			}
+			uf = &uploadFile{name: f.Name, contentType: f.ContentType, size: -1, reader: f.Reader}
+			if uf.contentType == "" {
+				uf.contentType = "application/octet-stream"
+			}
+			if f.Size > 0 {
+				uf.size = f.Size
+			} else if l, ok := f.Reader.(interface{ Len() int }); ok {
+				uf.size = int64(l.Len())
+			}
+			if seeker, ok := f.Reader.(io.Seeker); ok {
+				off, err := seeker.Seek(0, io.SeekCurrent)
+				if err == nil {
+					uf.seeker, uf.offset = seeker, off
+				}
+			}
+		default:
+			return nil, errors.New("the file has no name, use InputFile for uploading readers")
+		}
+		if prev, ok := names[uf.name]; ok {
+			if prev == file {
+				continue
+			}
+			return nil, errors.New("more than one file is named \"" + uf.name + "\", files should have different names")
+		}
+		names[uf.name] = file
+		out = append(out, uf)
+	}
+	return out, nil
+}
+
+//attachName returns the string used for attaching the given file to the arguments of a request ("attach://<file name>").
+func attachName(file io.Reader) (string, error) {
+	files, err := newUploadFiles([]io.Reader{file})
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", errors.New("file is nil")
+	}
+	return "attach://" + files[0].name, nil
+}
+
+//rewind seeks the file to where it was when the upload started, so it can be sent again.
+func (uf *uploadFile) rewind() error {
+	if uf.seeker == nil {
+		return errors.New("file " + uf.name + " can't be rewound")
+	}
+	_, err := uf.seeker.Seek(uf.offset, io.SeekStart)
+	return err
+}
+
+func (uf *uploadFile) header() textproto.MIMEHeader {
+	h := make(textproto.MIMEHeader)
+	name := quoteEscaper.Replace(uf.name)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, name, name))
+	h.Set("Content-Type", uf.contentType)
+	return h
+}
+
+/*writeMultiPart writes the multipart form of the arguments and the files into the given body writer. If "withContent" is false the content of the files is not written, which is used for computing the length of the body.*/
+func writeMultiPart(body *bodyWriter, writer *mp.Writer, args objs.MethodArguments, files []*uploadFile, withContent bool) error {
+	//"ToMultiPart" methods ignore the errors, so the failed writes of the arguments are discarded instead of returning nil parts to them.
+	body.discard = true
+	args.ToMultiPart(writer)
+	body.discard = false
+	if body.err != nil {
+		return body.err
+	}
+	for _, file := range files {
+		fw, err := writer.CreatePart(file.header())
+		if err != nil {
+			return err
+		}
+		if withContent {
+			if _, err = io.Copy(fw, file.reader); err != nil {
+				return err
+			}
+		}
+	}
+	return writer.Close()
+}
+
+/*multiPartLength returns the length of the multipart body written with the given boundary. Returns -1 if the size of a file is unknown.*/
+func multiPartLength(boundary string, args objs.MethodArguments, files []*uploadFile) int64 {
+	cw := &countWriter{}
+	body := &bodyWriter{w: cw}
+	writer := mp.NewWriter(body)
+	if writer.SetBoundary(boundary) != nil {
+		return -1
+	}
+	for _, file := range files {
+		if file.size < 0 {
+			return -1
+		}
+		cw.n += file.size
+	}
+	if writeMultiPart(body, writer, args, files, false) != nil {
+		return -1
+	}
+	return cw.n
+}
+
+/*bodyWriter writes the multipart body into the underlying writer and keeps the first error. After an error nothing is written, and while "discard" is true the writes are reported as successful.*/
+type bodyWriter struct {
+	w       io.Writer
+	err     error
+	discard bool
+}
+
+func (bw *bodyWriter) Write(p []byte) (int, error) {
+	if bw.err == nil {
+		var n int
+		n, bw.err = bw.w.Write(p)
+		if bw.err == nil {
+			return n, nil
+		}
+	}
+	if bw.discard {
+		return len(p), nil
+	}
+	return 0, bw.err
+}
+
+type countWriter struct {
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	cw.n += int64(len(p))
+	return len(p), nil
+}
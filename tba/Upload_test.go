@@ -0,0 +1,154 @@
+package tba
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	objs "github.com/SakoDroid/telego/objects"
+)
+
+func TestStreamingUpload(t *testing.T) {
+	type received struct {
+		length  int64
+		files   map[string]string
+		types   map[string]string
+		caption string
+	}
+	var got []received
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		rc := received{length: req.ContentLength, files: make(map[string]string), types: make(map[string]string)}
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+		}
+		rc.caption = req.FormValue("caption")
+		for name, headers := range req.MultipartForm.File {
+			fl, _ := headers[0].Open()
+			data, _ := io.ReadAll(fl)
+			fl.Close()
+			rc.files[name] = string(data)
+			rc.types[name] = headers[0].Header.Get("Content-Type")
+		}
+		got = append(got, rc)
+		wr.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	if err := os.WriteFile(path, []byte("video content"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fl, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fl.Close()
+	thumb := &InputFile{Name: "thumb.jpg", ContentType: "image/jpeg", Reader: bytes.NewReader([]byte("thumb content"))}
+	if _, err = bai.SendVideo(1, "", "attach://video.mp4", fl, "caption", "", 0, "attach://thumb.jpg", thumb, false, false, false, nil, 0, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("streamed "))
+		pw.Write([]byte("photo"))
+		pw.Close()
+	}()
+	if _, err = bai.SendPhoto(1, "", "attach://photo.png", &InputFile{Name: "photo.png", ContentType: "image/png", Reader: pr}, "", "", 0, false, false, false, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatal("expected 2 requests, got", len(got))
+	}
+	if got[0].length <= 0 || got[0].files["video.mp4"] != "video content" || got[0].files["thumb.jpg"] != "thumb content" || got[0].types["thumb.jpg"] != "image/jpeg" || got[0].caption != "caption" {
+		t.Error("wrong first request :", got[0])
+	}
+	if got[1].length != -1 || got[1].files["photo.png"] != "streamed photo" || got[1].types["photo.png"] != "image/png" {
+		t.Error("wrong second request :", got[1])
+	}
+	if _, err = bai.SendPhoto(1, "", "attach://photo.png", strings.NewReader("no name"), "", "", 0, false, false, false, nil, nil); err == nil {
+		t.Error("reader without a name was accepted")
+	}
+}
+
+func TestRetryUpload(t *testing.T) {
+	calls := 0
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		calls++
+		_ = req.ParseMultipartForm(1 << 20)
+		if headers := req.MultipartForm.File["doc.txt"]; len(headers) == 1 {
+			fl, _ := headers[0].Open()
+			data, _ := io.ReadAll(fl)
+			fl.Close()
+			bodies = append(bodies, string(data))
+		}
+		if calls == 1 {
+			wr.WriteHeader(429)
+			wr.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 0","parameters":{"retry_after":0}}`))
+			return
+		}
+		wr.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, &cfgs.RetryConfigs{MaxRetries: 2})
+	doc := &InputFile{Name: "doc.txt", Reader: strings.NewReader("document")}
+	if _, err := bai.SendDocument(1, "", "attach://doc.txt", doc, "", "", 0, "", nil, false, false, false, nil, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || len(bodies) != 2 || bodies[1] != "document" {
+		t.Error("file was not rewound :", calls, bodies)
+	}
+	calls = 0
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("document"))
+		pw.Close()
+	}()
+	if _, err := bai.SendDocument(1, "", "attach://doc.txt", &InputFile{Name: "doc.txt", Reader: pr}, "", "", 0, "", nil, false, false, false, nil, false, nil); err == nil || calls != 1 {
+		t.Error("request with a reader that can't be rewound was retried :", calls, err)
+	}
+}
+
+func TestCancelledMultiPartArgs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		wr.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	//The body is not read when the request is cancelled, writing the arguments should not panic.
+	for i := 0; i < 20; i++ {
+		media := &objs.InputMediaPhoto{InputMediaDefault: objs.InputMediaDefault{Type: "photo", Media: "attach://photo.jpg"}}
+		_, err := bai.EditMessageMediaContext(ctx, 10, "", 1, "", media, nil, &InputFile{Name: "photo.jpg", Reader: strings.NewReader("photo")})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatal("request was not cancelled", err)
+		}
+	}
+}
+
+func TestDuplicateUploadFiles(t *testing.T) {
+	thumb := &InputFile{Name: "thumb.jpg", Reader: strings.NewReader("thumb")}
+	files, err := newUploadFiles([]io.Reader{&InputFile{Name: "video.mp4", Reader: strings.NewReader("video")}, thumb, thumb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Error("a file passed twice should be uploaded once, got", len(files), "files")
+	}
+	_, err = newUploadFiles([]io.Reader{
+		&InputFile{Name: "photo.jpg", Reader: strings.NewReader("first")},
+		&InputFile{Name: "photo.jpg", Reader: strings.NewReader("second")},
+	})
+	if err == nil {
+		t.Error("different files with the same name were accepted")
+	}
+}
@@ -1,10 +1,17 @@
 package tba
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"io"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	cfg "github.com/SakoDroid/telego/configs"
 	log "github.com/SakoDroid/telego/logger"
@@ -12,31 +19,377 @@ import (
 	up "github.com/SakoDroid/telego/parser"
 )
 
-var configs *cfg.BotConfigs
-var interfaceUpdateChannel *chan *objs.Update
-var chatUpdateChannel *chan *objs.ChatUpdate
-var isSecretTokenSet bool
+/*servers holds the running webhook servers by their listen address. Bots whose webhooks use the same address share one server and their requests are routed by the path.*/
+var servers = make(map[string]*webhookServer)
+var serversLock sync.Mutex
 
-//StartWebHook starts the webhook.
-func StartWebHook(cfg *cfg.BotConfigs, iuc *chan *objs.Update, cuc *chan *objs.ChatUpdate) error {
-	configs = cfg
-	interfaceUpdateChannel = iuc
-	chatUpdateChannel = cuc
-	isSecretTokenSet = cfg.WebHookConfigs.SecretToken != ""
-	startTheServer()
+//webhookServer is an HTTP(S) server shared by the webhooks of the bots that use the same listen address.
+type webhookServer struct {
+	server   *http.Server
+	plain    bool                //Is the server serving plain HTTP?
+	webhooks map[string]*webhook //The webhooks by their path
+	certs    []*serverCertificate
+	lock     sync.RWMutex
+}
+
+//serverCertificate is the certificate of the webhook on the given path.
+type serverCertificate struct {
+	path string
+	cert *tls.Certificate
+}
+
+/*webhook receives the updates of a bot. It's created with the interface and accepts the requests only while it's started, so the handler returned by "WebhookHandler" can be mounted before the bot is started.*/
+type webhook struct {
+	configs                *cfg.BotConfigs
+	parser                 *up.Parser
+	interfaceUpdateChannel *chan *objs.Update
+	chatUpdateChannel      *chan *objs.ChatUpdate
+	running                *webhookState  //nil if the webhook is not started.
+	server                 *webhookServer //The server this webhook is registered in. nil for external servers.
+	path                   string
+	inProgress             sync.WaitGroup //The requests that are being processed.
+	pending                sync.Map       //The responses of the updates that are waiting for their handlers, by the update id.
+	lock                   sync.RWMutex   //Guards running, server and path.
+}
+
+//webhookState holds what a started webhook needs for processing the requests.
+type webhookState struct {
+	allowed    []*net.IPNet
+	proxies    []*net.IPNet
+	queue      chan *objs.Update //The received updates waiting to be parsed.
+	workerDone chan bool
+	renewal    *time.Timer //Renews the generated certificate. nil if the certificate is not generated.
+}
+
+//webhookResponse is the response of an update that can be set by its handlers until the webhook request is answered.
+type webhookResponse struct {
+	body   []byte
+	closed bool
+	lock   sync.Mutex
+}
+
+/*WebhookHandler returns the http.Handler which processes the webhook requests of the bot. It can be mounted into any HTTP server or router, on any path. It responds with 503 (Service Unavailable) until the webhook is started.*/
+func (bai *BotAPIInterface) WebhookHandler() http.Handler {
+	return bai.webhook
+}
+
+/*StartWebHook starts the webhook of the bot. If "ExternalServer" field of the webhook configs is true, no server is started and the handler returned by "WebhookHandler" starts accepting the requests. Otherwise the certificate is loaded (unless "PlainHTTP" is true) and the address is bound before this function returns so any error in starting the server is returned.
+
+If the webhook of another bot is running on the same listen address, the server is shared and the requests are routed by their path. Certificates of the bots are chosen by the server name the client asks for.*/
+func (bai *BotAPIInterface) StartWebHook() error {
+	wh := bai.webhook
+	wh.lock.Lock()
+	defer wh.lock.Unlock()
+	if wh.running != nil {
+		return errors.New("webhook is already started")
+	}
+	whCfg := bai.botConfigs.WebHookConfigs
+	st := &webhookState{workerDone: make(chan bool)}
+	var err error
+	if whCfg.VerifySourceIP {
+		subnets := whCfg.AllowedSubnets
+		if len(subnets) == 0 {
+			subnets = cfg.TelegramSubnets
+		}
+		if st.allowed, err = parseSubnets(subnets); err != nil {
+			return err
+		}
+	}
+	if st.proxies, err = parseSubnets(whCfg.TrustedProxies); err != nil {
+		return err
+	}
+	if !whCfg.ExternalServer {
+		var cert *tls.Certificate
+		if whCfg.GenerateCertificate {
+			if cert, _, err = loadOrGenerateCertificate(whCfg); err != nil {
+				return err
+			}
+		} else if !whCfg.PlainHTTP {
+			pair, err := tls.LoadX509KeyPair(whCfg.CertFile, whCfg.KeyFile)
+			if err != nil {
+				return err
+			}
+			cert = &pair
+		}
+		path := whCfg.GetPath(bai.botConfigs.APIKey)
+		srv, err := registerWebhook(whCfg, path, wh, cert)
+		if err != nil {
+			return err
+		}
+		wh.server, wh.path = srv, path
+		if whCfg.GenerateCertificate {
+			st.renewal = time.AfterFunc(time.Until(renewalTime(cert.Leaf)), func() { bai.renewCertificate(st) })
+		}
+	}
+	st.queue = make(chan *objs.Update, bai.botConfigs.DispatcherConfigs.BufferSize())
+	go wh.parseUpdates(st)
+	wh.running = st
 	return nil
 }
 
-func startTheServer() {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", mainHandler)
-	mux.HandleFunc("/"+configs.APIKey, handleReq)
+/*StartWebHook starts a webhook for the given configs which passes the updates to the given channels. The updates are dispatched to the handlers added by the package level functions of the parser package.
+
+Deprecated: Use "StartWebHook" method of the interface returned by "CreateInterface" function.*/
+func StartWebHook(cfg *cfg.BotConfigs, iuc *chan *objs.Update, cuc *chan *objs.ChatUpdate) error {
+	client, err := newHTTPClient(cfg.HTTPConfigs)
+	if err != nil {
+		return err
+	}
+	bai := &BotAPIInterface{botConfigs: cfg, updateChannel: iuc, chatUpadateChannel: cuc, parser: up.DefaultParser(), client: client}
+	bai.webhook = &webhook{configs: cfg, parser: bai.parser, interfaceUpdateChannel: iuc, chatUpdateChannel: cuc}
+	return bai.StartWebHook()
+}
+
+/*StopWebHook stops the webhook of the bot. It waits for the requests in progress and the received updates to be processed until the given context is done. The server is shut down if no other bot uses it.*/
+func (bai *BotAPIInterface) StopWebHook(ctx context.Context) error {
+	wh := bai.webhook
+	wh.lock.Lock()
+	st := wh.running
+	if st == nil {
+		wh.lock.Unlock()
+		return nil
+	}
+	wh.running = nil
+	if st.renewal != nil {
+		st.renewal.Stop()
+	}
+	srv, path := wh.server, wh.path
+	wh.server = nil
+	wh.lock.Unlock()
+	var err error
+	if srv != nil && unregisterWebhook(srv, path) {
+		err = srv.server.Shutdown(ctx)
+	}
+	//Requests that are still in progress may put updates in the queue, so it can't be closed until they return.
+	if err2 := waitFor(ctx, &wh.inProgress); err2 != nil {
+		return err2
+	}
+	close(st.queue)
+	select {
+	case <-st.workerDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return err
+}
+
+//RespondInWebhook sets the response of the webhook request of the given update to a call of the given method. Returns false if the request has already been answered or the webhook does not wait for the handlers (see "ResponseTimeout" field of the webhook configs).
+func (bai *BotAPIInterface) RespondInWebhook(update *objs.Update, method string, args objs.MethodArguments) bool {
+	val, ok := bai.webhook.pending.Load(update.Update_id)
+	if !ok {
+		return false
+	}
+	body := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(args.ToJson(), &body); err != nil {
+		return false
+	}
+	body["method"], _ = json.Marshal(method)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return false
+	}
+	res := val.(*webhookResponse)
+	res.lock.Lock()
+	defer res.lock.Unlock()
+	if res.closed || res.body != nil {
+		return false
+	}
+	res.body = data
+	return true
+}
+
+func (wh *webhook) parseUpdates(st *webhookState) {
+	defer close(st.workerDone)
+	for update := range st.queue {
+		wh.parser.ParseSingleUpdate(update, wh.interfaceUpdateChannel, wh.chatUpdateChannel, wh.configs)
+	}
+}
+
+//waitFor waits for the wait group until the context is done.
+func waitFor(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan bool)
 	go func() {
-		err := http.ListenAndServeTLS(":"+strconv.Itoa(configs.WebHookConfigs.Port), configs.WebHookConfigs.CertFile, configs.WebHookConfigs.KeyFile, mux)
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//parseSubnets parses the given subnets. IP addresses are accepted too.
+func parseSubnets(subnets []string) ([]*net.IPNet, error) {
+	out := make([]*net.IPNet, 0, len(subnets))
+	for _, subnet := range subnets {
+		if !strings.Contains(subnet, "/") {
+			ip := net.ParseIP(subnet)
+			if ip == nil {
+				return nil, errors.New("invalid IP address : " + subnet)
+			}
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ipNet)
+	}
+	return out, nil
+}
+
+func containsIP(subnets []*net.IPNet, ip net.IP) bool {
+	for _, subnet := range subnets {
+		if subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+//registerWebhook adds the webhook to the server of its listen address. The server is started if it's not running.
+func registerWebhook(whCfg *cfg.WebHookConfigs, path string, wh *webhook, cert *tls.Certificate) (*webhookServer, error) {
+	address := whCfg.GetListenAddress()
+	serversLock.Lock()
+	defer serversLock.Unlock()
+	srv := servers[address]
+	if srv == nil {
+		var err error
+		srv, err = startTheServer(address, whCfg.PlainHTTP)
 		if err != nil {
-			log.Logger.Fatalln("Webhook : Failed to start the HTTPS server.", err)
+			return nil, err
+		}
+		servers[address] = srv
+	} else if srv.plain != whCfg.PlainHTTP {
+		return nil, errors.New("the webhook server on " + address + " is shared with another bot and both should use HTTPS or plain HTTP")
+	}
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+	if srv.webhooks[path] != nil {
+		return nil, errors.New("another webhook is running on path " + path)
+	}
+	srv.webhooks[path] = wh
+	if cert != nil {
+		srv.certs = append(srv.certs, &serverCertificate{path: path, cert: cert})
+	}
+	return srv, nil
+}
+
+//unregisterWebhook removes the webhook on the given path from the server. Returns true if the server has no other webhook, in which case it should be shut down.
+func unregisterWebhook(srv *webhookServer, path string) bool {
+	serversLock.Lock()
+	defer serversLock.Unlock()
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+	delete(srv.webhooks, path)
+	for i, sc := range srv.certs {
+		if sc.path == path {
+			srv.certs = append(srv.certs[:i], srv.certs[i+1:]...)
+			break
+		}
+	}
+	if len(srv.webhooks) != 0 {
+		return false
+	}
+	for address, ws := range servers {
+		if ws == srv {
+			delete(servers, address)
+		}
+	}
+	return true
+}
+
+func startTheServer(address string, plain bool) (*webhookServer, error) {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	ws := &webhookServer{plain: plain, webhooks: make(map[string]*webhook)}
+	ws.server = &http.Server{Handler: ws}
+	if !plain {
+		ws.server.TLSConfig = &tls.Config{GetCertificate: ws.getCertificate}
+	}
+	srv := ws.server
+	go func() {
+		var err error
+		if plain {
+			err = srv.Serve(ln)
+		} else {
+			err = srv.ServeTLS(ln, "", "")
+		}
+		if err != nil && err != http.ErrServerClosed {
+			log.Logger.Println("Webhook : server stopped unexpectedly.", err)
 		}
 	}()
+	return ws, nil
+}
+
+//getCertificate returns the first certificate that supports the client hello. If none does, the first certificate is returned.
+func (ws *webhookServer) getCertificate(chi *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	ws.lock.RLock()
+	defer ws.lock.RUnlock()
+	if len(ws.certs) == 0 {
+		return nil, errors.New("no certificate")
+	}
+	for _, sc := range ws.certs {
+		if chi.SupportsCertificate(sc.cert) == nil {
+			return sc.cert, nil
+		}
+	}
+	return ws.certs[0].cert, nil
+}
+
+//certificate returns the certificate of the webhook on the given path.
+func (ws *webhookServer) certificate(path string) *tls.Certificate {
+	ws.lock.RLock()
+	defer ws.lock.RUnlock()
+	for _, sc := range ws.certs {
+		if sc.path == path {
+			return sc.cert
+		}
+	}
+	return nil
+}
+
+//setCertificate replaces the certificate of the webhook on the given path.
+func (ws *webhookServer) setCertificate(path string, cert *tls.Certificate) {
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+	for _, sc := range ws.certs {
+		if sc.path == path {
+			sc.cert = cert
+		}
+	}
+}
+
+func (ws *webhookServer) ServeHTTP(wr http.ResponseWriter, req *http.Request) {
+	ws.lock.RLock()
+	wh := ws.webhooks[req.URL.Path]
+	ws.lock.RUnlock()
+	if wh == nil {
+		mainHandler(wr, req)
+		return
+	}
+	wh.ServeHTTP(wr, req)
+}
+
+//ServeHTTP processes a webhook request.
+func (wh *webhook) ServeHTTP(wr http.ResponseWriter, req *http.Request) {
+	wh.lock.RLock()
+	st := wh.running
+	if st == nil {
+		wh.lock.RUnlock()
+		wr.WriteHeader(503)
+		wr.Write([]byte{})
+		return
+	}
+	wh.inProgress.Add(1)
+	wh.lock.RUnlock()
+	defer wh.inProgress.Done()
+	wh.handleReq(wr, req, st)
 }
 
 func mainHandler(wr http.ResponseWriter, req *http.Request) {
@@ -44,41 +397,114 @@ func mainHandler(wr http.ResponseWriter, req *http.Request) {
 	wr.Write([]byte{})
 }
 
-func handleReq(wr http.ResponseWriter, req *http.Request) {
-	if isSecretTokenSet {
-		token := req.Header.Get("X-Telegram-Bot-Api-Secret-Token")
-		if token != configs.WebHookConfigs.SecretToken {
+func (wh *webhook) handleReq(wr http.ResponseWriter, req *http.Request, st *webhookState) {
+	whCfg := wh.configs.WebHookConfigs
+	if whCfg.VerifySourceIP {
+		ip := sourceIP(req, st.proxies)
+		if ip == nil || !containsIP(st.allowed, ip) {
+			log.Logger.Println("Webhook : Request is not sent from an allowed subnet. Address :", req.RemoteAddr)
 			wr.WriteHeader(403)
 			wr.Write([]byte{})
 			return
 		}
 	}
-	contentType := req.Header.Get("Content-Type")
-	if contentType != "" && strings.HasSuffix(contentType, "json") {
-		if req.ContentLength > 0 {
-			body := make([]byte, req.ContentLength)
-			_, err := req.Body.Read(body)
-			if err == nil {
-				update := &objs.Update{}
-				jsonErr := json.Unmarshal(body, update)
-				if jsonErr == nil {
-					up.ParseSingleUpdate(update, interfaceUpdateChannel, chatUpdateChannel, configs)
-				} else {
-					log.Logger.Println("Webhook : Error parsing the update. Address :", req.RemoteAddr, ". Error :", jsonErr)
-				}
-			} else {
-				log.Logger.Println("Webhook : Error reading the body. Address :", req.RemoteAddr, ". Error :", err)
-			}
-			wr.WriteHeader(200)
+	if whCfg.SecretToken != "" {
+		token := req.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+		if token != whCfg.SecretToken {
+			wr.WriteHeader(403)
 			wr.Write([]byte{})
-		} else {
-			log.Logger.Println("Webhook : Request has no body. Address :", req.RemoteAddr)
-			send400(&wr, "Request has no body")
+			return
 		}
-	} else {
+	}
+	contentType := req.Header.Get("Content-Type")
+	if contentType == "" || !strings.HasSuffix(contentType, "json") {
 		log.Logger.Println("Webhook : \"Content-Type\" header is not json or it's missing. Address :", req.RemoteAddr)
 		send400(&wr, " \"Content-Type\" header is not json or it's missing")
+		return
+	}
+	maxSize := whCfg.MaxBodySize
+	if maxSize <= 0 {
+		maxSize = cfg.DefaultMaxBodySize
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxSize+1))
+	if err != nil {
+		log.Logger.Println("Webhook : Error reading the body. Address :", req.RemoteAddr, ". Error :", err)
+		send400(&wr, "Error reading the body")
+		return
+	}
+	if int64(len(body)) > maxSize {
+		log.Logger.Println("Webhook : Request body is too large. Address :", req.RemoteAddr)
+		wr.WriteHeader(413)
+		wr.Write([]byte{})
+		return
+	}
+	if len(body) == 0 {
+		log.Logger.Println("Webhook : Request has no body. Address :", req.RemoteAddr)
+		send400(&wr, "Request has no body")
+		return
+	}
+	update := &objs.Update{}
+	if err = json.Unmarshal(body, update); err != nil {
+		log.Logger.Println("Webhook : Error parsing the update. Address :", req.RemoteAddr, ". Error :", err)
+		wr.WriteHeader(200)
+		wr.Write([]byte{})
+		return
+	}
+	if whCfg.ResponseTimeout > 0 {
+		wh.respond(wr, update, whCfg.ResponseTimeout)
+		return
+	}
+	select {
+	case st.queue <- update:
+		wr.WriteHeader(200)
+	case <-req.Context().Done():
+		wr.WriteHeader(503)
+	}
+	wr.Write([]byte{})
+}
+
+//respond processes the update and waits for its handlers, then answers the request with the response set by the handlers (if any).
+func (wh *webhook) respond(wr http.ResponseWriter, update *objs.Update, timeout time.Duration) {
+	res := &webhookResponse{}
+	wh.pending.Store(update.Update_id, res)
+	defer wh.pending.Delete(update.Update_id)
+	wg := wh.parser.ParseAndTrack(update, wh.interfaceUpdateChannel, wh.chatUpdateChannel, wh.configs)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_ = waitFor(ctx, wg)
+	res.lock.Lock()
+	res.closed = true
+	body := res.body
+	res.lock.Unlock()
+	if body != nil {
+		wr.Header().Set("Content-Type", "application/json")
+	}
+	wr.WriteHeader(200)
+	wr.Write(body)
+}
+
+/*sourceIP returns the IP address the request has been sent from. If the request is sent by a trusted proxy, the address is taken from the "X-Forwarded-For" header, skipping the trusted proxies from the right.*/
+func sourceIP(req *http.Request, proxies []*net.IPNet) net.IP {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !containsIP(proxies, ip) {
+		return ip
+	}
+	hops := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			return nil
+		}
+		ip = hop
+		if !containsIP(proxies, hop) {
+			break
+		}
 	}
+	return ip
 }
 
 func send400(wr *http.ResponseWriter, reason string) {
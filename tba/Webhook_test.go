@@ -0,0 +1,286 @@
+package tba
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/configs"
+	"github.com/SakoDroid/telego/logger"
+	objs "github.com/SakoDroid/telego/objects"
+	up "github.com/SakoDroid/telego/parser"
+)
+
+func TestSharedWebhookServer(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	certFile, keyFile := createTestCertificate(t)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+	bots := make([]*BotAPIInterface, 2)
+	for i, key := range []string{"first", "second"} {
+		cfg := cfgs.Default(key)
+		cfg.Webhook = true
+		cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com", Port: port, CertFile: certFile, KeyFile: keyFile}
+		bots[i], err = CreateInterface(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = bots[i].StartWebHook(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	post := func(path string, id int) int {
+		body := []byte(`{"update_id":` + strconv.Itoa(id) + `,"inline_query":{"id":"1","query":"q"}}`)
+		res, err := client.Post("https://127.0.0.1:"+strconv.Itoa(port)+path, "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+	expectUpdate := func(bai *BotAPIInterface, id int) {
+		select {
+		case up := <-*bai.GetUpdateChannel():
+			if up.Update_id != id {
+				t.Error("wrong update received :", up.Update_id)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("update", id, "was not received")
+		}
+	}
+	if post("/first", 1) != 200 || post("/second", 2) != 200 {
+		t.Fatal("updates were not accepted")
+	}
+	expectUpdate(bots[0], 1)
+	expectUpdate(bots[1], 2)
+	if post("/third", 3) != 404 {
+		t.Error("unknown path was accepted")
+	}
+	if err = bots[0].StopWebHook(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if post("/first", 4) != 404 || post("/second", 5) != 200 {
+		t.Error("wrong routing after stopping the first webhook")
+	}
+	expectUpdate(bots[1], 5)
+	if err = bots[1].StopWebHook(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ln, err = net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+	if err != nil {
+		t.Error("server was not shut down :", err)
+	} else {
+		ln.Close()
+	}
+}
+
+func TestPlainAndExternalWebhooks(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	cfg := cfgs.Default("plain")
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com/updates", PlainHTTP: true, ListenAddress: "127.0.0.1:0", Path: "/updates"}
+	if !cfg.Check() {
+		t.Fatal("config check failed")
+	}
+	bai, err := CreateInterface(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bai.StartWebHook(); err != nil {
+		t.Fatal(err)
+	}
+	serversLock.Lock()
+	srv := servers["127.0.0.1:0"]
+	serversLock.Unlock()
+	if srv == nil || !srv.plain {
+		t.Fatal("plain server was not started")
+	}
+	rec := postTestUpdate(srv, "/updates", 1)
+	if rec.Code != 200 || (<-*bai.GetUpdateChannel()).Update_id != 1 {
+		t.Error("update was not processed by the plain server :", rec.Code)
+	}
+	if err = bai.StopWebHook(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	cfg = cfgs.Default("external")
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com/hook", ExternalServer: true}
+	bai, err = CreateInterface(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := bai.WebhookHandler()
+	if rec = postTestUpdate(handler, "/anything", 2); rec.Code != 503 {
+		t.Error("webhook accepted an update before it was started :", rec.Code)
+	}
+	if err = bai.StartWebHook(); err != nil {
+		t.Fatal(err)
+	}
+	if rec = postTestUpdate(handler, "/anything", 3); rec.Code != 200 || (<-*bai.GetUpdateChannel()).Update_id != 3 {
+		t.Error("update was not processed by the external handler :", rec.Code)
+	}
+	_ = bai.StopWebHook(context.Background())
+}
+
+func TestWebhookRequestChecks(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	cfg := cfgs.Default("checks")
+	cfg.DispatcherConfigs = cfgs.DefaultDispatcherConfigs()
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com/hook", ExternalServer: true, VerifySourceIP: true, TrustedProxies: []string{"10.0.0.1"}, MaxBodySize: 100}
+	bai, err := CreateInterface(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bai.StartWebHook(); err != nil {
+		t.Fatal(err)
+	}
+	defer bai.StopWebHook(context.Background())
+	handler := bai.WebhookHandler()
+	tests := []struct {
+		remote, forwarded string
+		code              int
+	}{
+		{"149.154.167.220:443", "", 200},
+		{"91.108.6.1:443", "", 200},
+		{"1.2.3.4:443", "", 403},
+		{"1.2.3.4:443", "149.154.167.220", 403},
+		{"10.0.0.1:443", "149.154.167.220", 200},
+		{"10.0.0.1:443", "149.154.167.220, 10.0.0.1", 200},
+		{"10.0.0.1:443", "149.154.167.220, 1.2.3.4", 403},
+		{"10.0.0.1:443", "", 403},
+	}
+	for i, test := range tests {
+		body := []byte(`{"update_id":` + strconv.Itoa(i) + `}`)
+		req := httptest.NewRequest("POST", "/hook", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = test.remote
+		if test.forwarded != "" {
+			req.Header.Set("X-Forwarded-For", test.forwarded)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != test.code {
+			t.Errorf("case %d : expected %d, got %d", i, test.code, rec.Code)
+		}
+	}
+	body := []byte(`{"update_id":1,"inline_query":{"id":"1","query":"` + string(bytes.Repeat([]byte("q"), 100)) + `"}}`)
+	req := httptest.NewRequest("POST", "/hook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "149.154.167.220:443"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != 413 {
+		t.Error("large body was not rejected :", rec.Code)
+	}
+	cfg.WebHookConfigs.AllowedSubnets = []string{"invalid"}
+	if bai, err = CreateInterface(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if bai.StartWebHook() == nil {
+		t.Error("invalid subnet was accepted")
+	}
+}
+
+func TestRespondInWebhook(t *testing.T) {
+	if logger.Logger == nil {
+		logger.Logger = log.New(io.Discard, "", 0)
+	}
+	cfg := cfgs.Default("respond")
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com/hook", ExternalServer: true, ResponseTimeout: 5 * time.Second}
+	bai, err := CreateInterface(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bai.StartWebHook(); err != nil {
+		t.Fatal(err)
+	}
+	defer bai.StopWebHook(context.Background())
+	_ = bai.GetParser().AddUpdateHandler("inline_query", "", func(update *objs.Update, _ *up.Match) {
+		if !bai.RespondInWebhook(update, "answerInlineQuery", &objs.AnswerInlineQueryArgs{InlineQueryId: update.InlineQuery.Id}) {
+			t.Error("response was not set")
+		}
+		if bai.RespondInWebhook(update, "answerInlineQuery", &objs.AnswerInlineQueryArgs{InlineQueryId: update.InlineQuery.Id}) {
+			t.Error("response was set twice")
+		}
+	})
+	rec := postTestUpdate(bai.WebhookHandler(), "/hook", 7)
+	if rec.Code != 200 || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatal("wrong response :", rec.Code, rec.Header())
+	}
+	res := make(map[string]interface{})
+	if err = json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res["method"] != "answerInlineQuery" || res["inline_query_id"] != "1" {
+		t.Error("wrong response body :", rec.Body.String())
+	}
+	if bai.RespondInWebhook(&objs.Update{Update_id: 7}, "answerInlineQuery", &objs.AnswerInlineQueryArgs{}) {
+		t.Error("response was set after the request was answered")
+	}
+}
+
+func postTestUpdate(handler http.Handler, path string, id int) *httptest.ResponseRecorder {
+	body := []byte(`{"update_id":` + strconv.Itoa(id) + `,"inline_query":{"id":"1","query":"q"}}`)
+	req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+//createTestCertificate writes a self signed certificate for 127.0.0.1 and its key into a temp directory.
+func createTestCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "127.0.0.1"}, IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}